@@ -9,7 +9,13 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/handler"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/mailer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/rates"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/scheduler"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
 
 	// Swagger
@@ -22,10 +28,20 @@ import (
 
 	// Импорты моделей для явного использования, если потребуется
 	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/budget"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/common"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/networth"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/rate"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/recurrence"
 	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
 	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/response"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/rule"
 	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
-	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	_ "github.com/stepanpotapov/moneyflow-go-backend/internal/models/transfer"
 )
 
 // @title MoneyFlow API
@@ -53,31 +69,260 @@ func main() {
 	}
 	defer pool.Close()
 
+	// Параметры выпуска и проверки JWT
+	jwtConfig := token.JWTConfig{
+		Secret:   os.Getenv("JWT_SECRET"),
+		Issuer:   getEnv("JWT_ISSUER", "moneyflow"),
+		Audience: getEnv("JWT_AUDIENCE", "moneyflow-api"),
+	}
+
 	// Инициализируем репозитории, сервисы и обработчики
 	repo := repository.NewUserRepository(pool)
 	refreshRepo := repository.NewRefreshTokenRepository(pool)
-	authService := service.NewAuthService(repo, refreshRepo, os.Getenv("JWT_SECRET"))
+
+	// --- отправка писем: через SMTP, если задан SMTP_HOST, иначе письма пишутся в MAIL_FILE или в stdout ---
+	var mail mailer.Mailer
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		smtpMailer, err := mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     host,
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("MAIL_FROM", "MoneyFlow <no-reply@moneyflow.local>"),
+		})
+		if err != nil {
+			log.Fatalf("Некорректный MAIL_FROM: %v", err)
+		}
+		mail = smtpMailer
+	} else if path := os.Getenv("MAIL_FILE"); path != "" {
+		mailFile, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			log.Fatalf("Не удалось открыть MAIL_FILE: %v", err)
+		}
+		defer mailFile.Close()
+		mail = mailer.NewLogMailer(mailFile)
+	} else {
+		mail = mailer.NewLogMailer(os.Stdout)
+	}
+
+	// --- подтверждение email: UNVERIFIED_ACCESS задаёт ограничения до подтверждения ---
+	unverifiedAccess := getEnv("UNVERIFIED_ACCESS", user.UnverifiedAccessReadOnly)
+	if !user.ValidUnverifiedAccess(unverifiedAccess) {
+		log.Fatalf("Некорректный UNVERIFIED_ACCESS: %q", unverifiedAccess)
+	}
+	emailVerificationRepo := repository.NewEmailVerificationRepository(pool)
+	emailVerificationService := service.NewEmailVerificationService(repo, emailVerificationRepo, mail, getEnv("EMAIL_VERIFY_URL", "http://localhost:3000/verify-email"))
+	emailVerificationHandler := handler.NewEmailVerificationHandler(emailVerificationService)
+
+	authService := service.NewAuthService(repo, refreshRepo, emailVerificationService, jwtConfig, unverifiedAccess)
 	authHandler := handler.NewAuthHandler(authService)
 
+	// --- восстановление доступа ---
+	passwordResetRepo := repository.NewPasswordResetRepository(pool)
+	passwordResetService := service.NewPasswordResetService(repo, passwordResetRepo, mail, getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password"))
+	passwordResetHandler := handler.NewPasswordResetHandler(passwordResetService)
+
+	// --- курсы валют: загружаются из файла RATES_FILE, если он задан ---
+	var rateProvider rates.Provider
+	if path := os.Getenv("RATES_FILE"); path != "" {
+		rateProvider = rates.NewCSVProvider(path)
+	}
+	rateRepo := repository.NewRateRepository(pool)
+	rateService := service.NewRateService(rateRepo, getEnv("RATES_BASE", "RUB"), rateProvider)
+	rateHandler := handler.NewRateHandler(rateService)
+
 	// --- банковские аккаунты ---
 	bankAccountRepo := repository.NewBankAccountRepository(pool)
-	bankAccountService := service.NewBankAccountService(bankAccountRepo)
-	bankAccountHandler := handler.NewBankAccountHandler(bankAccountService, os.Getenv("JWT_SECRET"))
+	bankAccountService := service.NewBankAccountService(bankAccountRepo, repo, rateService)
+	bankAccountHandler := handler.NewBankAccountHandler(bankAccountService)
+
+	// --- капитал и снимки балансов ---
+	snapshotRepo := repository.NewSnapshotRepository(pool)
+	netWorthService := service.NewNetWorthService(snapshotRepo, repo, bankAccountService, rateService)
+	netWorthHandler := handler.NewNetWorthHandler(netWorthService)
+
+	// --- категории доходов и расходов ---
+	categoryRepo := repository.NewCategoryRepository(pool)
+	categoryService := service.NewCategoryService(categoryRepo)
+	categoryHandler := handler.NewCategoryHandler(categoryService)
+
+	// --- операции по аккаунтам и правила их категоризации ---
+	transactionRepo := repository.NewTransactionRepository(pool)
+	ruleRepo := repository.NewRuleRepository(pool)
+	transactionService := service.NewTransactionService(transactionRepo, bankAccountRepo, categoryRepo, ruleRepo)
+	transactionHandler := handler.NewTransactionHandler(transactionService)
+	ruleService := service.NewRuleService(ruleRepo, categoryRepo, bankAccountRepo, transactionRepo)
+	ruleHandler := handler.NewRuleHandler(ruleService)
+
+	// --- бюджеты ---
+	budgetRepo := repository.NewBudgetRepository(pool)
+	budgetService := service.NewBudgetService(budgetRepo, categoryRepo)
+	budgetHandler := handler.NewBudgetHandler(budgetService)
+
+	// --- импорт банковских выписок ---
+	importRepo := repository.NewImportRepository(pool)
+	importService := service.NewImportService(importRepo, bankAccountRepo, transactionRepo, ruleRepo, categoryRepo)
+	importHandler := handler.NewImportHandler(importService)
+
+	// --- регулярные операции ---
+	recurrenceRepo := repository.NewRecurrenceRepository(pool)
+	recurrenceService := service.NewRecurrenceService(recurrenceRepo, bankAccountRepo, categoryRepo)
+	recurrenceHandler := handler.NewRecurrenceHandler(recurrenceService)
+
+	// --- переводы между аккаунтами ---
+	transferRepo := repository.NewTransferRepository(pool)
+	transferService := service.NewTransferService(transferRepo)
+	transferHandler := handler.NewTransferHandler(transferService)
+
+	// Фоновые задачи: первый запуск сразу после старта догоняет платежи, пропущенные за время простоя
+	schedulerInterval, err := time.ParseDuration(getEnv("SCHEDULER_INTERVAL", "15m"))
+	if err != nil || schedulerInterval <= 0 {
+		log.Fatalf("Некорректный SCHEDULER_INTERVAL: %q", os.Getenv("SCHEDULER_INTERVAL"))
+	}
+	jobs := scheduler.New(
+		scheduler.Job{Name: "exchange-rates", Interval: schedulerInterval, Run: rateService.Sync},
+		scheduler.Job{Name: "recurrences", Interval: schedulerInterval, Run: recurrenceService.PostDue},
+		scheduler.Job{Name: "balance-snapshots", Interval: schedulerInterval, Run: netWorthService.Capture},
+		scheduler.Job{Name: "account-purge", Interval: schedulerInterval, Run: bankAccountService.PurgeDeleted},
+		scheduler.Job{Name: "password-reset-purge", Interval: schedulerInterval, Run: passwordResetService.PurgeExpired},
+		scheduler.Job{Name: "email-verification-purge", Interval: schedulerInterval, Run: emailVerificationService.PurgeExpired},
+	)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobs.Start(jobsCtx)
 
 	// Создаём новый роутер Gin с логированием и обработкой паник
 	r := gin.New()
 	r.Use(gin.Logger())
 	r.Use(gin.Recovery())
+	r.Use(middleware.HandleErrors())
 
-	// Регистрируем маршруты для регистрации, логина и логаута
+	// Регистрируем маршруты для регистрации, логина, обновления токенов и логаута
 	r.POST("/register", authHandler.Register)
 	r.POST("/login", authHandler.Login)
+	r.POST("/refresh", authHandler.Refresh)
 	r.POST("/logout", authHandler.Logout)
 
-	// Банковские аккаунты (требуют авторизации)
-	r.POST("/accounts", bankAccountHandler.CreateBankAccount)
-	r.PUT("/accounts/:id", bankAccountHandler.UpdateBankAccount)
-	r.DELETE("/accounts/:id", bankAccountHandler.DeleteBankAccount)
+	// Восстановление доступа по ссылке из письма
+	r.POST("/password/forgot", passwordResetHandler.ForgotPassword)
+	r.POST("/password/reset", passwordResetHandler.ResetPassword)
+
+	// Подтверждение email по ссылке из письма
+	r.POST("/email/verify", emailVerificationHandler.VerifyEmail)
+	r.POST("/email/verify/resend", emailVerificationHandler.ResendVerification)
+
+	// Справочник валют ISO 4217
+	r.GET("/currencies", rateHandler.ListCurrencies)
+
+	// Маршруты, требующие авторизации по access токену
+	protected := r.Group("/", middleware.Authenticate(jwtConfig))
+	canWrite := middleware.RequireScope(token.ScopeWrite)
+
+	// Управление сессиями
+	protected.GET("/sessions", authHandler.ListSessions)
+	protected.DELETE("/sessions/:id", authHandler.RevokeSession)
+	protected.DELETE("/sessions", authHandler.LogoutAll)
+
+	// Профиль пользователя
+	protected.GET("/me", authHandler.GetProfile)
+	protected.PUT("/me", canWrite, authHandler.UpdateProfile)
+
+	// Банковские аккаунты
+	accounts := protected.Group("/accounts")
+	accounts.GET("", bankAccountHandler.ListBankAccounts)
+	accounts.GET("/totals", bankAccountHandler.GetAccountTotals)
+	accounts.GET("/deleted", bankAccountHandler.ListDeletedBankAccounts)
+	accounts.GET("/:id", bankAccountHandler.GetBankAccount)
+	accounts.POST("", canWrite, bankAccountHandler.CreateBankAccount)
+	accounts.PUT("/:id", canWrite, bankAccountHandler.UpdateBankAccount)
+	accounts.PATCH("/:id", canWrite, bankAccountHandler.PatchBankAccount)
+	accounts.DELETE("/:id", canWrite, bankAccountHandler.DeleteBankAccount)
+	accounts.POST("/:id/restore", canWrite, bankAccountHandler.RestoreBankAccount)
+	accounts.POST("/:id/archive", canWrite, bankAccountHandler.ArchiveBankAccount)
+	accounts.POST("/:id/unarchive", canWrite, bankAccountHandler.UnarchiveBankAccount)
+
+	// Капитал и его история
+	protected.GET("/networth", netWorthHandler.GetNetWorth)
+	protected.GET("/networth/history", netWorthHandler.GetNetWorthHistory)
+
+	// Курсы валют и пересчёт сумм
+	protected.GET("/rates", rateHandler.ListRates)
+	protected.GET("/rates/convert", rateHandler.Convert)
+
+	// Категории доходов и расходов
+	categories := protected.Group("/categories")
+	categories.GET("", categoryHandler.ListCategories)
+	categories.GET("/:id", categoryHandler.GetCategory)
+	categories.POST("", canWrite, categoryHandler.CreateCategory)
+	categories.PUT("/:id", canWrite, categoryHandler.UpdateCategory)
+	categories.DELETE("/:id", canWrite, categoryHandler.DeleteCategory)
+	categories.POST("/:id/merge", canWrite, categoryHandler.MergeCategory)
+
+	// Операции по аккаунтам
+	transactions := protected.Group("/transactions")
+	transactions.GET("", transactionHandler.ListTransactions)
+	transactions.GET("/duplicates", transactionHandler.ListDuplicates)
+	transactions.GET("/merges", transactionHandler.ListMerges)
+	transactions.GET("/:id", transactionHandler.GetTransaction)
+	transactions.POST("", canWrite, transactionHandler.CreateTransaction)
+	transactions.PUT("/:id", canWrite, transactionHandler.UpdateTransaction)
+	transactions.DELETE("/:id", canWrite, transactionHandler.DeleteTransaction)
+	transactions.POST("/:id/merge", canWrite, transactionHandler.MergeTransaction)
+
+	// Правила автоматической категоризации операций
+	rules := protected.Group("/rules")
+	rules.GET("", ruleHandler.ListRules)
+	rules.GET("/:id", ruleHandler.GetRule)
+	rules.POST("", canWrite, ruleHandler.CreateRule)
+	rules.PUT("/order", canWrite, ruleHandler.ReorderRules)
+	rules.POST("/apply", canWrite, ruleHandler.ApplyRules)
+	rules.PUT("/:id", canWrite, ruleHandler.UpdateRule)
+	rules.DELETE("/:id", canWrite, ruleHandler.DeleteRule)
+
+	// Регулярные операции и платежи по расписанию
+	recurrences := protected.Group("/recurrences")
+	recurrences.GET("", recurrenceHandler.ListRecurrences)
+	recurrences.GET("/occurrences", recurrenceHandler.ListOccurrences)
+	recurrences.GET("/:id", recurrenceHandler.GetRecurrence)
+	recurrences.GET("/:id/upcoming", recurrenceHandler.GetUpcoming)
+	recurrences.POST("", canWrite, recurrenceHandler.CreateRecurrence)
+	recurrences.PUT("/:id", canWrite, recurrenceHandler.UpdateRecurrence)
+	recurrences.DELETE("/:id", canWrite, recurrenceHandler.DeleteRecurrence)
+	recurrences.POST("/occurrences/:id/confirm", canWrite, recurrenceHandler.ConfirmOccurrence)
+	recurrences.POST("/occurrences/:id/skip", canWrite, recurrenceHandler.SkipOccurrence)
+
+	// Переводы между своими аккаунтами
+	transfers := protected.Group("/transfers")
+	transfers.GET("", transferHandler.ListTransfers)
+	transfers.GET("/:id", transferHandler.GetTransfer)
+	transfers.POST("", canWrite, transferHandler.CreateTransfer)
+	transfers.DELETE("/:id", canWrite, transferHandler.DeleteTransfer)
+
+	// Бюджеты
+	budgets := protected.Group("/budgets")
+	budgets.GET("", budgetHandler.ListBudgets)
+	budgets.GET("/:id", budgetHandler.GetBudget)
+	budgets.GET("/:id/progress", budgetHandler.GetBudgetProgress)
+	budgets.POST("", canWrite, budgetHandler.CreateBudget)
+	budgets.PUT("/:id", canWrite, budgetHandler.UpdateBudget)
+	budgets.DELETE("/:id", canWrite, budgetHandler.DeleteBudget)
+
+	// Импорт банковских выписок: предпросмотр и подтверждение
+	imps := protected.Group("/imports")
+	imps.GET("/profiles", importHandler.ListImportProfiles)
+	imps.GET("/profiles/:id", importHandler.GetImportProfile)
+	imps.POST("/profiles", canWrite, importHandler.CreateImportProfile)
+	imps.PUT("/profiles/:id", canWrite, importHandler.UpdateImportProfile)
+	imps.DELETE("/profiles/:id", canWrite, importHandler.DeleteImportProfile)
+	imps.GET("", importHandler.ListImports)
+	imps.GET("/:id", importHandler.GetImport)
+	imps.POST("/csv", canWrite, importHandler.ImportCSV)
+	imps.POST("/ofx", canWrite, importHandler.ImportOFX)
+	imps.POST("/qif", canWrite, importHandler.ImportQIF)
+	imps.POST("/camt053", canWrite, importHandler.ImportCAMT053)
+	imps.POST("/mt940", canWrite, importHandler.ImportMT940)
+	imps.POST("/:id/commit", canWrite, importHandler.CommitImport)
+	imps.DELETE("/:id", canWrite, importHandler.DeleteImport)
 
 	// Swagger endpoint
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
@@ -95,3 +340,11 @@ func main() {
 	// Запускаем HTTP сервер на порту 8080
 	r.Run(":8080")
 }
+
+// getEnv возвращает значение переменной окружения или значение по умолчанию, если переменная не задана.
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -17,6 +17,90 @@ const docTemplate = `{
     "basePath": "{{.BasePath}}",
     "paths": {
         "/accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Список банковских аккаунтов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по валюте",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по типу аккаунта: checking, cash, credit_card, savings, loan, investment",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по подстроке названия",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Включать архивные аккаунты",
+                        "name": "include_archived",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только аккаунты с кодом валюты вне справочника ISO 4217",
+                        "name": "invalid_currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сортировка: created_at, name, balance; префикс - для убывания",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (1-100, по умолчанию 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.BankAccountListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
                 "security": [
                     {
@@ -62,50 +146,76 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "409": {
+                        "description": "IBAN уже занят",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/accounts/{id}": {
-            "put": {
+        "/accounts/deleted": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Удалённые банковские аккаунты",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/account.BankAccount"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/totals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Обновить банковский аккаунт",
+                "summary": "Итог по аккаунтам в одной валюте",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID аккаунта",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные аккаунта",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.bankAccountRequest"
-                        }
+                        "type": "string",
+                        "description": "Валюта итога (по умолчанию — домашняя валюта пользователя)",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/account.BankAccount"
+                            "$ref": "#/definitions/account.Totals"
                         }
                     },
                     "400": {
@@ -117,21 +227,26 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизован",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/common.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/accounts/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "accounts"
                 ],
-                "summary": "Удалить банковский аккаунт",
+                "summary": "Получить банковский аккаунт",
                 "parameters": [
                     {
                         "type": "integer",
@@ -145,7 +260,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MessageResponse"
+                            "$ref": "#/definitions/account.BankAccount"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия аккаунта для If-Match"
+                            }
                         }
                     },
                     "400": {
@@ -157,14 +278,23 @@ const docTemplate = `{
                     "401": {
                         "description": "Неавторизован",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/login": {
-            "post": {
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -172,17 +302,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "accounts"
                 ],
-                "summary": "Логин",
+                "summary": "Обновить банковский аккаунт",
                 "parameters": [
                     {
-                        "description": "Данные для входа",
+                        "type": "integer",
+                        "description": "ID аккаунта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag аккаунта, полученный при чтении",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Данные аккаунта",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
+                            "$ref": "#/definitions/request.BankAccountRequest"
                         }
                     }
                 ],
@@ -190,7 +333,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.TokensResponse"
+                            "$ref": "#/definitions/account.BankAccount"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия аккаунта"
+                            }
                         }
                     },
                     "400": {
@@ -198,31 +347,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "IBAN уже занят или у аккаунта есть операции",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Аккаунт изменён на другом устройстве",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/logout": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
                 "tags": [
-                    "auth"
+                    "accounts"
                 ],
-                "summary": "Логаут",
+                "summary": "Удалить банковский аккаунт",
                 "parameters": [
                     {
-                        "description": "Refresh токен для логаута",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.LogoutRequest"
-                        }
+                        "type": "integer",
+                        "description": "ID аккаунта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -237,12 +405,27 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/register": {
-            "post": {
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "consumes": [
                     "application/json"
                 ],
@@ -250,17 +433,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "accounts"
                 ],
-                "summary": "Регистрация",
+                "summary": "Частично обновить банковский аккаунт",
                 "parameters": [
                     {
-                        "description": "Данные для регистрации",
+                        "type": "integer",
+                        "description": "ID аккаунта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag аккаунта, полученный при чтении",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Изменяемые поля аккаунта",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.RegisterRequest"
+                            "$ref": "#/definitions/request.BankAccountRequest"
                         }
                     }
                 ],
@@ -268,7 +464,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.MessageResponse"
+                            "$ref": "#/definitions/account.BankAccount"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия аккаунта"
+                            }
                         }
                     },
                     "400": {
@@ -276,156 +478,5904 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/common.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "IBAN уже занят или у аккаунта есть операции",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Аккаунт изменён на другом устройстве",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "account.BankAccount": {
-            "type": "object",
-            "properties": {
-                "balance": {
-                    "description": "Баланс",
+        },
+        "/accounts/{id}/archive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Перенести банковский аккаунт в архив",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID аккаунта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/account.BankAccount"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Восстановить банковский аккаунт",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID аккаунта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/account.BankAccount"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден или срок восстановления истёк",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/accounts/{id}/unarchive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "accounts"
+                ],
+                "summary": "Вернуть банковский аккаунт из архива",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID аккаунта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/account.BankAccount"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Список бюджетов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/budget.Budget"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Создать бюджет",
+                "parameters": [
+                    {
+                        "description": "Данные бюджета",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BudgetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/budget.Budget"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Получить бюджет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бюджета",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/budget.Budget"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бюджет не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Изменить бюджет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бюджета",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные бюджета",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.BudgetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/budget.Budget"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бюджет или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Удалить бюджет",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бюджета",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бюджет не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/budgets/{id}/progress": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "budgets"
+                ],
+                "summary": "Исполнение бюджета",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID бюджета",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Любая дата внутри периода (YYYY-MM-DD), по умолчанию — сегодня",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/budget.Progress"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Бюджет не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Список категорий",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Фильтр по виду: income, expense",
+                        "name": "kind",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/category.Category"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Создать категорию",
+                "parameters": [
+                    {
+                        "description": "Данные категории",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Родительская категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Категория с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Получить категорию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Изменить категорию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные категории",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/category.Category"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Категория с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Удалить категорию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "categories"
+                ],
+                "summary": "Объединить категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID исходной категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Категория назначения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.CategoryMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Категория с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/currencies": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Справочник валют",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/currency.Currency"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/email/verify": {
+            "post": {
+                "description": "Отмечает email подтверждённым по токену из ссылки. Снятые ограничения действуют со следующего обновления токенов.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Подтверждение email",
+                "parameters": [
+                    {
+                        "description": "Токен из письма",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Недействительный токен",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/email/verify/resend": {
+            "post": {
+                "description": "Отправляет новую ссылку для подтверждения email, действующую сутки. Не чаще раза в 2 минуты и не больше 5 писем в сутки.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Повторная отправка письма для подтверждения email",
+                "parameters": [
+                    {
+                        "description": "Email пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Список импортов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/imports.Batch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/camt053": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Предпросмотр импорта camt.053",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID аккаунта, если счёт в файле один и не найден по IBAN",
+                        "name": "account_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "XML-файл выписки camt.053",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/imports.Batch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/csv": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Предпросмотр импорта CSV",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID банковского аккаунта",
+                        "name": "account_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID профиля импорта",
+                        "name": "profile_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV-файл выписки",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Batch"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт или профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/mt940": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Предпросмотр импорта MT940",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID аккаунта, если счёт в файле один и не найден по IBAN",
+                        "name": "account_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл выписки MT940",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/imports.Batch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/ofx": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Предпросмотр импорта OFX/QFX",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID банковского аккаунта",
+                        "name": "account_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл выписки OFX/QFX",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Batch"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/profiles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Список профилей импорта",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/imports.Profile"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Создать профиль импорта",
+                "parameters": [
+                    {
+                        "description": "Данные профиля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Profile"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Профиль с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/profiles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Получить профиль импорта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID профиля",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Profile"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Изменить профиль импорта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID профиля",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные профиля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ImportProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Profile"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Профиль с таким названием уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Удалить профиль импорта",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID профиля",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Профиль не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/qif": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Предпросмотр импорта QIF",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID банковского аккаунта",
+                        "name": "account_id",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Порядок частей даты: MDY (по умолчанию), DMY, YMD",
+                        "name": "date_order",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Десятичный разделитель: точка (по умолчанию) или запятая",
+                        "name": "decimal_separator",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Кодировка: utf-8 (по умолчанию) или windows-1251",
+                        "name": "encoding",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл выписки QIF",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Batch"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Получить импорт",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID импорта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Batch"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Импорт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Отменить импорт",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID импорта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Импорт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Импорт уже подтверждён",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/{id}/commit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "imports"
+                ],
+                "summary": "Подтвердить импорт",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID импорта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/imports.Batch"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Импорт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Импорт уже подтверждён",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Логин",
+                "parameters": [
+                    {
+                        "description": "Данные для входа",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokensResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный email или пароль",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email не подтверждён (UNVERIFIED_ACCESS=none)",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Логаут",
+                "parameters": [
+                    {
+                        "description": "Refresh токен для логаута",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Профиль пользователя",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProfileResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Изменить профиль",
+                "parameters": [
+                    {
+                        "description": "Новые данные профиля",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ProfileUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ProfileResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networth": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networth"
+                ],
+                "summary": "Текущий капитал",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/account.Totals"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/networth/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "networth"
+                ],
+                "summary": "История капитала",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (YYYY-MM-DD), по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Шаг ряда: day, week, month (по умолчанию day)",
+                        "name": "granularity",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/networth.Series"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
+            "post": {
+                "description": "Отправляет на email одноразовую ссылку для сброса пароля, действующую один час.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Запрос сброса пароля",
+                "parameters": [
+                    {
+                        "description": "Email пользователя",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/reset": {
+            "post": {
+                "description": "Устанавливает новый пароль по токену из ссылки и завершает все сессии пользователя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Сброс пароля",
+                "parameters": [
+                    {
+                        "description": "Токен и новый пароль",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Слабый пароль или недействительный токен",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "История курса валют",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Валюта, курс которой запрашивается",
+                        "name": "base",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Валюта, в которой указан курс",
+                        "name": "quote",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (YYYY-MM-DD), по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rate.Rate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rates/convert": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rates"
+                ],
+                "summary": "Пересчёт суммы между валютами",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Сумма в исходной валюте",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Исходная валюта",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Целевая валюта",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Дата курса (YYYY-MM-DD), по умолчанию сегодня",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rate.Conversion"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Курс не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Список регулярных операций",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recurrence.Recurrence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Создать регулярную операцию",
+                "parameters": [
+                    {
+                        "description": "Данные регулярной операции",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RecurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recurrence.Recurrence"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/occurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Платежи по расписанию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по регулярной операции",
+                        "name": "recurrence_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по статусу: pending, posted, skipped",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recurrence.Occurrence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/occurrences/{id}/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Подтвердить платёж",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Фактические сумма и дата",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.OccurrenceConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Платёж не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Платёж уже обработан",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/occurrences/{id}/skip": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Пропустить платёж",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID платежа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recurrence.Occurrence"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Платёж не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Платёж уже обработан",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Получить регулярную операцию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID регулярной операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recurrence.Recurrence"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Регулярная операция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Изменить регулярную операцию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID регулярной операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные регулярной операции",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RecurrenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/recurrence.Recurrence"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Регулярная операция, аккаунт или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Удалить регулярную операцию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID регулярной операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Регулярная операция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recurrences/{id}/upcoming": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "recurrences"
+                ],
+                "summary": "Предстоящие платежи",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID регулярной операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Количество платежей (1-100, по умолчанию 10)",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/recurrence.Planned"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Регулярная операция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Обновление токенов",
+                "parameters": [
+                    {
+                        "description": "Refresh токен",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TokensResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email не подтверждён (UNVERIFIED_ACCESS=none)",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Создаёт пользователя и отправляет на email ссылку для его подтверждения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Регистрация",
+                "parameters": [
+                    {
+                        "description": "Данные для регистрации",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email уже зарегистрирован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Список правил категоризации",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rule.Rule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Создать правило",
+                "parameters": [
+                    {
+                        "description": "Данные правила",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.Rule"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/apply": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Применить правила к истории операций",
+                "parameters": [
+                    {
+                        "description": "Параметры применения",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RuleApplyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.ApplyResult"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/order": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Изменить порядок правил",
+                "parameters": [
+                    {
+                        "description": "ID всех правил в новом порядке",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RuleOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/rule.Rule"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/rules/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Получить правило",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID правила",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.Rule"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Правило не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Изменить правило",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID правила",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные правила",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rule.Rule"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Правило, аккаунт или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "rules"
+                ],
+                "summary": "Удалить правило",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID правила",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Правило не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Список активных сессий",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.SessionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Выйти на всех устройствах",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sessions"
+                ],
+                "summary": "Завершить сессию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID сессии",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Сессия не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Список операций",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по аккаунту",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по типу: income, expense, adjustment, transfer_in, transfer_out",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по категории (включая подкатегории)",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по тегу",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (1-100, по умолчанию 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransactionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Создать операцию",
+                "parameters": [
+                    {
+                        "description": "Данные операции",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Вероятные дубликаты операций",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по аккаунту",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD), по умолчанию 90 дней назад",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода (YYYY-MM-DD), по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/transaction.Duplicate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/merges": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Журнал объединений операций",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/transaction.Merge"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Получить операцию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Операция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Обновить операцию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные операции",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransactionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Операция или аккаунт не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Удалить операцию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID операции",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Операция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Операция является частью перевода",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transactions/{id}/merge": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transactions"
+                ],
+                "summary": "Объединить дубликаты",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID операции-дубликата, которая будет удалена",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Операция, которая останется",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransactionMergeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Операция не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Список переводов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Фильтр по аккаунту",
+                        "name": "account_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (1-100, по умолчанию 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TransferListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Перевод между аккаунтами",
+                "parameters": [
+                    {
+                        "description": "Данные перевода",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transfer.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Аккаунт принадлежит другому пользователю",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Аккаунт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/transfers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Получить перевод",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID перевода",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/transfer.Transfer"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Перевод не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "tags": [
+                    "transfers"
+                ],
+                "summary": "Отменить перевод",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID перевода",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ошибка",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Неавторизован",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Перевод не найден",
+                        "schema": {
+                            "$ref": "#/definitions/common.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "account.BankAccount": {
+            "type": "object",
+            "properties": {
+                "archivedAt": {
+                    "description": "Дата переноса в архив (null — аккаунт не в архиве)",
+                    "type": "string"
+                },
+                "availableCredit": {
+                    "description": "Доступный остаток лимита: лимит плюс баланс (null, если лимит не задан)",
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "Баланс (в JSON — строка с точностью валюты)",
+                    "type": "string"
+                },
+                "bic": {
+                    "description": "BIC (SWIFT-код) банка (пусто, если не указан)",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "creditLimit": {
+                    "description": "Кредитный лимит (только для кредитных карт)",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Валюта",
+                    "type": "string"
+                },
+                "deletedAt": {
+                    "description": "Дата удаления (null — аккаунт не удалён); до окончательного удаления аккаунт можно восстановить",
+                    "type": "string"
+                },
+                "iban": {
+                    "description": "IBAN счёта в банке (пусто, если не указан); по нему сопоставляются выписки camt.053 и MT940",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор аккаунта",
+                    "type": "integer"
+                },
+                "interestRate": {
+                    "description": "Годовая процентная ставка в процентах (для вкладов и кредитов)",
+                    "type": "string"
+                },
+                "invalidCurrency": {
+                    "description": "Код валюты вне справочника ISO 4217 (аккаунт создан до проверки кодов); исправляется через PUT /accounts/{id}",
+                    "type": "boolean"
+                },
+                "liability": {
+                    "description": "Аккаунт — обязательство (кредитная карта, кредит): долг хранится отрицательным балансом",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Название аккаунта",
+                    "type": "string"
+                },
+                "statementDay": {
+                    "description": "День формирования выписки по карте (1–31; в коротких месяцах — последний день месяца)",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Тип аккаунта: checking, cash, credit_card, savings, loan, investment",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Версия аккаунта: увеличивается при каждом изменении, передаётся в ETag",
+                    "type": "integer"
+                }
+            }
+        },
+        "account.ConvertedBalance": {
+            "type": "object",
+            "properties": {
+                "accountID": {
+                    "description": "ID аккаунта",
+                    "type": "integer"
+                },
+                "balance": {
+                    "description": "Баланс в валюте аккаунта",
+                    "type": "string"
+                },
+                "converted": {
+                    "description": "Баланс в валюте итога (null, если нет курса)",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Валюта аккаунта",
+                    "type": "string"
+                },
+                "liability": {
+                    "description": "Аккаунт — обязательство",
+                    "type": "boolean"
+                },
+                "name": {
+                    "description": "Название аккаунта",
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "Применённый курс (null, если нет курса)",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Тип аккаунта",
+                    "type": "string"
+                }
+            }
+        },
+        "account.Totals": {
+            "type": "object",
+            "properties": {
+                "accounts": {
+                    "description": "Балансы по аккаунтам",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.ConvertedBalance"
+                    }
+                },
+                "assets": {
+                    "description": "Сумма балансов аккаунтов-активов",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Валюта итога",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата курсов пересчёта",
+                    "type": "string"
+                },
+                "liabilities": {
+                    "description": "Долг по аккаунтам-обязательствам (положительное число при отрицательных балансах)",
+                    "type": "string"
+                },
+                "missingRates": {
+                    "description": "Валюты, для которых нет курса; их аккаунты не вошли в Total",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "description": "Капитал: активы минус обязательства",
+                    "type": "string"
+                }
+            }
+        },
+        "budget.Budget": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Валюта; учитываются только операции по аккаунтам в этой валюте",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор бюджета",
+                    "type": "integer"
+                },
+                "limits": {
+                    "description": "Лимиты по категориям",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/budget.Limit"
+                    }
+                },
+                "name": {
+                    "description": "Название",
+                    "type": "string"
+                },
+                "period": {
+                    "description": "Период: week, month, quarter или year",
+                    "type": "string"
+                },
+                "rollover": {
+                    "description": "Переносить неизрасходованный остаток на следующий период",
+                    "type": "boolean"
+                },
+                "startDate": {
+                    "description": "Начало первого периода",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "budget.CategoryProgress": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "description": "Доступно в периоде: лимит плюс перенесённый остаток",
+                    "type": "string"
+                },
+                "categoryID": {
+                    "description": "ID категории",
+                    "type": "integer"
+                },
+                "limit": {
+                    "description": "Лимит на период",
+                    "type": "string"
+                },
+                "percent": {
+                    "description": "Доля потраченного от доступного в процентах (null, если доступно 0)",
+                    "type": "string"
+                },
+                "remaining": {
+                    "description": "Остаток; отрицательный при перерасходе",
+                    "type": "string"
+                },
+                "rolledOver": {
+                    "description": "Остаток, перенесённый с предыдущих периодов",
+                    "type": "string"
+                },
+                "spent": {
+                    "description": "Потрачено в периоде (с учётом подкатегорий)",
+                    "type": "string"
+                }
+            }
+        },
+        "budget.Limit": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Лимит на период",
+                    "type": "string"
+                },
+                "categoryID": {
+                    "description": "ID категории расходов",
+                    "type": "integer"
+                }
+            }
+        },
+        "budget.Progress": {
+            "type": "object",
+            "properties": {
+                "budgetID": {
+                    "description": "ID бюджета",
+                    "type": "integer"
+                },
+                "categories": {
+                    "description": "Исполнение по категориям",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/budget.CategoryProgress"
+                    }
+                },
+                "currency": {
+                    "description": "Валюта бюджета",
+                    "type": "string"
+                },
+                "periodEnd": {
+                    "description": "Конец периода включительно",
+                    "type": "string"
+                },
+                "periodStart": {
+                    "description": "Начало периода",
+                    "type": "string"
+                }
+            }
+        },
+        "category.Category": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор категории",
+                    "type": "integer"
+                },
+                "kind": {
+                    "description": "Вид: income или expense",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Название",
+                    "type": "string"
+                },
+                "parentID": {
+                    "description": "ID родительской категории (nil для категории верхнего уровня)",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "common.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Машиночитаемый код ошибки, например account_not_found",
+                    "type": "string"
+                },
+                "fields": {
+                    "description": "Ошибки отдельных полей запроса (только для ошибок валидации)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/common.FieldError"
+                    }
+                },
+                "message": {
+                    "description": "Сообщение с деталями ошибки",
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "HTTP статус ошибки",
+                    "type": "integer"
+                }
+            }
+        },
+        "common.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Имя поля в запросе (ключ JSON или параметр запроса)",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Описание ошибки",
+                    "type": "string"
+                }
+            }
+        },
+        "currency.Currency": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Буквенный код ISO 4217",
+                    "type": "string"
+                },
+                "minorUnits": {
+                    "description": "Количество знаков после запятой",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Название",
+                    "type": "string"
+                },
+                "symbol": {
+                    "description": "Символ для отображения",
+                    "type": "string"
+                }
+            }
+        },
+        "imports.Balance": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Сумма",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата, на которую указан баланс",
+                    "type": "string"
+                }
+            }
+        },
+        "imports.BalanceCheck": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Дата баланса по выписке",
+                    "type": "string"
+                },
+                "difference": {
+                    "description": "Разница: выписка минус расчёт",
+                    "type": "string"
+                },
+                "expected": {
+                    "description": "Баланс аккаунта на эту дату после импорта",
+                    "type": "string"
+                },
+                "matches": {
+                    "description": "Балансы совпадают",
+                    "type": "boolean"
+                },
+                "statement": {
+                    "description": "Баланс по выписке",
+                    "type": "string"
+                }
+            }
+        },
+        "imports.Batch": {
+            "type": "object",
+            "properties": {
+                "accountID": {
+                    "description": "ID банковского аккаунта",
+                    "type": "integer"
+                },
+                "balanceCheck": {
+                    "description": "Сверка итогового баланса выписки с аккаунтом",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/imports.BalanceCheck"
+                        }
+                    ]
+                },
+                "closingBalance": {
+                    "description": "Итоговый (ledger) баланс по выписке, если он в ней есть",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/imports.Balance"
+                        }
+                    ]
+                },
+                "committedAt": {
+                    "description": "Дата подтверждения (nil для preview)",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "description": "Дата загрузки",
+                    "type": "string"
+                },
+                "fileName": {
+                    "description": "Имя загруженного файла",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Формат выписки",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор пакета",
+                    "type": "integer"
+                },
+                "openingBalance": {
+                    "description": "Входящий баланс по выписке, если он в ней есть",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/imports.Balance"
+                        }
+                    ]
+                },
+                "openingBalanceCheck": {
+                    "description": "Сверка входящего баланса с балансом аккаунта перед первой операцией выписки",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/imports.BalanceCheck"
+                        }
+                    ]
+                },
+                "rows": {
+                    "description": "Строки выписки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/imports.Row"
+                    }
+                },
+                "status": {
+                    "description": "Статус: preview или committed",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.Profile": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "description": "Роль колонки → номер колонки (с 0)",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "dateFormat": {
+                    "description": "Формат даты, например DD.MM.YYYY",
+                    "type": "string"
+                },
+                "decimalSeparator": {
+                    "description": "Десятичный разделитель: точка или запятая",
+                    "type": "string"
+                },
+                "delimiter": {
+                    "description": "Разделитель полей",
+                    "type": "string"
+                },
+                "encoding": {
+                    "description": "Кодировка файла",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор профиля",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "Название профиля",
+                    "type": "string"
+                },
+                "signConvention": {
+                    "description": "Соглашение о знаке суммы",
+                    "type": "string"
+                },
+                "skipRows": {
+                    "description": "Сколько строк пропустить в начале файла (заголовок, шапка выписки)",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "imports.Row": {
+            "type": "object",
+            "properties": {
+                "alreadyImported": {
+                    "description": "Операция с таким ExternalID уже есть на аккаунте — строка будет пропущена",
+                    "type": "boolean"
+                },
+                "amount": {
+                    "description": "Сумма со знаком: положительная — поступление, отрицательная — расход",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата операции",
+                    "type": "string"
+                },
+                "duplicateOf": {
+                    "description": "Вероятный дубликат: ID похожей операции на аккаунте (строка всё равно будет импортирована)",
+                    "type": "integer"
+                },
+                "duplicateScore": {
+                    "description": "Оценка сходства с DuplicateOf от 0 до 1",
                     "type": "number"
                 },
-                "createdAt": {
-                    "description": "Дата создания",
+                "error": {
+                    "description": "Ключ сообщения об ошибке разбора строки в каталогах i18n (пусто, если строка корректна)",
+                    "type": "string"
+                },
+                "externalID": {
+                    "description": "Идентификатор операции в банке (FITID); пусто, если формат его не содержит",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "Номер строки в файле (с 1)",
+                    "type": "integer"
+                },
+                "note": {
+                    "description": "Назначение платежа / комментарий",
+                    "type": "string"
+                },
+                "payee": {
+                    "description": "Контрагент",
+                    "type": "string"
+                },
+                "transactionID": {
+                    "description": "ID созданной операции (после подтверждения)",
+                    "type": "integer"
+                }
+            }
+        },
+        "networth.Point": {
+            "type": "object",
+            "properties": {
+                "assets": {
+                    "description": "Сумма балансов аккаунтов-активов",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата точки",
+                    "type": "string"
+                },
+                "liabilities": {
+                    "description": "Долг по аккаунтам-обязательствам",
+                    "type": "string"
+                },
+                "missingRates": {
+                    "description": "Валюты, для которых на эту дату нет курса; их аккаунты не вошли в Total",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "total": {
+                    "description": "Капитал: активы минус обязательства",
+                    "type": "string"
+                }
+            }
+        },
+        "networth.Series": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Валюта ряда (домашняя валюта пользователя)",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "Начало периода",
+                    "type": "string"
+                },
+                "granularity": {
+                    "description": "Шаг ряда",
+                    "type": "string"
+                },
+                "points": {
+                    "description": "Точки ряда по возрастанию даты",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/networth.Point"
+                    }
+                },
+                "to": {
+                    "description": "Конец периода",
+                    "type": "string"
+                }
+            }
+        },
+        "rate.Conversion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Исходная сумма",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата, на которую выполнен пересчёт",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "Исходная валюта",
+                    "type": "string"
+                },
+                "path": {
+                    "description": "Валюты пересчёта: [From, To] — прямой или обратный курс, [From, база, To] — кросс-курс",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rate": {
+                    "description": "Применённый курс: 1 единица From в To",
+                    "type": "string"
+                },
+                "rateDate": {
+                    "description": "Дата самого старого из использованных курсов (курс берётся на последний день до Date)",
+                    "type": "string"
+                },
+                "result": {
+                    "description": "Сумма в целевой валюте с точностью её валюты",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Целевая валюта",
+                    "type": "string"
+                }
+            }
+        },
+        "rate.Rate": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "description": "Валюта, курс которой указан",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата курса",
+                    "type": "string"
+                },
+                "quote": {
+                    "description": "Валюта, в которой указан курс",
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "Стоимость 1 единицы Base в Quote",
+                    "type": "string"
+                },
+                "source": {
+                    "description": "Провайдер, загрузивший курс",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Дата загрузки",
+                    "type": "string"
+                }
+            }
+        },
+        "recurrence.Occurrence": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Плановая дата по расписанию",
+                    "type": "string"
+                },
+                "dueDate": {
+                    "description": "Дата платежа с учётом переноса с выходных",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор",
+                    "type": "integer"
+                },
+                "recurrenceID": {
+                    "description": "ID регулярной операции",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Статус: pending, posted или skipped",
+                    "type": "string"
+                },
+                "transactionID": {
+                    "description": "ID созданной операции",
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "recurrence.Planned": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Плановая дата по расписанию",
+                    "type": "string"
+                },
+                "dueDate": {
+                    "description": "Дата платежа с учётом переноса с выходных",
+                    "type": "string"
+                }
+            }
+        },
+        "recurrence.Recurrence": {
+            "type": "object",
+            "properties": {
+                "accountID": {
+                    "description": "ID банковского аккаунта",
+                    "type": "integer"
+                },
+                "active": {
+                    "description": "Расписание включено",
+                    "type": "boolean"
+                },
+                "amount": {
+                    "description": "Положительная сумма операции",
+                    "type": "string"
+                },
+                "businessDay": {
+                    "description": "Перенос с выходных: none, previous или next",
+                    "type": "string"
+                },
+                "categoryID": {
+                    "description": "ID категории (nil — без категории)",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Валюта (валюта аккаунта)",
+                    "type": "string"
+                },
+                "endDate": {
+                    "description": "Дата окончания включительно (nil — бессрочно)",
+                    "type": "string"
+                },
+                "frequency": {
+                    "description": "Частота: daily, weekly, monthly или yearly",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор",
+                    "type": "integer"
+                },
+                "interval": {
+                    "description": "Шаг повторения в единицах частоты",
+                    "type": "integer"
+                },
+                "mode": {
+                    "description": "Режим: auto или suggest",
+                    "type": "string"
+                },
+                "monthDay": {
+                    "description": "День месяца для monthly и yearly: 1-31, -1 — последний день, 0 — день даты начала",
+                    "type": "integer"
+                },
+                "nextDate": {
+                    "description": "Плановая дата следующего платежа (nil — расписание завершено)",
+                    "type": "string"
+                },
+                "nextDue": {
+                    "description": "Дата следующего платежа с учётом переноса с выходных",
+                    "type": "string"
+                },
+                "note": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "payee": {
+                    "description": "Контрагент",
+                    "type": "string"
+                },
+                "startDate": {
+                    "description": "Дата начала",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "Тип операции: income или expense",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
+                    "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.BankAccountRequest": {
+            "type": "object",
+            "required": [
+                "currency",
+                "name"
+            ],
+            "properties": {
+                "balance": {
+                    "description": "Строка или число, без потерь точности",
+                    "type": "string",
+                    "example": "1500.50"
+                },
+                "bic": {
+                    "description": "Необязательно",
+                    "type": "string",
+                    "example": "COBADEFFXXX"
+                },
+                "credit_limit": {
+                    "description": "Кредитный лимит (только credit_card)",
+                    "type": "string",
+                    "example": "100000.00"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "iban": {
+                    "description": "Необязательно; пробелы допускаются",
+                    "type": "string",
+                    "example": "DE89 3704 0044 0532 0130 00"
+                },
+                "interest_rate": {
+                    "description": "Годовая ставка в процентах (только savings и loan)",
+                    "type": "string",
+                    "example": "12.5"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "statement_day": {
+                    "description": "День формирования выписки, 1–31 (только credit_card)",
+                    "type": "integer",
+                    "example": 25
+                },
+                "type": {
+                    "description": "checking, cash, credit_card, savings, loan, investment; по умолчанию checking",
+                    "type": "string",
+                    "example": "credit_card"
+                }
+            }
+        },
+        "request.BudgetLimitRequest": {
+            "type": "object",
+            "required": [
+                "category_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Лимит на период",
+                    "type": "string",
+                    "example": "15000.00"
+                },
+                "category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "request.BudgetRequest": {
+            "type": "object",
+            "required": [
+                "currency",
+                "limits",
+                "name",
+                "period",
+                "start_date"
+            ],
+            "properties": {
+                "currency": {
+                    "description": "Валюта бюджета",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "limits": {
+                    "description": "Лимиты по категориям расходов",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/request.BudgetLimitRequest"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Расходы на жизнь"
+                },
+                "period": {
+                    "description": "Период бюджета",
+                    "type": "string",
+                    "enum": [
+                        "week",
+                        "month",
+                        "quarter",
+                        "year"
+                    ],
+                    "example": "month"
+                },
+                "rollover": {
+                    "description": "Переносить неизрасходованный остаток",
+                    "type": "boolean"
+                },
+                "start_date": {
+                    "description": "Начало первого периода (YYYY-MM-DD)",
+                    "type": "string",
+                    "example": "2024-05-01"
+                }
+            }
+        },
+        "request.CategoryMergeRequest": {
+            "type": "object",
+            "required": [
+                "target_id"
+            ],
+            "properties": {
+                "target_id": {
+                    "description": "ID категории, в которую переносятся операции и подкатегории",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.CategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "kind": {
+                    "description": "income — доходы, expense — расходы",
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ],
+                    "example": "expense"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Продукты"
+                },
+                "parent_id": {
+                    "description": "ID родительской категории (null — верхний уровень)",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ImportProfileRequest": {
+            "type": "object",
+            "required": [
+                "columns",
+                "date_format",
+                "name",
+                "sign_convention"
+            ],
+            "properties": {
+                "columns": {
+                    "description": "Роль → номер колонки с 0: date, amount, debit, credit, payee, note",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "date_format": {
+                    "description": "Формат даты из токенов DD, MM, YYYY/YY",
+                    "type": "string",
+                    "example": "DD.MM.YYYY"
+                },
+                "decimal_separator": {
+                    "description": "Точка (по умолчанию) или запятая",
+                    "type": "string",
+                    "example": ","
+                },
+                "delimiter": {
+                    "description": "Разделитель полей (по умолчанию запятая)",
+                    "type": "string",
+                    "example": ";"
+                },
+                "encoding": {
+                    "description": "utf-8 (по умолчанию) или windows-1251",
+                    "type": "string",
+                    "example": "windows-1251"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Выписка Сбербанка"
+                },
+                "sign_convention": {
+                    "description": "Соглашение о знаке суммы",
+                    "type": "string",
+                    "enum": [
+                        "signed",
+                        "inverted",
+                        "debit_credit"
+                    ],
+                    "example": "signed"
+                },
+                "skip_rows": {
+                    "description": "Сколько строк пропустить в начале файла",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "request.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "device_label": {
+                    "description": "Необязательное название устройства для списка сессий",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.OccurrenceConfirmRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Фактическая сумма (по умолчанию — из расписания)",
+                    "type": "string",
+                    "example": "3120.50"
+                },
+                "date": {
+                    "description": "Фактическая дата (YYYY-MM-DD, по умолчанию — дата платежа)",
+                    "type": "string",
+                    "example": "2024-06-05"
+                }
+            }
+        },
+        "request.ProfileUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "home_currency": {
+                    "description": "Домашняя валюта (код ISO 4217); пусто — не менять",
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "language": {
+                    "description": "Язык сообщений API: ru, en; \"\" — по Accept-Language, не передан — не менять",
+                    "type": "string",
+                    "example": "en"
+                }
+            }
+        },
+        "request.RecurrenceRequest": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "frequency",
+                "mode",
+                "start_date",
+                "type"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "active": {
+                    "description": "Расписание включено (по умолчанию true)",
+                    "type": "boolean"
+                },
+                "amount": {
+                    "description": "Положительная сумма операции",
+                    "type": "string",
+                    "example": "45000.00"
+                },
+                "business_day": {
+                    "description": "Перенос с выходных (по умолчанию none)",
+                    "type": "string",
+                    "enum": [
+                        "none",
+                        "previous",
+                        "next"
+                    ],
+                    "example": "previous"
+                },
+                "category_id": {
+                    "description": "ID категории того же вида, что и операция",
+                    "type": "integer"
+                },
+                "end_date": {
+                    "description": "Дата окончания включительно (YYYY-MM-DD), пусто — бессрочно",
+                    "type": "string",
+                    "example": "2025-05-31"
+                },
+                "frequency": {
+                    "description": "Частота повторения",
+                    "type": "string",
+                    "enum": [
+                        "daily",
+                        "weekly",
+                        "monthly",
+                        "yearly"
+                    ],
+                    "example": "monthly"
+                },
+                "interval": {
+                    "description": "Шаг повторения (по умолчанию 1)",
+                    "type": "integer",
+                    "maximum": 366,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "mode": {
+                    "description": "auto — создавать операции, suggest — предлагать",
+                    "type": "string",
+                    "enum": [
+                        "auto",
+                        "suggest"
+                    ],
+                    "example": "auto"
+                },
+                "month_day": {
+                    "description": "День месяца для monthly и yearly: -1 — последний, 0 — день даты начала",
+                    "type": "integer",
+                    "maximum": 31,
+                    "minimum": -1,
+                    "example": 5
+                },
+                "note": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "payee": {
+                    "description": "Контрагент",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Арендодатель"
+                },
+                "start_date": {
+                    "description": "Дата начала (YYYY-MM-DD)",
+                    "type": "string",
+                    "example": "2024-06-01"
+                },
+                "tags": {
+                    "description": "Теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "income — поступление, expense — расход",
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ],
+                    "example": "expense"
+                }
+            }
+        },
+        "request.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "home_currency": {
+                    "description": "Домашняя валюта (код ISO 4217, по умолчанию RUB)",
+                    "type": "string",
+                    "example": "RUB"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "description": "Новый пароль",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Токен из ссылки в письме",
+                    "type": "string"
+                }
+            }
+        },
+        "request.RuleApplyRequest": {
+            "type": "object",
+            "properties": {
+                "account_id": {
+                    "description": "Только операции этого аккаунта",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "description": "Только показать изменения, не сохраняя их (по умолчанию true)",
+                    "type": "boolean"
+                },
+                "from": {
+                    "description": "Начало периода (YYYY-MM-DD), по умолчанию — с начала истории",
+                    "type": "string",
+                    "example": "2024-01-01"
+                },
+                "overwrite": {
+                    "description": "Заменять уже установленные категории",
+                    "type": "boolean"
+                },
+                "to": {
+                    "description": "Конец периода (YYYY-MM-DD), по умолчанию — сегодня",
+                    "type": "string",
+                    "example": "2024-12-31"
+                }
+            }
+        },
+        "request.RuleOrderRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "description": "ID всех правил пользователя в новом порядке",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "request.RuleRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "account_id": {
+                    "description": "Условие: аккаунт операции",
+                    "type": "integer"
+                },
+                "category_id": {
+                    "description": "Действие: установить категорию",
+                    "type": "integer"
+                },
+                "enabled": {
+                    "description": "Правило включено (по умолчанию true)",
+                    "type": "boolean"
+                },
+                "max_amount": {
+                    "description": "Условие: максимальная сумма по модулю",
+                    "type": "string",
+                    "example": "5000.00"
+                },
+                "min_amount": {
+                    "description": "Условие: минимальная сумма по модулю",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Такси"
+                },
+                "note_pattern": {
+                    "description": "Условие: регулярное выражение для комментария",
+                    "type": "string"
+                },
+                "payee_pattern": {
+                    "description": "Условие: регулярное выражение для контрагента",
+                    "type": "string",
+                    "example": "yandex.*taxi|uber"
+                },
+                "rename_payee": {
+                    "description": "Действие: заменить контрагента",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Яндекс Такси"
+                },
+                "tags": {
+                    "description": "Действие: добавить теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "транспорт"
+                    ]
+                },
+                "type": {
+                    "description": "Условие: тип операции",
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ]
+                }
+            }
+        },
+        "request.TransactionMergeRequest": {
+            "type": "object",
+            "required": [
+                "target_id"
+            ],
+            "properties": {
+                "target_id": {
+                    "description": "ID операции, которая остаётся после объединения",
+                    "type": "integer"
+                }
+            }
+        },
+        "request.TransactionRequest": {
+            "type": "object",
+            "required": [
+                "account_id",
+                "date",
+                "type"
+            ],
+            "properties": {
+                "account_id": {
+                    "type": "integer"
+                },
+                "amount": {
+                    "description": "Положительная сумма операции",
+                    "type": "string",
+                    "example": "250.00"
+                },
+                "category_id": {
+                    "description": "ID категории того же вида, что и операция",
+                    "type": "integer"
+                },
+                "date": {
+                    "description": "Дата в формате YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2024-05-31"
+                },
+                "note": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "payee": {
+                    "description": "Контрагент",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "tags": {
+                    "description": "Теги операции",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "отпуск"
+                    ]
+                },
+                "type": {
+                    "description": "income — поступление, expense — расход",
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "expense"
+                    ]
+                }
+            }
+        },
+        "request.TransferRequest": {
+            "type": "object",
+            "required": [
+                "date",
+                "from_account_id",
+                "to_account_id"
+            ],
+            "properties": {
+                "amount": {
+                    "description": "Сумма списания",
+                    "type": "string",
+                    "example": "100.00"
+                },
+                "date": {
+                    "description": "Дата в формате YYYY-MM-DD",
+                    "type": "string",
+                    "example": "2024-05-31"
+                },
+                "from_account_id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "Курс (для разных валют)",
+                    "type": "string",
+                    "example": "91.5"
+                },
+                "to_account_id": {
+                    "type": "integer"
+                },
+                "to_amount": {
+                    "description": "Сумма зачисления (для разных валют)",
+                    "type": "string",
+                    "example": "9150.00"
+                }
+            }
+        },
+        "request.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "description": "Токен из ссылки в письме",
+                    "type": "string"
+                }
+            }
+        },
+        "response.BankAccountListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/account.BankAccount"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Пусто, если страниц больше нет",
+                    "type": "string"
+                }
+            }
+        },
+        "response.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ProfileResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "currency": {
-                    "description": "Валюта",
+                "email": {
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "description": "Время подтверждения email; null — email не подтверждён",
+                    "type": "string"
+                },
+                "home_currency": {
+                    "description": "Домашняя валюта: в ней по умолчанию считаются итоги по аккаунтам",
                     "type": "string"
                 },
                 "id": {
-                    "description": "Уникальный идентификатор аккаунта",
                     "type": "integer"
                 },
-                "name": {
-                    "description": "Название аккаунта",
+                "language": {
+                    "description": "Язык сообщений API; пусто — выбирается по Accept-Language",
+                    "type": "string"
+                }
+            }
+        },
+        "response.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "updatedAt": {
-                    "description": "Дата обновления",
+                "current": {
+                    "description": "Сессия, от имени которой выполнен запрос",
+                    "type": "boolean"
+                },
+                "device_label": {
                     "type": "string"
                 },
-                "userID": {
-                    "description": "ID пользователя",
-                    "type": "integer"
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
                 }
             }
         },
-        "common.ErrorResponse": {
+        "response.TokensResponse": {
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "Сообщение с деталями ошибки",
+                "access_token": {
                     "type": "string"
                 },
-                "statusCode": {
-                    "description": "HTTP статус ошибки",
-                    "type": "integer"
+                "refresh_token": {
+                    "type": "string"
                 }
             }
         },
-        "handler.bankAccountRequest": {
+        "response.TransactionListResponse": {
             "type": "object",
-            "required": [
-                "balance",
-                "currency",
-                "name"
-            ],
             "properties": {
-                "balance": {
-                    "description": "Баланс",
-                    "type": "number"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transaction.Transaction"
+                    }
                 },
-                "currency": {
-                    "description": "Валюта",
+                "next_cursor": {
+                    "description": "Пусто, если страниц больше нет",
                     "type": "string"
+                }
+            }
+        },
+        "response.TransferListResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/transfer.Transfer"
+                    }
                 },
-                "name": {
-                    "description": "Название",
+                "next_cursor": {
+                    "description": "Пусто, если страниц больше нет",
                     "type": "string"
                 }
             }
         },
-        "request.BankAccountRequest": {
+        "rule.ApplyResult": {
             "type": "object",
-            "required": [
-                "balance",
-                "currency",
-                "name"
-            ],
             "properties": {
-                "balance": {
-                    "type": "number"
+                "changes": {
+                    "description": "Изменённые операции",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rule.Change"
+                    }
                 },
-                "currency": {
+                "checked": {
+                    "description": "Количество проверенных операций",
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "description": "Изменения только рассчитаны и не сохранены",
+                    "type": "boolean"
+                }
+            }
+        },
+        "rule.Change": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "Значения после применения правил",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rule.Snapshot"
+                        }
+                    ]
+                },
+                "before": {
+                    "description": "Значения до применения правил",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rule.Snapshot"
+                        }
+                    ]
+                },
+                "ruleIDs": {
+                    "description": "Сработавшие правила",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "transactionID": {
+                    "description": "ID операции",
+                    "type": "integer"
+                }
+            }
+        },
+        "rule.Rule": {
+            "type": "object",
+            "properties": {
+                "accountID": {
+                    "description": "Только операции этого аккаунта",
+                    "type": "integer"
+                },
+                "categoryID": {
+                    "description": "Действие: установить категорию",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "enabled": {
+                    "description": "Правило включено",
+                    "type": "boolean"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор правила",
+                    "type": "integer"
+                },
+                "maxAmount": {
+                    "description": "Максимальная сумма операции по модулю включительно",
+                    "type": "string"
+                },
+                "minAmount": {
+                    "description": "Минимальная сумма операции по модулю включительно",
                     "type": "string"
                 },
                 "name": {
+                    "description": "Название",
+                    "type": "string"
+                },
+                "notePattern": {
+                    "description": "Регулярное выражение для комментария (без учёта регистра)",
+                    "type": "string"
+                },
+                "payeePattern": {
+                    "description": "Регулярное выражение для контрагента (без учёта регистра)",
+                    "type": "string"
+                },
+                "position": {
+                    "description": "Порядковый номер (меньше — выше приоритет)",
+                    "type": "integer"
+                },
+                "renamePayee": {
+                    "description": "Действие: заменить контрагента",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Действие: добавить теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "type": {
+                    "description": "Только операции этого типа: income или expense (пусто — любые)",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
                     "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
                 }
             }
         },
-        "request.LoginRequest": {
+        "rule.Snapshot": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "categoryID": {
+                    "description": "ID категории",
+                    "type": "integer"
                 },
-                "password": {
+                "payee": {
+                    "description": "Контрагент",
                     "type": "string"
+                },
+                "tags": {
+                    "description": "Теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "request.LogoutRequest": {
+        "transaction.Duplicate": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string"
+                "duplicate": {
+                    "description": "Вероятный дубликат",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    ]
+                },
+                "score": {
+                    "description": "Оценка сходства от 0 до 1",
+                    "type": "number"
+                },
+                "transaction": {
+                    "description": "Более ранняя по id операция",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    ]
                 }
             }
         },
-        "request.RegisterRequest": {
+        "transaction.Merge": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "createdAt": {
+                    "description": "Дата объединения",
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "id": {
+                    "description": "Уникальный идентификатор записи",
+                    "type": "integer"
+                },
+                "merged": {
+                    "description": "Удалённая операция в том виде, в каком она была до объединения",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/transaction.Transaction"
+                        }
+                    ]
+                },
+                "transactionID": {
+                    "description": "ID оставленной операции (nil, если она позже удалена)",
+                    "type": "integer"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
                 }
             }
         },
-        "response.MessageResponse": {
+        "transaction.Transaction": {
             "type": "object",
             "properties": {
-                "message": {
+                "accountID": {
+                    "description": "ID банковского аккаунта",
+                    "type": "integer"
+                },
+                "amount": {
+                    "description": "Сумма со знаком: положительная — поступление, отрицательная — списание",
+                    "type": "string"
+                },
+                "categoryID": {
+                    "description": "ID категории (nil — без категории)",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "description": "Дата создания",
+                    "type": "string"
+                },
+                "currency": {
+                    "description": "Валюта (валюта аккаунта)",
+                    "type": "string"
+                },
+                "date": {
+                    "description": "Дата операции",
+                    "type": "string"
+                },
+                "externalID": {
+                    "description": "Идентификатор операции в банке (FITID), если операция импортирована из выписки",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор операции",
+                    "type": "integer"
+                },
+                "note": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "payee": {
+                    "description": "Контрагент",
+                    "type": "string"
+                },
+                "tags": {
+                    "description": "Теги",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transferID": {
+                    "description": "ID перевода, если операция является его частью",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Тип операции",
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "description": "Дата обновления",
                     "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
                 }
             }
         },
-        "response.TokensResponse": {
+        "transfer.Transfer": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "createdAt": {
+                    "description": "Дата создания",
                     "type": "string"
                 },
-                "refresh_token": {
+                "date": {
+                    "description": "Дата перевода",
+                    "type": "string"
+                },
+                "fromAccountID": {
+                    "description": "ID аккаунта списания",
+                    "type": "integer"
+                },
+                "fromAmount": {
+                    "description": "Сумма списания в валюте аккаунта списания",
+                    "type": "string"
+                },
+                "fromCurrency": {
+                    "description": "Валюта аккаунта списания",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Уникальный идентификатор перевода",
+                    "type": "integer"
+                },
+                "note": {
+                    "description": "Комментарий",
+                    "type": "string"
+                },
+                "rate": {
+                    "description": "Курс: единиц валюты зачисления за единицу валюты списания",
+                    "type": "string"
+                },
+                "toAccountID": {
+                    "description": "ID аккаунта зачисления",
+                    "type": "integer"
+                },
+                "toAmount": {
+                    "description": "Сумма зачисления в валюте аккаунта зачисления",
+                    "type": "string"
+                },
+                "toCurrency": {
+                    "description": "Валюта аккаунта зачисления",
                     "type": "string"
+                },
+                "userID": {
+                    "description": "ID пользователя",
+                    "type": "integer"
                 }
             }
         }
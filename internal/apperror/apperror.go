@@ -0,0 +1,160 @@
+// Package apperror описывает типизированные ошибки предметной области. Сервисы возвращают *Error,
+// а middleware.HandleErrors превращает его в ответ с HTTP статусом по виду ошибки, стабильным кодом
+// и сообщением на языке запроса.
+package apperror
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/common"
+)
+
+// Kind — вид ошибки, определяющий HTTP статус ответа.
+type Kind string
+
+// Виды ошибок.
+const (
+	KindValidation         Kind = "validation"          // Некорректные данные запроса (400)
+	KindUnauthorized       Kind = "unauthorized"        // Не пройдена аутентификация (401)
+	KindForbidden          Kind = "forbidden"           // Нет прав на действие или ресурс (403)
+	KindNotFound           Kind = "not_found"           // Ресурс не найден (404)
+	KindConflict           Kind = "conflict"            // Конфликт с текущим состоянием ресурса (409)
+	KindPreconditionFailed Kind = "precondition_failed" // Не выполнено условие запроса, например If-Match (412)
+	KindInternal           Kind = "internal"            // Внутренняя ошибка сервера (500)
+)
+
+// Коды общих ошибок.
+const (
+	CodeValidationFailed = "validation_failed" // Ошибка валидации без более точного кода
+	CodeInternal         = "internal_error"    // Внутренняя ошибка сервера
+)
+
+// Error — ошибка предметной области с видом, машиночитаемым кодом и ключом сообщения для клиента.
+// Сообщение переводится на язык запроса при ответе; у ошибок с собственным кодом ключ совпадает с кодом.
+type Error struct {
+	Kind   Kind         // Вид ошибки
+	Code   string       // Машиночитаемый код, например account_not_found
+	Key    string       // Ключ сообщения в каталогах i18n
+	Args   []any        // Аргументы сообщения
+	Fields []FieldError // Ошибки отдельных полей (для ошибок валидации)
+	Err    error        // Исходная ошибка (для внутренних ошибок); клиенту не показывается
+}
+
+// FieldError описывает ошибку в значении одного поля запроса.
+type FieldError struct {
+	Field string // Имя поля в запросе (ключ JSON или параметр запроса)
+	Key   string // Ключ сообщения в каталогах i18n
+	Args  []any  // Аргументы сообщения
+}
+
+// Error возвращает сообщение ошибки на языке по умолчанию; для внутренних ошибок — вместе с исходной ошибкой.
+func (e *Error) Error() string {
+	message := e.Message(i18n.Default)
+	if e.Err != nil {
+		return message + ": " + e.Err.Error()
+	}
+	return message
+}
+
+// Unwrap возвращает исходную ошибку.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Message возвращает сообщение ошибки на языке lang.
+func (e *Error) Message(lang string) string {
+	return i18n.Translate(lang, e.Key, e.Args...)
+}
+
+// Response собирает тело ответа с ошибкой на языке lang.
+func (e *Error) Response(lang string) common.ErrorResponse {
+	resp := common.ErrorResponse{StatusCode: e.Status(), Code: e.Code, Message: e.Message(lang)}
+	for _, f := range e.Fields {
+		resp.Fields = append(resp.Fields, common.FieldError{Field: f.Field, Message: i18n.Translate(lang, f.Key, f.Args...)})
+	}
+	return resp
+}
+
+// Status возвращает HTTP статус ответа для ошибки.
+func (e *Error) Status() int {
+	switch e.Kind {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindUnauthorized:
+		return http.StatusUnauthorized
+	case KindForbidden:
+		return http.StatusForbidden
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusInternalServerError
+}
+
+// WithFields возвращает копию ошибки с ошибками полей fields.
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	c := *e
+	c.Fields = append(slices.Clip(e.Fields), fields...)
+	return &c
+}
+
+// New создает ошибку вида kind с кодом code; сообщение берётся из каталогов по ключу code.
+func New(kind Kind, code string, args ...any) *Error {
+	return &Error{Kind: kind, Code: code, Key: code, Args: args}
+}
+
+// Validation создает ошибку валидации с общим кодом validation_failed и сообщением по ключу key.
+func Validation(key string, args ...any) *Error {
+	return &Error{Kind: KindValidation, Code: CodeValidationFailed, Key: key, Args: args}
+}
+
+// Field создает ошибку поля field с сообщением по ключу key.
+func Field(field, key string, args ...any) FieldError {
+	return FieldError{Field: field, Key: key, Args: args}
+}
+
+// NotFound создает ошибку «не найдено».
+func NotFound(code string, args ...any) *Error {
+	return New(KindNotFound, code, args...)
+}
+
+// Conflict создает ошибку конфликта с текущим состоянием ресурса.
+func Conflict(code string, args ...any) *Error {
+	return New(KindConflict, code, args...)
+}
+
+// Unauthorized создает ошибку аутентификации.
+func Unauthorized(code string) *Error {
+	return New(KindUnauthorized, code)
+}
+
+// Forbidden создает ошибку отсутствия прав.
+func Forbidden(code string) *Error {
+	return New(KindForbidden, code)
+}
+
+// PreconditionFailed создает ошибку невыполненного условия запроса.
+func PreconditionFailed(code string) *Error {
+	return New(KindPreconditionFailed, code)
+}
+
+// Internal оборачивает непредвиденную ошибку err во внутреннюю ошибку; клиент видит только общее сообщение.
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Code: CodeInternal, Key: CodeInternal, Err: err}
+}
+
+// From приводит произвольную ошибку к *Error: типизированная ошибка возвращается как есть
+// (в том числе обёрнутая), остальные считаются внутренними.
+func From(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return Internal(err)
+}
@@ -0,0 +1,216 @@
+// Package dedup ищет вероятные дубликаты операций: одна и та же операция, загруженная из пересекающихся
+// выписок или внесённая вручную и затем импортированная. Пакет не обращается к БД.
+package dedup
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+const (
+	// DateWindowDays — максимальная разница дат дубликатов в днях: банк может провести операцию
+	// на несколько дней позже даты, под которой её внёс пользователь.
+	DateWindowDays = 3
+	// Threshold — минимальная оценка, начиная с которой пара считается вероятным дубликатом.
+	Threshold = 0.6
+)
+
+// Entry описывает операцию или строку выписки для сравнения.
+type Entry struct {
+	ID         int           // Идентификатор операции (или индекс строки выписки)
+	AccountID  int           // ID банковского аккаунта
+	Date       time.Time     // Дата операции
+	Amount     money.Decimal // Сумма со знаком
+	Payee      string        // Контрагент
+	ExternalID string        // Идентификатор операции в банке (пусто, если его нет)
+}
+
+// Match описывает найденную пару: элемент Item совпал с кандидатом Candidate.
+type Match struct {
+	Item      int     // ID элемента
+	Candidate int     // ID кандидата
+	Score     float64 // Оценка сходства от Threshold до 1
+}
+
+// Score оценивает, насколько a и b похожи на одну и ту же операцию, от 0 до 1.
+// Операции должны относиться к одному аккаунту, суммы — совпадать, а даты отличаться не больше чем на DateWindowDays; совпадающий
+// идентификатор банка даёт 1, различающиеся идентификаторы исключают совпадение.
+// Остальное — среднее близости дат и сходства нормализованных контрагентов
+// (если у одной из операций контрагента нет, его сходство считается нейтральным — 0.5).
+func Score(a, b Entry) float64 {
+	if a.AccountID != b.AccountID {
+		return 0
+	}
+	if a.ExternalID != "" && b.ExternalID != "" {
+		if a.ExternalID == b.ExternalID {
+			return 1
+		}
+		return 0
+	}
+	if !a.Amount.Equal(b.Amount) {
+		return 0
+	}
+	days := a.Date.Sub(b.Date).Hours() / 24
+	if days < 0 {
+		days = -days
+	}
+	if days > DateWindowDays {
+		return 0
+	}
+	dateScore := 1 - days/(DateWindowDays+1)
+
+	payeeScore := 0.5
+	pa, pb := NormalizePayee(a.Payee), NormalizePayee(b.Payee)
+	if pa != "" && pb != "" {
+		payeeScore = payeeSimilarity(pa, pb)
+	}
+	return (dateScore + payeeScore) / 2
+}
+
+// Find сопоставляет элементы с кандидатами один к одному: сначала берутся пары с наибольшей оценкой,
+// каждый элемент и каждый кандидат участвуют не больше чем в одной паре. Пары с оценкой ниже Threshold
+// отбрасываются. Если same, элементы и кандидаты — один и тот же набор: пара с самим собой не
+// рассматривается, а пара (a, b) возвращается один раз с Item < Candidate.
+// Оцениваются только пары, у которых Score может быть больше нуля: кандидаты группируются по аккаунту
+// и идентификатору банка или по аккаунту и сумме, а внутри группы по сумме перебираются только даты
+// в пределах DateWindowDays.
+func Find(items, candidates []Entry, same bool) []Match {
+	byExternalID := map[bucketKey][]int{}
+	byAmount := map[bucketKey][]int{}
+	for i, c := range candidates {
+		if c.ExternalID != "" {
+			key := bucketKey{accountID: c.AccountID, value: c.ExternalID}
+			byExternalID[key] = append(byExternalID[key], i)
+		}
+		key := bucketKey{accountID: c.AccountID, value: amountKey(c.Amount)}
+		byAmount[key] = append(byAmount[key], i)
+	}
+	for _, bucket := range byAmount {
+		sort.SliceStable(bucket, func(i, j int) bool { return candidates[bucket[i]].Date.Before(candidates[bucket[j]].Date) })
+	}
+
+	window := time.Duration(DateWindowDays) * 24 * time.Hour
+	var pairs []pair
+	add := func(itemIndex, candidateIndex int) {
+		item, c := items[itemIndex], candidates[candidateIndex]
+		if same && item.ID >= c.ID {
+			return
+		}
+		if score := Score(item, c); score >= Threshold {
+			pairs = append(pairs, pair{Match: Match{Item: item.ID, Candidate: c.ID, Score: score}, item: itemIndex, candidate: candidateIndex})
+		}
+	}
+	for i, item := range items {
+		if item.ExternalID != "" {
+			for _, j := range byExternalID[bucketKey{accountID: item.AccountID, value: item.ExternalID}] {
+				add(i, j)
+			}
+		}
+		bucket := byAmount[bucketKey{accountID: item.AccountID, value: amountKey(item.Amount)}]
+		from, to := item.Date.Add(-window), item.Date.Add(window)
+		first := sort.Search(len(bucket), func(k int) bool { return !candidates[bucket[k]].Date.Before(from) })
+		for _, j := range bucket[first:] {
+			c := candidates[j]
+			if c.Date.After(to) {
+				break
+			}
+			// Пары с идентификаторами банка у обеих операций уже оценены по группе идентификатора
+			if item.ExternalID != "" && c.ExternalID != "" {
+				continue
+			}
+			add(i, j)
+		}
+	}
+	// Порядок пар с равной оценкой — по позициям элемента и кандидата во входных наборах
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i].Score != pairs[j].Score {
+			return pairs[i].Score > pairs[j].Score
+		}
+		if pairs[i].item != pairs[j].item {
+			return pairs[i].item < pairs[j].item
+		}
+		return pairs[i].candidate < pairs[j].candidate
+	})
+
+	usedItems, usedCandidates := map[int]bool{}, map[int]bool{}
+	matches := []Match{}
+	for _, p := range pairs {
+		if usedItems[p.Item] || usedCandidates[p.Candidate] {
+			continue
+		}
+		if same && (usedCandidates[p.Item] || usedItems[p.Candidate]) {
+			continue
+		}
+		usedItems[p.Item], usedCandidates[p.Candidate] = true, true
+		matches = append(matches, p.Match)
+	}
+	return matches
+}
+
+// bucketKey — группа кандидатов одного аккаунта с одинаковым идентификатором банка или суммой.
+type bucketKey struct {
+	accountID int
+	value     string
+}
+
+// pair — оценённая пара с позициями элемента и кандидата во входных наборах.
+type pair struct {
+	Match
+	item      int
+	candidate int
+}
+
+// amountKey возвращает запись суммы без хвостовых нулей, одинаковую для равных сумм (1.50 и 1.5).
+func amountKey(d money.Decimal) string {
+	s := d.String()
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// legalForms — организационно-правовые формы и служебные слова, которые не отличают контрагентов.
+var legalForms = map[string]bool{
+	"ооо": true, "оао": true, "зао": true, "пао": true, "ао": true, "ип": true,
+	"llc": true, "ltd": true, "inc": true, "gmbh": true, "ag": true, "sa": true, "bv": true, "plc": true,
+}
+
+// NormalizePayee приводит контрагента к виду для сравнения: нижний регистр, только буквы и пробелы,
+// без организационно-правовых форм. Цифры отбрасываются: банки добавляют к названию номера
+// терминалов, карт и чеков.
+func NormalizePayee(s string) string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return !unicode.IsLetter(r) })
+	words := fields[:0]
+	for _, f := range fields {
+		if !legalForms[f] {
+			words = append(words, f)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// payeeSimilarity сравнивает нормализованных контрагентов: 1, если все слова одного названия есть в другом
+// («magnit» и «magnit moskva»), иначе доля общих слов (коэффициент Жаккара).
+func payeeSimilarity(a, b string) float64 {
+	wa, wb := map[string]bool{}, map[string]bool{}
+	for _, w := range strings.Fields(a) {
+		wa[w] = true
+	}
+	for _, w := range strings.Fields(b) {
+		wb[w] = true
+	}
+	common := 0
+	for w := range wa {
+		if wb[w] {
+			common++
+		}
+	}
+	if common > 0 && (common == len(wa) || common == len(wb)) {
+		return 1
+	}
+	return float64(common) / float64(len(wa)+len(wb)-common)
+}
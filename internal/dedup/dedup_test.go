@@ -0,0 +1,174 @@
+package dedup
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+var day0 = time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func entry(id, account, day int, amount, payee, externalID string) Entry {
+	return Entry{ID: id, AccountID: account, Date: day0.AddDate(0, 0, day), Amount: money.MustParse(amount), Payee: payee, ExternalID: externalID}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b Entry
+		want float64
+	}{
+		{name: "same day and payee", a: entry(1, 1, 0, "-100.00", "Magnit", ""), b: entry(2, 1, 0, "-100", "MAGNIT", ""), want: 1},
+		{name: "other account", a: entry(1, 1, 0, "-100", "Magnit", ""), b: entry(2, 2, 0, "-100", "Magnit", ""), want: 0},
+		{name: "other amount", a: entry(1, 1, 0, "-100", "Magnit", ""), b: entry(2, 1, 0, "-100.01", "Magnit", ""), want: 0},
+		{name: "same external id ignores amount", a: entry(1, 1, 0, "-100", "", "X1"), b: entry(2, 1, 10, "-5", "", "X1"), want: 1},
+		{name: "different external ids", a: entry(1, 1, 0, "-100", "Magnit", "X1"), b: entry(2, 1, 0, "-100", "Magnit", "X2"), want: 0},
+		{name: "one external id", a: entry(1, 1, 0, "-100", "Magnit", "X1"), b: entry(2, 1, 0, "-100", "Magnit", ""), want: 1},
+		{name: "outside window", a: entry(1, 1, 0, "-100", "Magnit", ""), b: entry(2, 1, DateWindowDays+1, "-100", "Magnit", ""), want: 0},
+		{name: "edge of window", a: entry(1, 1, 0, "-100", "Magnit", ""), b: entry(2, 1, DateWindowDays, "-100", "Magnit", ""), want: (0.25 + 1) / 2},
+		{name: "missing payee is neutral", a: entry(1, 1, 0, "-100", "", ""), b: entry(2, 1, 0, "-100", "Magnit", ""), want: 0.75},
+		{name: "payee subset", a: entry(1, 1, 1, "-100", "ООО Магнит", ""), b: entry(2, 1, 0, "-100", "Магнит Москва 1234", ""), want: (0.75 + 1) / 2},
+		{name: "different payees", a: entry(1, 1, 0, "-100", "Magnit", ""), b: entry(2, 1, 0, "-100", "Pyaterochka", ""), want: 0.5},
+	}
+	for _, tt := range tests {
+		if got := Score(tt.a, tt.b); got != tt.want {
+			t.Errorf("%s: Score = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := Score(tt.b, tt.a); got != tt.want {
+			t.Errorf("%s: Score (swapped) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizePayee(t *testing.T) {
+	tests := map[string]string{
+		"ООО \"Магнит\"":           "магнит",
+		"MAGNIT MM 1234 MOSKVA":    "magnit mm moskva",
+		"Acme GmbH":                "acme",
+		"ИП Иванов И.И.":           "иванов и и",
+		"  ":                       "",
+		"12345":                    "",
+		"Yandex.Taxi LLC":          "yandex taxi",
+		"Coffee&Co, Ltd. #42 card": "coffee co card",
+	}
+	for in, want := range tests {
+		if got := NormalizePayee(in); got != want {
+			t.Errorf("NormalizePayee(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	items := []Entry{
+		entry(1, 1, 0, "-100", "Magnit", ""),
+		entry(2, 1, 5, "-50", "Lenta", "L1"),
+		entry(3, 1, 9, "-7", "Cafe", ""),
+	}
+	candidates := []Entry{
+		entry(10, 1, 1, "-100.00", "Magnit Moskva", ""),
+		entry(11, 1, 0, "-100", "Magnit", ""),
+		entry(12, 1, 30, "-50", "", "L1"),
+		entry(13, 2, 9, "-7", "Cafe", ""),
+	}
+	got := Find(items, candidates, false)
+	want := []Match{
+		{Item: 1, Candidate: 11, Score: 1},
+		{Item: 2, Candidate: 12, Score: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Find = %+v, want %+v", got, want)
+	}
+}
+
+func TestFindSame(t *testing.T) {
+	entries := []Entry{
+		entry(1, 1, 0, "-100", "Magnit", ""),
+		entry(2, 1, 1, "-100", "Magnit", ""),
+		entry(3, 1, 0, "-100", "Magnit", ""),
+		entry(4, 1, 0, "-30", "Lenta", ""),
+	}
+	got := Find(entries, entries, true)
+	want := []Match{{Item: 1, Candidate: 3, Score: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Find = %+v, want %+v", got, want)
+	}
+	if got := Find(nil, entries, false); len(got) != 0 {
+		t.Errorf("Find(nil) = %+v, want none", got)
+	}
+}
+
+// findBruteForce — исходный алгоритм: сравнение каждого элемента с каждым кандидатом.
+func findBruteForce(items, candidates []Entry, same bool) []Match {
+	pairs := []Match{}
+	for _, item := range items {
+		for _, c := range candidates {
+			if same && item.ID >= c.ID {
+				continue
+			}
+			if score := Score(item, c); score >= Threshold {
+				pairs = append(pairs, Match{Item: item.ID, Candidate: c.ID, Score: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].Score > pairs[j].Score })
+	usedItems, usedCandidates := map[int]bool{}, map[int]bool{}
+	matches := []Match{}
+	for _, p := range pairs {
+		if usedItems[p.Item] || usedCandidates[p.Candidate] {
+			continue
+		}
+		if same && (usedCandidates[p.Item] || usedItems[p.Candidate]) {
+			continue
+		}
+		usedItems[p.Item], usedCandidates[p.Candidate] = true, true
+		matches = append(matches, p)
+	}
+	return matches
+}
+
+func randomEntries(rnd *rand.Rand, n, firstID int) []Entry {
+	amounts := []string{"-100", "-100.00", "-250.5", "-250.50", "1000", "-7"}
+	payees := []string{"", "Magnit", "MAGNIT MOSKVA", "Lenta", "ООО Лента", "Cafe"}
+	entries := make([]Entry, n)
+	for i := range entries {
+		externalID := ""
+		if rnd.Intn(3) == 0 {
+			externalID = string(rune('A' + rnd.Intn(5)))
+		}
+		entries[i] = entry(firstID+i, 1+rnd.Intn(2), rnd.Intn(20), amounts[rnd.Intn(len(amounts))], payees[rnd.Intn(len(payees))], externalID)
+	}
+	return entries
+}
+
+func TestFindMatchesBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for round := 0; round < 50; round++ {
+		items := randomEntries(rnd, 40, 1)
+		candidates := randomEntries(rnd, 60, 1000)
+		if got, want := Find(items, candidates, false), findBruteForce(items, candidates, false); !reflect.DeepEqual(got, want) {
+			t.Fatalf("round %d: Find = %+v, want %+v", round, got, want)
+		}
+		if got, want := Find(candidates, candidates, true), findBruteForce(candidates, candidates, true); !reflect.DeepEqual(got, want) {
+			t.Fatalf("round %d (same): Find = %+v, want %+v", round, got, want)
+		}
+	}
+}
+
+// TestFindLarge проверяет, что поиск по 20000 операциям (maxDuplicateScan) не сравнивает все пары.
+func TestFindLarge(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	entries := make([]Entry, 20000)
+	for i := range entries {
+		amount := money.New(-int64(rnd.Intn(100000)), -2)
+		entries[i] = Entry{ID: i + 1, AccountID: 1 + rnd.Intn(3), Date: day0.AddDate(0, 0, rnd.Intn(365)), Amount: amount, Payee: "Shop"}
+	}
+	start := time.Now()
+	Find(entries, entries, true)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Find on %d entries took %s", len(entries), elapsed)
+	}
+}
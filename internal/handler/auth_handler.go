@@ -3,10 +3,15 @@ package handler
 import (
 	"context"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gin-gonic/gin"
-	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/common"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
 	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/response"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
 )
 
@@ -20,24 +25,45 @@ func NewAuthHandler(service *service.AuthService) *AuthHandler {
 	return &AuthHandler{service: service}
 }
 
+// clientInfo собирает данные клиента из запроса для привязки к сессии. User-Agent и IP приходят от клиента
+// без ограничений, поэтому обрезаются до размеров колонок: иначе слишком длинный заголовок не дал бы войти.
+func clientInfo(c *gin.Context, deviceLabel string) token.ClientInfo {
+	return token.ClientInfo{
+		UserAgent:   truncateRunes(c.Request.UserAgent(), token.MaxUserAgentLength),
+		IP:          truncateRunes(c.ClientIP(), token.MaxIPLength),
+		DeviceLabel: deviceLabel,
+	}
+}
+
+// truncateRunes обрезает строку до n символов; некорректные последовательности UTF-8 заменяются на U+FFFD.
+func truncateRunes(s string, n int) string {
+	s = strings.ToValidUTF8(s, "\uFFFD")
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
 // Register обрабатывает запрос на регистрацию пользователя.
 // @Summary Регистрация
+// @Description Создаёт пользователя и отправляет на email ссылку для его подтверждения.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param input body request.RegisterRequest true "Данные для регистрации"
 // @Success 200 {object} response.MessageResponse
 // @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 409 {object} common.ErrorResponse "Email уже зарегистрирован"
 // @Router /register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var reqBody req.RegisterRequest
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, common.ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Некорректные данные"})
+		c.Error(bindError(err))
 		return
 	}
-	err := h.service.Register(context.Background(), reqBody.Email, reqBody.Password)
+	err := h.service.Register(context.Background(), reqBody.Email, reqBody.Password, reqBody.HomeCurrency, middleware.Language(c))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.ErrorResponse{StatusCode: http.StatusBadRequest, Message: err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "ok"})
@@ -51,16 +77,18 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // @Param input body request.LoginRequest true "Данные для входа"
 // @Success 200 {object} response.TokensResponse
 // @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неверный email или пароль"
+// @Failure 403 {object} common.ErrorResponse "Email не подтверждён (UNVERIFIED_ACCESS=none)"
 // @Router /login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var reqBody req.LoginRequest
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, common.ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Некорректные данные"})
+		c.Error(bindError(err))
 		return
 	}
-	tokens, err := h.service.Login(context.Background(), reqBody.Email, reqBody.Password)
+	tokens, err := h.service.Login(context.Background(), reqBody.Email, reqBody.Password, clientInfo(c, reqBody.DeviceLabel))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.ErrorResponse{StatusCode: http.StatusBadRequest, Message: err.Error()})
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, tokens)
@@ -78,12 +106,151 @@ func (h *AuthHandler) Login(c *gin.Context) {
 func (h *AuthHandler) Logout(c *gin.Context) {
 	var reqBody req.LogoutRequest
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, common.ErrorResponse{StatusCode: http.StatusBadRequest, Message: "Некорректные данные"})
+		c.Error(bindError(err))
 		return
 	}
 	err := h.service.Logout(context.Background(), reqBody.RefreshToken)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, common.ErrorResponse{StatusCode: http.StatusBadRequest, Message: err.Error()})
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// Refresh обрабатывает запрос на обновление пары токенов по refresh токену (с ротацией).
+// @Summary Обновление токенов
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body request.RefreshRequest true "Refresh токен"
+// @Success 200 {object} response.TokensResponse
+// @Failure 401 {object} common.ErrorResponse "ошибка"
+// @Failure 403 {object} common.ErrorResponse "Email не подтверждён (UNVERIFIED_ACCESS=none)"
+// @Router /refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var reqBody req.RefreshRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	tokens, err := h.service.Refresh(context.Background(), reqBody.RefreshToken, clientInfo(c, ""))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// GetProfile возвращает профиль текущего пользователя.
+// @Summary Профиль пользователя
+// @Tags profile
+// @Produce json
+// @Success 200 {object} response.ProfileResponse
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /me [get]
+func (h *AuthHandler) GetProfile(c *gin.Context) {
+	userObj, err := h.service.Profile(context.Background(), middleware.MustGetPrincipal(c).UserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, profileResponse(userObj))
+}
+
+// UpdateProfile изменяет профиль текущего пользователя (домашнюю валюту и язык).
+// @Summary Изменить профиль
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param input body request.ProfileUpdateRequest true "Новые данные профиля"
+// @Success 200 {object} response.ProfileResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /me [put]
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	var reqBody req.ProfileUpdateRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	userObj, err := h.service.UpdateProfile(context.Background(), middleware.MustGetPrincipal(c).UserID, reqBody.HomeCurrency, reqBody.Language)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, profileResponse(userObj))
+}
+
+// profileResponse собирает ответ с профилем пользователя.
+func profileResponse(u *user.User) response.ProfileResponse {
+	return response.ProfileResponse{ID: u.ID, Email: u.Email, HomeCurrency: u.HomeCurrency, Language: u.Language, EmailVerifiedAt: u.EmailVerifiedAt, CreatedAt: u.CreatedAt}
+}
+
+// ListSessions возвращает активные сессии текущего пользователя.
+// @Summary Список активных сессий
+// @Tags sessions
+// @Produce json
+// @Success 200 {array} response.SessionResponse
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	principal := middleware.MustGetPrincipal(c)
+	sessions, err := h.service.ListSessions(context.Background(), principal.UserID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	result := make([]response.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, response.SessionResponse{
+			ID:          s.ID,
+			DeviceLabel: s.DeviceLabel,
+			UserAgent:   s.UserAgent,
+			IP:          s.IP,
+			CreatedAt:   s.CreatedAt,
+			LastUsedAt:  s.LastUsedAt,
+			ExpiresAt:   s.ExpiresAt,
+			Current:     s.ID == principal.SessionID,
+		})
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// RevokeSession завершает одну сессию текущего пользователя.
+// @Summary Завершить сессию
+// @Tags sessions
+// @Produce json
+// @Param id path string true "ID сессии"
+// @Success 200 {object} response.MessageResponse
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Сессия не найдена"
+// @Security BearerAuth
+// @Router /sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	principal := middleware.MustGetPrincipal(c)
+	err := h.service.RevokeSession(context.Background(), principal.UserID, c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// LogoutAll завершает все сессии текущего пользователя на всех устройствах.
+// @Summary Выйти на всех устройствах
+// @Tags sessions
+// @Produce json
+// @Success 200 {object} response.MessageResponse
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /sessions [delete]
+func (h *AuthHandler) LogoutAll(c *gin.Context) {
+	principal := middleware.MustGetPrincipal(c)
+	if err := h.service.LogoutAll(context.Background(), principal.UserID); err != nil {
+		c.Error(err)
 		return
 	}
 	c.JSON(http.StatusOK, gin.H{"message": "ok"})
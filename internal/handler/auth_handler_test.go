@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
+)
+
+func TestClientInfoTruncates(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/login", nil)
+	c.Request.Header.Set("User-Agent", strings.Repeat("я", token.MaxUserAgentLength+100)+"\xff")
+
+	info := clientInfo(c, "phone")
+	if n := utf8.RuneCountInString(info.UserAgent); n != token.MaxUserAgentLength || !utf8.ValidString(info.UserAgent) {
+		t.Errorf("UserAgent has %d runes (valid UTF-8: %v), want %d", n, utf8.ValidString(info.UserAgent), token.MaxUserAgentLength)
+	}
+	if info.DeviceLabel != "phone" {
+		t.Errorf("DeviceLabel = %q, want phone", info.DeviceLabel)
+	}
+	if got := truncateRunes("ab\xffc", 10); got != "ab�c" {
+		t.Errorf("truncateRunes = %q, want invalid byte replaced", got)
+	}
+}
@@ -7,55 +7,116 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/common"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/response"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
 )
 
 // BankAccountHandler содержит обработчики HTTP-запросов для банковских аккаунтов.
 type BankAccountHandler struct {
-	service   *service.BankAccountService // Сервис банковских аккаунтов
-	jwtSecret string                      // Секрет для JWT
+	service *service.BankAccountService // Сервис банковских аккаунтов
 }
 
 // NewBankAccountHandler создает новый экземпляр BankAccountHandler.
-func NewBankAccountHandler(service *service.BankAccountService, jwtSecret string) *BankAccountHandler {
-	return &BankAccountHandler{service: service, jwtSecret: jwtSecret}
+func NewBankAccountHandler(service *service.BankAccountService) *BankAccountHandler {
+	return &BankAccountHandler{service: service}
 }
 
-// bankAccountRequest описывает структуру запроса для создания/обновления аккаунта.
-type bankAccountRequest struct {
-	Name     string  `json:"name" binding:"required"`     // Название
-	Balance  float64 `json:"balance" binding:"required"`  // Баланс
-	Currency string  `json:"currency" binding:"required"` // Валюта
+// ListBankAccounts возвращает страницу банковских аккаунтов пользователя.
+// @Summary Список банковских аккаунтов
+// @Tags accounts
+// @Produce json
+// @Param currency query string false "Фильтр по валюте"
+// @Param type query string false "Фильтр по типу аккаунта: checking, cash, credit_card, savings, loan, investment"
+// @Param name query string false "Фильтр по подстроке названия"
+// @Param include_archived query bool false "Включать архивные аккаунты"
+// @Param invalid_currency query bool false "Только аккаунты с кодом валюты вне справочника ISO 4217"
+// @Param sort query string false "Сортировка: created_at, name, balance; префикс - для убывания"
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 20)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Success 200 {object} response.BankAccountListResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /accounts [get]
+func (h *BankAccountHandler) ListBankAccounts(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.BankAccountListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	filter := account.ListFilter{
+		Currency:        query.Currency,
+		Type:            query.Type,
+		Name:            query.Name,
+		IncludeArchived: query.IncludeArchived,
+		InvalidCurrency: query.InvalidCurrency,
+		SortBy:          strings.TrimPrefix(query.Sort, "-"),
+		SortDesc:        strings.HasPrefix(query.Sort, "-"),
+		Limit:           query.Limit,
+		Cursor:          query.Cursor,
+	}
+	accounts, nextCursor, err := h.service.List(context.Background(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.BankAccountListResponse{Items: accounts, NextCursor: nextCursor})
 }
 
-// getUserIDFromToken извлекает userID из access token (JWT).
-func (h *BankAccountHandler) getUserIDFromToken(c *gin.Context) (int, error) {
-	authHeader := c.GetHeader("Authorization")
-	if authHeader == "" {
-		return 0, http.ErrNoCookie
-	}
-	parts := strings.Split(authHeader, " ")
-	if len(parts) != 2 || parts[0] != "Bearer" {
-		return 0, http.ErrNoCookie
+// GetAccountTotals возвращает сумму балансов всех аккаунтов пользователя, пересчитанную в одну валюту.
+// @Summary Итог по аккаунтам в одной валюте
+// @Tags accounts
+// @Produce json
+// @Param currency query string false "Валюта итога (по умолчанию — домашняя валюта пользователя)"
+// @Success 200 {object} account.Totals
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /accounts/totals [get]
+func (h *BankAccountHandler) GetAccountTotals(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.AccountTotalsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
 	}
-	tokenStr := parts[1]
-	token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-		return []byte(h.jwtSecret), nil
-	})
-	if err != nil || !token.Valid {
-		return 0, err
+	totals, err := h.service.Totals(context.Background(), userID, query.Currency)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return 0, http.ErrNoCookie
+	c.JSON(http.StatusOK, totals)
+}
+
+// GetBankAccount возвращает банковский аккаунт пользователя по id.
+// @Summary Получить банковский аккаунт
+// @Tags accounts
+// @Produce json
+// @Param id path int true "ID аккаунта"
+// @Success 200 {object} account.BankAccount
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Header 200 {string} ETag "Версия аккаунта для If-Match"
+// @Router /accounts/{id} [get]
+func (h *BankAccountHandler) GetBankAccount(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
 	}
-	userIDf, ok := claims["user_id"].(float64)
-	if !ok {
-		return 0, http.ErrNoCookie
+	acc, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
 	}
-	return int(userIDf), nil
+	writeBankAccount(c, acc)
 }
 
 // CreateBankAccount создает новый банковский аккаунт для пользователя.
@@ -67,89 +128,284 @@ func (h *BankAccountHandler) getUserIDFromToken(c *gin.Context) (int, error) {
 // @Success 200 {object} account.BankAccount
 // @Failure 400 {object} common.ErrorResponse "ошибка"
 // @Failure 401 {string} string "Неавторизован"
+// @Failure 409 {object} common.ErrorResponse "IBAN уже занят"
 // @Security BearerAuth
 // @Router /accounts [post]
 func (h *BankAccountHandler) CreateBankAccount(c *gin.Context) {
-	userID, err := h.getUserIDFromToken(c)
-	if err != nil {
-		c.JSON(http.StatusUnauthorized, common.ErrorResponse{StatusCode: http.StatusUnauthorized, Message: "Неавторизован"})
+	userID := middleware.MustGetPrincipal(c).UserID
+	var reqBody req.BankAccountRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
 		return
 	}
-	var req bankAccountRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные данные"})
-		return
-	}
-	acc, err := h.service.Create(context.Background(), userID, req.Name, req.Balance, req.Currency)
+	acc, err := h.service.Create(context.Background(), account.BankAccount{
+		UserID:       userID,
+		Name:         reqBody.Name,
+		Balance:      reqBody.Balance,
+		Currency:     reqBody.Currency,
+		IBAN:         reqBody.IBAN,
+		BIC:          reqBody.BIC,
+		Type:         reqBody.Type,
+		CreditLimit:  reqBody.CreditLimit,
+		StatementDay: reqBody.StatementDay,
+		InterestRate: reqBody.InterestRate,
+	})
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, acc)
+	writeBankAccount(c, acc)
 }
 
 // UpdateBankAccount обновляет банковский аккаунт по id.
+// Изменение баланса записывается в историю операций корректировкой; если баланс не передан, он не меняется.
+// С заголовком If-Match аккаунт обновляется, только если не изменился с получения этого ETag.
 // @Summary Обновить банковский аккаунт
 // @Tags accounts
 // @Accept json
 // @Produce json
 // @Param id path int true "ID аккаунта"
-// @Param input body bankAccountRequest true "Данные аккаунта"
+// @Param If-Match header string false "ETag аккаунта, полученный при чтении"
+// @Param input body request.BankAccountRequest true "Данные аккаунта"
 // @Success 200 {object} account.BankAccount
+// @Header 200 {string} ETag "Версия аккаунта"
 // @Failure 400 {object} common.ErrorResponse "ошибка"
-// @Failure 401 {string} string "Неавторизован"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Failure 409 {object} common.ErrorResponse "IBAN уже занят или у аккаунта есть операции"
+// @Failure 412 {object} common.ErrorResponse "Аккаунт изменён на другом устройстве"
 // @Security BearerAuth
 // @Router /accounts/{id} [put]
 func (h *BankAccountHandler) UpdateBankAccount(c *gin.Context) {
-	userID, err := h.getUserIDFromToken(c)
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, common.ErrorResponse{StatusCode: http.StatusUnauthorized, Message: "Неавторизован"})
+		c.Error(errInvalidID)
+		return
+	}
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		c.Error(service.ErrVersionConflict)
+		return
+	}
+	var reqBody req.BankAccountRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
 		return
 	}
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	h.update(c, id, userID, reqBody, version)
+}
+
+// PatchBankAccount частично обновляет банковский аккаунт по id: меняются только переданные поля,
+// null очищает необязательный параметр (лимит, день выписки, ставку). Без If-Match изменение
+// применяется к версии, прочитанной в начале запроса, и не затирает параллельные изменения.
+// @Summary Частично обновить банковский аккаунт
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Param id path int true "ID аккаунта"
+// @Param If-Match header string false "ETag аккаунта, полученный при чтении"
+// @Param input body request.BankAccountRequest true "Изменяемые поля аккаунта"
+// @Success 200 {object} account.BankAccount
+// @Header 200 {string} ETag "Версия аккаунта"
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Failure 409 {object} common.ErrorResponse "IBAN уже занят или у аккаунта есть операции"
+// @Failure 412 {object} common.ErrorResponse "Аккаунт изменён на другом устройстве"
+// @Security BearerAuth
+// @Router /accounts/{id} [patch]
+func (h *BankAccountHandler) PatchBankAccount(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный id"})
+		c.Error(errInvalidID)
 		return
 	}
-	var req bankAccountRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректные данные"})
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		c.Error(service.ErrVersionConflict)
 		return
 	}
-	acc, err := h.service.Update(context.Background(), id, userID, req.Name, req.Balance, req.Currency)
+	current, err := h.service.Get(context.Background(), id, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, acc)
+	if version == 0 {
+		version = current.Version
+	}
+	// Тело запроса накладывается на текущие значения; баланс меняется, только если передан явно
+	reqBody := req.BankAccountRequest{
+		Name:         current.Name,
+		Currency:     current.Currency,
+		IBAN:         current.IBAN,
+		BIC:          current.BIC,
+		Type:         current.Type,
+		CreditLimit:  current.CreditLimit,
+		StatementDay: current.StatementDay,
+		InterestRate: current.InterestRate,
+	}
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	h.update(c, id, userID, reqBody, version)
+}
+
+// update сохраняет аккаунт из тела запроса и отвечает обновлённым аккаунтом с новым ETag.
+func (h *BankAccountHandler) update(c *gin.Context, id, userID int, reqBody req.BankAccountRequest, version int) {
+	acc, err := h.service.Update(context.Background(), account.BankAccount{
+		ID:           id,
+		UserID:       userID,
+		Name:         reqBody.Name,
+		Balance:      reqBody.Balance,
+		Currency:     reqBody.Currency,
+		IBAN:         reqBody.IBAN,
+		BIC:          reqBody.BIC,
+		Type:         reqBody.Type,
+		CreditLimit:  reqBody.CreditLimit,
+		StatementDay: reqBody.StatementDay,
+		InterestRate: reqBody.InterestRate,
+	}, version)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBankAccount(c, acc)
 }
 
 // DeleteBankAccount удаляет банковский аккаунт по id.
+// Аккаунт и его операции скрываются и окончательно удаляются через 30 дней; до этого аккаунт можно восстановить.
 // @Summary Удалить банковский аккаунт
 // @Tags accounts
 // @Param id path int true "ID аккаунта"
 // @Success 200 {object} response.MessageResponse
 // @Failure 400 {object} common.ErrorResponse "ошибка"
-// @Failure 401 {string} string "Неавторизован"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
 // @Security BearerAuth
 // @Router /accounts/{id} [delete]
 func (h *BankAccountHandler) DeleteBankAccount(c *gin.Context) {
-	userID, err := h.getUserIDFromToken(c)
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, common.ErrorResponse{StatusCode: http.StatusUnauthorized, Message: "Неавторизован"})
+		c.Error(errInvalidID)
 		return
 	}
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// ListDeletedBankAccounts возвращает удалённые аккаунты, которые ещё можно восстановить.
+// @Summary Удалённые банковские аккаунты
+// @Tags accounts
+// @Produce json
+// @Success 200 {array} account.BankAccount
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /accounts/deleted [get]
+func (h *BankAccountHandler) ListDeletedBankAccounts(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	accounts, err := h.service.ListDeleted(context.Background(), userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Некорректный id"})
+		c.Error(err)
 		return
 	}
-	err = h.service.Delete(context.Background(), id, userID)
+	c.JSON(http.StatusOK, accounts)
+}
+
+// RestoreBankAccount восстанавливает удалённый аккаунт вместе с его операциями.
+// @Summary Восстановить банковский аккаунт
+// @Tags accounts
+// @Produce json
+// @Param id path int true "ID аккаунта"
+// @Success 200 {object} account.BankAccount
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден или срок восстановления истёк"
+// @Security BearerAuth
+// @Router /accounts/{id}/restore [post]
+func (h *BankAccountHandler) RestoreBankAccount(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(errInvalidID)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	acc, err := h.service.Restore(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBankAccount(c, acc)
+}
+
+// ArchiveBankAccount переносит аккаунт в архив.
+// @Summary Перенести банковский аккаунт в архив
+// @Tags accounts
+// @Produce json
+// @Param id path int true "ID аккаунта"
+// @Success 200 {object} account.BankAccount
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /accounts/{id}/archive [post]
+func (h *BankAccountHandler) ArchiveBankAccount(c *gin.Context) {
+	h.setArchived(c, true)
+}
+
+// UnarchiveBankAccount возвращает аккаунт из архива.
+// @Summary Вернуть банковский аккаунт из архива
+// @Tags accounts
+// @Produce json
+// @Param id path int true "ID аккаунта"
+// @Success 200 {object} account.BankAccount
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /accounts/{id}/unarchive [post]
+func (h *BankAccountHandler) UnarchiveBankAccount(c *gin.Context) {
+	h.setArchived(c, false)
+}
+
+// setArchived меняет признак архива аккаунта из пути запроса.
+func (h *BankAccountHandler) setArchived(c *gin.Context, archived bool) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	acc, err := h.service.SetArchived(context.Background(), id, userID, archived)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBankAccount(c, acc)
+}
+
+// writeBankAccount отвечает аккаунтом и его версией в заголовке ETag.
+func writeBankAccount(c *gin.Context, acc *account.BankAccount) {
+	c.Header("ETag", `"`+strconv.Itoa(acc.Version)+`"`)
+	c.JSON(http.StatusOK, acc)
+}
+
+// ifMatchVersion возвращает версию аккаунта из заголовка If-Match (0 — заголовка нет или он равен "*").
+// Второй результат false, если заголовок не содержит ETag аккаунта: такой запрос не может совпасть с версией.
+func ifMatchVersion(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
 }
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/budget"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// BudgetHandler содержит обработчики HTTP-запросов для бюджетов.
+type BudgetHandler struct {
+	service *service.BudgetService // Сервис бюджетов
+}
+
+// NewBudgetHandler создает новый экземпляр BudgetHandler.
+func NewBudgetHandler(service *service.BudgetService) *BudgetHandler {
+	return &BudgetHandler{service: service}
+}
+
+// ListBudgets возвращает все бюджеты пользователя с лимитами.
+// @Summary Список бюджетов
+// @Tags budgets
+// @Produce json
+// @Success 200 {array} budget.Budget
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /budgets [get]
+func (h *BudgetHandler) ListBudgets(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	budgets, err := h.service.List(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, budgets)
+}
+
+// GetBudget возвращает бюджет пользователя по id.
+// @Summary Получить бюджет
+// @Tags budgets
+// @Produce json
+// @Param id path int true "ID бюджета"
+// @Success 200 {object} budget.Budget
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Бюджет не найден"
+// @Security BearerAuth
+// @Router /budgets/{id} [get]
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	b, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, b)
+}
+
+// CreateBudget создает бюджет с лимитами по категориям расходов.
+// @Summary Создать бюджет
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param input body request.BudgetRequest true "Данные бюджета"
+// @Success 200 {object} budget.Budget
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Категория не найдена"
+// @Security BearerAuth
+// @Router /budgets [post]
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	b, ok := bindBudget(c)
+	if !ok {
+		return
+	}
+	b.UserID = middleware.MustGetPrincipal(c).UserID
+	created, err := h.service.Create(context.Background(), b)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// UpdateBudget изменяет бюджет; лимиты из запроса полностью заменяют прежние.
+// @Summary Изменить бюджет
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Param id path int true "ID бюджета"
+// @Param input body request.BudgetRequest true "Данные бюджета"
+// @Success 200 {object} budget.Budget
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Бюджет или категория не найдены"
+// @Security BearerAuth
+// @Router /budgets/{id} [put]
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	b, ok := bindBudget(c)
+	if !ok {
+		return
+	}
+	b.ID = id
+	b.UserID = middleware.MustGetPrincipal(c).UserID
+	updated, err := h.service.Update(context.Background(), b)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteBudget удаляет бюджет пользователя.
+// @Summary Удалить бюджет
+// @Tags budgets
+// @Param id path int true "ID бюджета"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Бюджет не найден"
+// @Security BearerAuth
+// @Router /budgets/{id} [delete]
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// GetBudgetProgress возвращает потраченное, остаток и процент исполнения по каждой категории за период.
+// @Summary Исполнение бюджета
+// @Tags budgets
+// @Produce json
+// @Param id path int true "ID бюджета"
+// @Param date query string false "Любая дата внутри периода (YYYY-MM-DD), по умолчанию — сегодня"
+// @Success 200 {object} budget.Progress
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Бюджет не найден"
+// @Security BearerAuth
+// @Router /budgets/{id}/progress [get]
+func (h *BudgetHandler) GetBudgetProgress(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	var query req.BudgetProgressQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if query.Date != "" {
+		date, err = time.Parse(time.DateOnly, query.Date)
+		if err != nil {
+			c.Error(apperror.Validation("request.invalid_date"))
+			return
+		}
+	}
+	progress, err := h.service.Progress(context.Background(), id, userID, date)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, progress)
+}
+
+// bindBudget разбирает тело запроса бюджета. При ошибке передаёт её в c.Error и возвращает false.
+func bindBudget(c *gin.Context) (budget.Budget, bool) {
+	var reqBody req.BudgetRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return budget.Budget{}, false
+	}
+	startDate, err := time.Parse(time.DateOnly, reqBody.StartDate)
+	if err != nil {
+		c.Error(apperror.Validation("request.invalid_date"))
+		return budget.Budget{}, false
+	}
+	limits := make([]budget.Limit, 0, len(reqBody.Limits))
+	for _, l := range reqBody.Limits {
+		limits = append(limits, budget.Limit{CategoryID: l.CategoryID, Amount: l.Amount})
+	}
+	return budget.Budget{
+		Name:      reqBody.Name,
+		Period:    reqBody.Period,
+		Currency:  reqBody.Currency,
+		StartDate: startDate,
+		Rollover:  reqBody.Rollover,
+		Limits:    limits,
+	}, true
+}
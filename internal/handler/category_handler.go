@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// CategoryHandler содержит обработчики HTTP-запросов для категорий доходов и расходов.
+type CategoryHandler struct {
+	service *service.CategoryService // Сервис категорий
+}
+
+// NewCategoryHandler создает новый экземпляр CategoryHandler.
+func NewCategoryHandler(service *service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{service: service}
+}
+
+// ListCategories возвращает все категории пользователя плоским списком; дерево строится по ParentID.
+// @Summary Список категорий
+// @Tags categories
+// @Produce json
+// @Param kind query string false "Фильтр по виду: income, expense"
+// @Success 200 {array} category.Category
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /categories [get]
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.CategoryListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	categories, err := h.service.List(context.Background(), userID, query.Kind)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, categories)
+}
+
+// GetCategory возвращает категорию пользователя по id.
+// @Summary Получить категорию
+// @Tags categories
+// @Produce json
+// @Param id path int true "ID категории"
+// @Success 200 {object} category.Category
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Категория не найдена"
+// @Security BearerAuth
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	cat, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, cat)
+}
+
+// CreateCategory создает категорию пользователя.
+// @Summary Создать категорию
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param input body request.CategoryRequest true "Данные категории"
+// @Success 200 {object} category.Category
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Родительская категория не найдена"
+// @Failure 409 {object} common.ErrorResponse "Категория с таким названием уже существует"
+// @Security BearerAuth
+// @Router /categories [post]
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var reqBody req.CategoryRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil || reqBody.Kind == "" {
+		c.Error(bindError(err))
+		return
+	}
+	created, err := h.service.Create(context.Background(), category.Category{
+		UserID:   userID,
+		ParentID: reqBody.ParentID,
+		Name:     reqBody.Name,
+		Kind:     reqBody.Kind,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// UpdateCategory переименовывает категорию и/или переносит её к другому родителю.
+// @Summary Изменить категорию
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "ID категории"
+// @Param input body request.CategoryRequest true "Данные категории"
+// @Success 200 {object} category.Category
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Категория не найдена"
+// @Failure 409 {object} common.ErrorResponse "Категория с таким названием уже существует"
+// @Security BearerAuth
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	var reqBody req.CategoryRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	updated, err := h.service.Update(context.Background(), category.Category{
+		ID:       id,
+		UserID:   userID,
+		ParentID: reqBody.ParentID,
+		Name:     reqBody.Name,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteCategory удаляет категорию; подкатегории переходят к её родителю, операции остаются без категории.
+// @Summary Удалить категорию
+// @Tags categories
+// @Param id path int true "ID категории"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Категория не найдена"
+// @Security BearerAuth
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// MergeCategory переносит операции и подкатегории в другую категорию того же вида и удаляет исходную.
+// @Summary Объединить категории
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path int true "ID исходной категории"
+// @Param input body request.CategoryMergeRequest true "Категория назначения"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Категория не найдена"
+// @Failure 409 {object} common.ErrorResponse "Категория с таким названием уже существует"
+// @Security BearerAuth
+// @Router /categories/{id}/merge [post]
+func (h *CategoryHandler) MergeCategory(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	var reqBody req.CategoryMergeRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if err := h.service.Merge(context.Background(), userID, id, reqBody.TargetID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// EmailVerificationHandler содержит обработчики HTTP-запросов для подтверждения email.
+type EmailVerificationHandler struct {
+	service *service.EmailVerificationService // Сервис подтверждения email
+}
+
+// NewEmailVerificationHandler создает новый экземпляр EmailVerificationHandler.
+func NewEmailVerificationHandler(service *service.EmailVerificationService) *EmailVerificationHandler {
+	return &EmailVerificationHandler{service: service}
+}
+
+// VerifyEmail обрабатывает запрос подтверждения email по токену из письма.
+// @Summary Подтверждение email
+// @Description Отмечает email подтверждённым по токену из ссылки. Снятые ограничения действуют со следующего обновления токенов.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body request.VerifyEmailRequest true "Токен из письма"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "Недействительный токен"
+// @Router /email/verify [post]
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	var reqBody req.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if err := h.service.Verify(context.Background(), reqBody.Token); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// ResendVerification обрабатывает запрос повторной отправки письма для подтверждения email.
+// Ответ одинаков для любого email, частые запросы молча пропускаются.
+// @Summary Повторная отправка письма для подтверждения email
+// @Description Отправляет новую ссылку для подтверждения email, действующую сутки. Не чаще раза в 2 минуты и не больше 5 писем в сутки.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body request.ResendVerificationRequest true "Email пользователя"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Router /email/verify/resend [post]
+func (h *EmailVerificationHandler) ResendVerification(c *gin.Context) {
+	var reqBody req.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if err := h.service.Resend(context.Background(), reqBody.Email, middleware.Language(c)); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+)
+
+// errInvalidID — ответ на нечисловой id в пути запроса.
+var errInvalidID = apperror.Validation("request.invalid_id").WithFields(apperror.Field("id", "field.integer_expected"))
+
+func init() {
+	// Ошибки полей называют поле так же, как клиент: по ключу JSON или имени параметра запроса.
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// requestFieldName возвращает имя поля структуры запроса из тега json, form или uri.
+func requestFieldName(f reflect.StructField) string {
+	for _, key := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(f.Tag.Get(key), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// bindError превращает ошибку разбора запроса (ShouldBindJSON, ShouldBindQuery, ShouldBind) в ошибку
+// валидации: нарушенные правила binding и значения неверного типа перечисляются по полям.
+func bindError(err error) error {
+	var validationErrs validator.ValidationErrors
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrs):
+		fields := make([]apperror.FieldError, 0, len(validationErrs))
+		for _, fe := range validationErrs {
+			fields = append(fields, ruleError(fe))
+		}
+		return apperror.Validation(apperror.CodeValidationFailed).WithFields(fields...)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return apperror.Validation(apperror.CodeValidationFailed).WithFields(apperror.Field(typeErr.Field, "field.wrong_type"))
+	case errors.As(err, &syntaxErr):
+		return apperror.Validation("request.invalid_json")
+	}
+	return apperror.Validation(apperror.CodeValidationFailed)
+}
+
+// fieldPath возвращает путь к полю без имени структуры запроса, например limits[0].category_id.
+func fieldPath(fe validator.FieldError) string {
+	_, path, ok := strings.Cut(fe.Namespace(), ".")
+	if !ok {
+		return fe.Field()
+	}
+	return path
+}
+
+// ruleError описывает нарушенное правило binding как ошибку поля.
+func ruleError(fe validator.FieldError) apperror.FieldError {
+	field := fieldPath(fe)
+	switch fe.Tag() {
+	case "required":
+		return apperror.Field(field, "field.required")
+	case "email":
+		return apperror.Field(field, "field.email")
+	case "oneof":
+		return apperror.Field(field, "field.oneof", strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min", "max":
+		key := "field." + fe.Tag()
+		switch fe.Kind() {
+		case reflect.String:
+			key += "_length"
+		case reflect.Slice, reflect.Map:
+			key += "_items"
+		}
+		return apperror.Field(field, key, fe.Param())
+	}
+	return apperror.Field(field, "field.invalid")
+}
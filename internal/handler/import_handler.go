@@ -0,0 +1,508 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/importer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+const (
+	maxStatementSize = 10 << 20 // Максимальный размер загружаемой выписки в байтах
+	maxFormOverhead  = 1 << 20  // Запас на остальные поля и разделители multipart-формы с выпиской
+)
+
+// ImportHandler содержит обработчики HTTP-запросов для импорта банковских выписок.
+type ImportHandler struct {
+	service *service.ImportService // Сервис импорта
+}
+
+// NewImportHandler создает новый экземпляр ImportHandler.
+func NewImportHandler(service *service.ImportService) *ImportHandler {
+	return &ImportHandler{service: service}
+}
+
+// ListImportProfiles возвращает профили импорта CSV пользователя.
+// @Summary Список профилей импорта
+// @Tags imports
+// @Produce json
+// @Success 200 {array} imports.Profile
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /imports/profiles [get]
+func (h *ImportHandler) ListImportProfiles(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	profiles, err := h.service.ListProfiles(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, profiles)
+}
+
+// GetImportProfile возвращает профиль импорта по id.
+// @Summary Получить профиль импорта
+// @Tags imports
+// @Produce json
+// @Param id path int true "ID профиля"
+// @Success 200 {object} imports.Profile
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Профиль не найден"
+// @Security BearerAuth
+// @Router /imports/profiles/{id} [get]
+func (h *ImportHandler) GetImportProfile(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	p, err := h.service.GetProfile(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, p)
+}
+
+// CreateImportProfile сохраняет профиль сопоставления колонок CSV.
+// @Summary Создать профиль импорта
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Param input body request.ImportProfileRequest true "Данные профиля"
+// @Success 200 {object} imports.Profile
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 409 {object} common.ErrorResponse "Профиль с таким названием уже существует"
+// @Security BearerAuth
+// @Router /imports/profiles [post]
+func (h *ImportHandler) CreateImportProfile(c *gin.Context) {
+	p, ok := bindImportProfile(c)
+	if !ok {
+		return
+	}
+	p.UserID = middleware.MustGetPrincipal(c).UserID
+	created, err := h.service.CreateProfile(context.Background(), p)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// UpdateImportProfile изменяет профиль импорта.
+// @Summary Изменить профиль импорта
+// @Tags imports
+// @Accept json
+// @Produce json
+// @Param id path int true "ID профиля"
+// @Param input body request.ImportProfileRequest true "Данные профиля"
+// @Success 200 {object} imports.Profile
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Профиль не найден"
+// @Failure 409 {object} common.ErrorResponse "Профиль с таким названием уже существует"
+// @Security BearerAuth
+// @Router /imports/profiles/{id} [put]
+func (h *ImportHandler) UpdateImportProfile(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	p, ok := bindImportProfile(c)
+	if !ok {
+		return
+	}
+	p.ID = id
+	p.UserID = middleware.MustGetPrincipal(c).UserID
+	updated, err := h.service.UpdateProfile(context.Background(), p)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteImportProfile удаляет профиль импорта.
+// @Summary Удалить профиль импорта
+// @Tags imports
+// @Param id path int true "ID профиля"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Профиль не найден"
+// @Security BearerAuth
+// @Router /imports/profiles/{id} [delete]
+func (h *ImportHandler) DeleteImportProfile(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.DeleteProfile(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// ImportCSV загружает CSV-выписку и возвращает предпросмотр: разобранные строки и ошибки по строкам.
+// Операции создаются только после подтверждения импорта.
+// @Summary Предпросмотр импорта CSV
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param account_id formData int true "ID банковского аккаунта"
+// @Param profile_id formData int true "ID профиля импорта"
+// @Param file formData file true "CSV-файл выписки"
+// @Success 200 {object} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт или профиль не найден"
+// @Security BearerAuth
+// @Router /imports/csv [post]
+func (h *ImportHandler) ImportCSV(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var form req.ImportCSVForm
+	if !bindStatementForm(c, &form) {
+		return
+	}
+	file, fileName, ok := openStatement(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	batch, err := h.service.PreviewCSV(context.Background(), userID, form.AccountID, form.ProfileID, fileName, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatch(c, batch)
+}
+
+// ImportOFX загружает выписку OFX/QFX (SGML или XML) и возвращает предпросмотр со сверкой баланса.
+// Операции, чей FITID уже импортирован на аккаунт, помечаются и при подтверждении пропускаются.
+// @Summary Предпросмотр импорта OFX/QFX
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param account_id formData int true "ID банковского аккаунта"
+// @Param file formData file true "Файл выписки OFX/QFX"
+// @Success 200 {object} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /imports/ofx [post]
+func (h *ImportHandler) ImportOFX(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var form req.ImportOFXForm
+	if !bindStatementForm(c, &form) {
+		return
+	}
+	file, fileName, ok := openStatement(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	batch, err := h.service.PreviewOFX(context.Background(), userID, form.AccountID, fileName, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatch(c, batch)
+}
+
+// ImportQIF загружает выписку QIF и возвращает предпросмотр.
+// @Summary Предпросмотр импорта QIF
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param account_id formData int true "ID банковского аккаунта"
+// @Param date_order formData string false "Порядок частей даты: MDY (по умолчанию), DMY, YMD"
+// @Param decimal_separator formData string false "Десятичный разделитель: точка (по умолчанию) или запятая"
+// @Param encoding formData string false "Кодировка: utf-8 (по умолчанию) или windows-1251"
+// @Param file formData file true "Файл выписки QIF"
+// @Success 200 {object} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /imports/qif [post]
+func (h *ImportHandler) ImportQIF(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var form req.ImportQIFForm
+	if !bindStatementForm(c, &form) {
+		return
+	}
+	file, fileName, ok := openStatement(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	opts := importer.QIFOptions{DateOrder: form.DateOrder, DecimalSeparator: form.DecimalSeparator, Encoding: form.Encoding}
+	batch, err := h.service.PreviewQIF(context.Background(), userID, form.AccountID, opts, fileName, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatch(c, batch)
+}
+
+// ImportCAMT053 загружает выписку ISO 20022 camt.053 и возвращает предпросмотр по каждому счёту из файла.
+// Счета сопоставляются с банковскими аккаунтами по IBAN; выписки по одному счёту объединяются в один импорт.
+// @Summary Предпросмотр импорта camt.053
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param account_id formData int false "ID аккаунта, если счёт в файле один и не найден по IBAN"
+// @Param file formData file true "XML-файл выписки camt.053"
+// @Success 200 {array} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /imports/camt053 [post]
+func (h *ImportHandler) ImportCAMT053(c *gin.Context) {
+	h.importStatements(c, h.service.PreviewCAMT053)
+}
+
+// ImportMT940 загружает выписку SWIFT MT940 и возвращает предпросмотр по каждому счёту из файла.
+// Счета сопоставляются с банковскими аккаунтами по IBAN из поля :25:.
+// @Summary Предпросмотр импорта MT940
+// @Tags imports
+// @Accept multipart/form-data
+// @Produce json
+// @Param account_id formData int false "ID аккаунта, если счёт в файле один и не найден по IBAN"
+// @Param file formData file true "Файл выписки MT940"
+// @Success 200 {array} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /imports/mt940 [post]
+func (h *ImportHandler) ImportMT940(c *gin.Context) {
+	h.importStatements(c, h.service.PreviewMT940)
+}
+
+// ListImports возвращает импорты пользователя без строк, от новых к старым.
+// @Summary Список импортов
+// @Tags imports
+// @Produce json
+// @Success 200 {array} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /imports [get]
+func (h *ImportHandler) ListImports(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	batches, err := h.service.ListBatches(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatchList(c, batches)
+}
+
+// GetImport возвращает импорт со строками выписки.
+// @Summary Получить импорт
+// @Tags imports
+// @Produce json
+// @Param id path int true "ID импорта"
+// @Success 200 {object} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Импорт не найден"
+// @Security BearerAuth
+// @Router /imports/{id} [get]
+func (h *ImportHandler) GetImport(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	batch, err := h.service.GetBatch(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatch(c, batch)
+}
+
+// CommitImport подтверждает импорт: создаёт операции из корректных строк и изменяет баланс аккаунта.
+// @Summary Подтвердить импорт
+// @Tags imports
+// @Produce json
+// @Param id path int true "ID импорта"
+// @Success 200 {object} imports.Batch
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Импорт не найден"
+// @Failure 409 {object} common.ErrorResponse "Импорт уже подтверждён"
+// @Security BearerAuth
+// @Router /imports/{id}/commit [post]
+func (h *ImportHandler) CommitImport(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	batch, err := h.service.Commit(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatch(c, batch)
+}
+
+// DeleteImport отменяет неподтверждённый импорт.
+// @Summary Отменить импорт
+// @Tags imports
+// @Param id path int true "ID импорта"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Импорт не найден"
+// @Failure 409 {object} common.ErrorResponse "Импорт уже подтверждён"
+// @Security BearerAuth
+// @Router /imports/{id} [delete]
+func (h *ImportHandler) DeleteImport(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.DeleteBatch(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// importStatements загружает многосчётную выписку и передаёт её в функцию предпросмотра формата.
+func (h *ImportHandler) importStatements(c *gin.Context, preview func(ctx context.Context, userID, accountID int, fileName string, r io.Reader) ([]imports.Batch, error)) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var form req.ImportStatementForm
+	if !bindStatementForm(c, &form) {
+		return
+	}
+	file, fileName, ok := openStatement(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	batches, err := preview(context.Background(), userID, form.AccountID, fileName, file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	writeBatchList(c, batches)
+}
+
+// bindStatementForm разбирает multipart-форму с выпиской в form. Тело запроса ограничивается до разбора:
+// иначе форма целиком читалась бы в память и временные файлы до проверки размера выписки.
+// При ошибке передаёт её в c.Error и возвращает false.
+func bindStatementForm(c *gin.Context, form any) bool {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxStatementSize+maxFormOverhead)
+	if err := c.ShouldBind(form); err != nil {
+		c.Error(statementFormError(err, bindError(err)))
+		return false
+	}
+	return true
+}
+
+// statementFormError заменяет ошибку чтения слишком большого тела запроса на import.file_too_large.
+func statementFormError(err, fallback error) error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return apperror.Validation("import.file_too_large")
+	}
+	return fallback
+}
+
+// openStatement открывает файл выписки из поля file multipart-формы, разобранной bindStatementForm.
+// При ошибке передаёт её в c.Error и возвращает false.
+func openStatement(c *gin.Context) (multipart.File, string, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.Error(statementFormError(err, apperror.Validation("import.file_required")))
+		return nil, "", false
+	}
+	if fileHeader.Size > maxStatementSize {
+		c.Error(apperror.Validation("import.file_too_large"))
+		return nil, "", false
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.Error(apperror.Validation("import.file_unreadable"))
+		return nil, "", false
+	}
+	return file, fileHeader.Filename, true
+}
+
+// bindImportProfile разбирает тело запроса профиля импорта. При ошибке передаёт её в c.Error и возвращает false.
+func bindImportProfile(c *gin.Context) (imports.Profile, bool) {
+	var reqBody req.ImportProfileRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return imports.Profile{}, false
+	}
+	return imports.Profile{
+		Name:             reqBody.Name,
+		Delimiter:        reqBody.Delimiter,
+		Encoding:         reqBody.Encoding,
+		SkipRows:         reqBody.SkipRows,
+		DateFormat:       reqBody.DateFormat,
+		DecimalSeparator: reqBody.DecimalSeparator,
+		SignConvention:   reqBody.SignConvention,
+		Columns:          reqBody.Columns,
+	}, true
+}
+
+// writeBatch отвечает пакетом импорта, переводя ошибки разбора строк на язык запроса.
+func writeBatch(c *gin.Context, batch *imports.Batch) {
+	localizeRowErrors(middleware.Language(c), batch)
+	c.JSON(http.StatusOK, batch)
+}
+
+// writeBatchList отвечает списком пакетов импорта, переводя ошибки разбора строк на язык запроса.
+func writeBatchList(c *gin.Context, batches []imports.Batch) {
+	lang := middleware.Language(c)
+	for i := range batches {
+		localizeRowErrors(lang, &batches[i])
+	}
+	c.JSON(http.StatusOK, batches)
+}
+
+// localizeRowErrors заменяет ключи ошибок разбора строк пакета сообщениями на языке lang.
+// Ошибки, сохранённые текстом до появления ключей сообщений, остаются как есть.
+func localizeRowErrors(lang string, batch *imports.Batch) {
+	for i := range batch.Rows {
+		if batch.Rows[i].Error != "" {
+			batch.Rows[i].Error = i18n.Translate(lang, batch.Rows[i].Error)
+		}
+	}
+}
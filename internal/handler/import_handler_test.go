@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+)
+
+// uploadStatement отправляет форму с выпиской размером size и возвращает ошибку, переданную в c.Error.
+func uploadStatement(t *testing.T, size int) error {
+	t.Helper()
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	w.WriteField("account_id", "1")
+	part, _ := w.CreateFormFile("file", "statement.ofx")
+	part.Write([]byte(strings.Repeat("x", size)))
+	w.Close()
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("POST", "/imports/ofx", &body)
+	c.Request.Header.Set("Content-Type", w.FormDataContentType())
+	var form req.ImportOFXForm
+	if bindStatementForm(c, &form) {
+		if file, _, ok := openStatement(c); ok {
+			file.Close()
+		}
+	}
+	if len(c.Errors) == 0 {
+		return nil
+	}
+	return c.Errors.Last().Err
+}
+
+func TestStatementUploadSizeLimit(t *testing.T) {
+	if err := uploadStatement(t, 1024); err != nil {
+		t.Fatalf("small statement: %v", err)
+	}
+	for _, size := range []int{maxStatementSize + 1, maxStatementSize + maxFormOverhead + 1} {
+		var appErr *apperror.Error
+		if err := uploadStatement(t, size); !errors.As(err, &appErr) || appErr.Key != "import.file_too_large" {
+			t.Errorf("statement of %d bytes: error = %v, want import.file_too_large", size, err)
+		}
+	}
+}
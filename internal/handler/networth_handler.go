@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// NetWorthHandler содержит обработчики HTTP-запросов для капитала пользователя.
+type NetWorthHandler struct {
+	service *service.NetWorthService // Сервис капитала
+}
+
+// NewNetWorthHandler создает новый экземпляр NetWorthHandler.
+func NewNetWorthHandler(service *service.NetWorthService) *NetWorthHandler {
+	return &NetWorthHandler{service: service}
+}
+
+// GetNetWorth возвращает текущий капитал пользователя в домашней валюте.
+// @Summary Текущий капитал
+// @Tags networth
+// @Produce json
+// @Success 200 {object} account.Totals
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /networth [get]
+func (h *NetWorthHandler) GetNetWorth(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	totals, err := h.service.Current(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, totals)
+}
+
+// GetNetWorthHistory возвращает историю капитала пользователя в домашней валюте.
+// @Summary История капитала
+// @Tags networth
+// @Produce json
+// @Param from query string false "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад"
+// @Param to query string false "Конец периода (YYYY-MM-DD), по умолчанию сегодня"
+// @Param granularity query string false "Шаг ряда: day, week, month (по умолчанию day)"
+// @Success 200 {object} networth.Series
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /networth/history [get]
+func (h *NetWorthHandler) GetNetWorthHistory(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.NetWorthHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	series, err := h.service.History(context.Background(), userID, query.From, query.To, query.Granularity)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, series)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// PasswordResetHandler содержит обработчики HTTP-запросов для восстановления доступа к аккаунту.
+type PasswordResetHandler struct {
+	service *service.PasswordResetService // Сервис сброса пароля
+}
+
+// NewPasswordResetHandler создает новый экземпляр PasswordResetHandler.
+func NewPasswordResetHandler(service *service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{service: service}
+}
+
+// ForgotPassword обрабатывает запрос ссылки для сброса пароля.
+// Ответ одинаков для зарегистрированных и неизвестных email.
+// @Summary Запрос сброса пароля
+// @Description Отправляет на email одноразовую ссылку для сброса пароля, действующую один час.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body request.ForgotPasswordRequest true "Email пользователя"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Router /password/forgot [post]
+func (h *PasswordResetHandler) ForgotPassword(c *gin.Context) {
+	var reqBody req.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if err := h.service.Forgot(context.Background(), reqBody.Email, middleware.Language(c)); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// ResetPassword обрабатывает запрос установки нового пароля по токену из письма.
+// @Summary Сброс пароля
+// @Description Устанавливает новый пароль по токену из ссылки и завершает все сессии пользователя.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body request.ResetPasswordRequest true "Токен и новый пароль"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "Слабый пароль или недействительный токен"
+// @Router /password/reset [post]
+func (h *PasswordResetHandler) ResetPassword(c *gin.Context) {
+	var reqBody req.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if err := h.service.Reset(context.Background(), reqBody.Token, reqBody.Password); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
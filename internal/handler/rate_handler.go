@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// RateHandler содержит обработчики HTTP-запросов для курсов валют.
+type RateHandler struct {
+	service *service.RateService // Сервис курсов валют
+}
+
+// NewRateHandler создает новый экземпляр RateHandler.
+func NewRateHandler(service *service.RateService) *RateHandler {
+	return &RateHandler{service: service}
+}
+
+// ListCurrencies возвращает справочник валют ISO 4217, допустимых для аккаунтов и бюджетов.
+// @Summary Справочник валют
+// @Tags rates
+// @Produce json
+// @Success 200 {array} currency.Currency
+// @Router /currencies [get]
+func (h *RateHandler) ListCurrencies(c *gin.Context) {
+	c.JSON(http.StatusOK, currency.All())
+}
+
+// ListRates возвращает историю курса валютной пары.
+// @Summary История курса валют
+// @Tags rates
+// @Produce json
+// @Param base query string true "Валюта, курс которой запрашивается"
+// @Param quote query string true "Валюта, в которой указан курс"
+// @Param from query string false "Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад"
+// @Param to query string false "Конец периода (YYYY-MM-DD), по умолчанию сегодня"
+// @Success 200 {array} rate.Rate
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /rates [get]
+func (h *RateHandler) ListRates(c *gin.Context) {
+	var query req.RateListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	rates, err := h.service.List(context.Background(), query.Base, query.Quote, query.From, query.To)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rates)
+}
+
+// Convert пересчитывает сумму из одной валюты в другую по курсу на дату.
+// @Summary Пересчёт суммы между валютами
+// @Tags rates
+// @Produce json
+// @Param amount query string true "Сумма в исходной валюте"
+// @Param from query string true "Исходная валюта"
+// @Param to query string true "Целевая валюта"
+// @Param date query string false "Дата курса (YYYY-MM-DD), по умолчанию сегодня"
+// @Success 200 {object} rate.Conversion
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Курс не найден"
+// @Security BearerAuth
+// @Router /rates/convert [get]
+func (h *RateHandler) Convert(c *gin.Context) {
+	var query req.ConvertQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	amount, err := money.Parse(query.Amount)
+	if err != nil {
+		c.Error(apperror.Validation("request.invalid_amount"))
+		return
+	}
+	date := query.Date
+	if date.IsZero() {
+		date = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	conversion, err := h.service.Convert(context.Background(), amount, query.From, query.To, date)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, conversion)
+}
@@ -0,0 +1,331 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/recurrence"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// defaultUpcoming — количество предстоящих платежей в ответе по умолчанию.
+const defaultUpcoming = 10
+
+// RecurrenceHandler содержит обработчики HTTP-запросов для регулярных операций.
+type RecurrenceHandler struct {
+	service *service.RecurrenceService // Сервис регулярных операций
+}
+
+// NewRecurrenceHandler создает новый экземпляр RecurrenceHandler.
+func NewRecurrenceHandler(service *service.RecurrenceService) *RecurrenceHandler {
+	return &RecurrenceHandler{service: service}
+}
+
+// ListRecurrences возвращает регулярные операции пользователя.
+// @Summary Список регулярных операций
+// @Tags recurrences
+// @Produce json
+// @Success 200 {array} recurrence.Recurrence
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /recurrences [get]
+func (h *RecurrenceHandler) ListRecurrences(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	recurrences, err := h.service.List(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, recurrences)
+}
+
+// GetRecurrence возвращает регулярную операцию пользователя по id.
+// @Summary Получить регулярную операцию
+// @Tags recurrences
+// @Produce json
+// @Param id path int true "ID регулярной операции"
+// @Success 200 {object} recurrence.Recurrence
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Регулярная операция не найдена"
+// @Security BearerAuth
+// @Router /recurrences/{id} [get]
+func (h *RecurrenceHandler) GetRecurrence(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	rec, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rec)
+}
+
+// CreateRecurrence создает регулярную операцию.
+// @Summary Создать регулярную операцию
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param input body request.RecurrenceRequest true "Данные регулярной операции"
+// @Success 200 {object} recurrence.Recurrence
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт или категория не найдены"
+// @Security BearerAuth
+// @Router /recurrences [post]
+func (h *RecurrenceHandler) CreateRecurrence(c *gin.Context) {
+	rec, ok := bindRecurrence(c)
+	if !ok {
+		return
+	}
+	rec.UserID = middleware.MustGetPrincipal(c).UserID
+	created, err := h.service.Create(context.Background(), rec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// UpdateRecurrence изменяет регулярную операцию; уже созданные платежи не меняются.
+// @Summary Изменить регулярную операцию
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "ID регулярной операции"
+// @Param input body request.RecurrenceRequest true "Данные регулярной операции"
+// @Success 200 {object} recurrence.Recurrence
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Регулярная операция, аккаунт или категория не найдены"
+// @Security BearerAuth
+// @Router /recurrences/{id} [put]
+func (h *RecurrenceHandler) UpdateRecurrence(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	rec, ok := bindRecurrence(c)
+	if !ok {
+		return
+	}
+	rec.ID = id
+	rec.UserID = middleware.MustGetPrincipal(c).UserID
+	updated, err := h.service.Update(context.Background(), rec)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteRecurrence удаляет регулярную операцию; созданные по ней операции остаются.
+// @Summary Удалить регулярную операцию
+// @Tags recurrences
+// @Param id path int true "ID регулярной операции"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Регулярная операция не найдена"
+// @Security BearerAuth
+// @Router /recurrences/{id} [delete]
+func (h *RecurrenceHandler) DeleteRecurrence(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// GetUpcoming возвращает предстоящие платежи регулярной операции.
+// @Summary Предстоящие платежи
+// @Tags recurrences
+// @Produce json
+// @Param id path int true "ID регулярной операции"
+// @Param count query int false "Количество платежей (1-100, по умолчанию 10)"
+// @Success 200 {array} recurrence.Planned
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Регулярная операция не найдена"
+// @Security BearerAuth
+// @Router /recurrences/{id}/upcoming [get]
+func (h *RecurrenceHandler) GetUpcoming(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	var query req.RecurrenceUpcomingQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if query.Count == 0 {
+		query.Count = defaultUpcoming
+	}
+	planned, err := h.service.Upcoming(context.Background(), id, userID, query.Count)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, planned)
+}
+
+// ListOccurrences возвращает платежи по расписанию: созданные операции, предложения и пропущенные платежи.
+// @Summary Платежи по расписанию
+// @Tags recurrences
+// @Produce json
+// @Param recurrence_id query int false "Фильтр по регулярной операции"
+// @Param status query string false "Фильтр по статусу: pending, posted, skipped"
+// @Success 200 {array} recurrence.Occurrence
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /recurrences/occurrences [get]
+func (h *RecurrenceHandler) ListOccurrences(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.OccurrenceListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	occurrences, err := h.service.ListOccurrences(context.Background(), userID, query.RecurrenceID, query.Status)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, occurrences)
+}
+
+// ConfirmOccurrence создает операцию по предложенному платежу. Тело запроса необязательно.
+// @Summary Подтвердить платёж
+// @Tags recurrences
+// @Accept json
+// @Produce json
+// @Param id path int true "ID платежа"
+// @Param input body request.OccurrenceConfirmRequest false "Фактические сумма и дата"
+// @Success 200 {object} transaction.Transaction
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Платёж не найден"
+// @Failure 409 {object} common.ErrorResponse "Платёж уже обработан"
+// @Security BearerAuth
+// @Router /recurrences/occurrences/{id}/confirm [post]
+func (h *RecurrenceHandler) ConfirmOccurrence(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	var reqBody req.OccurrenceConfirmRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		c.Error(bindError(err))
+		return
+	}
+	var confirmation service.OccurrenceConfirmation
+	if reqBody.Amount.IsSet() {
+		confirmation.Amount = &reqBody.Amount
+	}
+	if reqBody.Date != "" {
+		date, err := time.Parse(time.DateOnly, reqBody.Date)
+		if err != nil {
+			c.Error(apperror.Validation("request.invalid_date"))
+			return
+		}
+		confirmation.Date = &date
+	}
+	t, err := h.service.Confirm(context.Background(), id, userID, confirmation)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+// SkipOccurrence помечает предложенный платёж пропущенным.
+// @Summary Пропустить платёж
+// @Tags recurrences
+// @Produce json
+// @Param id path int true "ID платежа"
+// @Success 200 {object} recurrence.Occurrence
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Платёж не найден"
+// @Failure 409 {object} common.ErrorResponse "Платёж уже обработан"
+// @Security BearerAuth
+// @Router /recurrences/occurrences/{id}/skip [post]
+func (h *RecurrenceHandler) SkipOccurrence(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	o, err := h.service.Skip(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, o)
+}
+
+// bindRecurrence разбирает тело запроса регулярной операции. При ошибке передаёт её в c.Error и возвращает false.
+func bindRecurrence(c *gin.Context) (recurrence.Recurrence, bool) {
+	var reqBody req.RecurrenceRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return recurrence.Recurrence{}, false
+	}
+	startDate, err := time.Parse(time.DateOnly, reqBody.StartDate)
+	if err != nil {
+		c.Error(apperror.Validation("request.invalid_date"))
+		return recurrence.Recurrence{}, false
+	}
+	rec := recurrence.Recurrence{
+		AccountID:   reqBody.AccountID,
+		Type:        reqBody.Type,
+		Amount:      reqBody.Amount,
+		Payee:       reqBody.Payee,
+		Note:        reqBody.Note,
+		CategoryID:  reqBody.CategoryID,
+		Tags:        reqBody.Tags,
+		Frequency:   reqBody.Frequency,
+		Interval:    reqBody.Interval,
+		MonthDay:    reqBody.MonthDay,
+		BusinessDay: reqBody.BusinessDay,
+		StartDate:   startDate,
+		Mode:        reqBody.Mode,
+		Active:      reqBody.Active == nil || *reqBody.Active,
+	}
+	if reqBody.EndDate != "" {
+		endDate, err := time.Parse(time.DateOnly, reqBody.EndDate)
+		if err != nil {
+			c.Error(apperror.Validation("request.invalid_date"))
+			return recurrence.Recurrence{}, false
+		}
+		rec.EndDate = &endDate
+	}
+	return rec, true
+}
@@ -0,0 +1,254 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rule"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// RuleHandler содержит обработчики HTTP-запросов для правил категоризации.
+type RuleHandler struct {
+	service *service.RuleService // Сервис правил
+}
+
+// NewRuleHandler создает новый экземпляр RuleHandler.
+func NewRuleHandler(service *service.RuleService) *RuleHandler {
+	return &RuleHandler{service: service}
+}
+
+// ListRules возвращает правила пользователя в порядке применения.
+// @Summary Список правил категоризации
+// @Tags rules
+// @Produce json
+// @Success 200 {array} rule.Rule
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /rules [get]
+func (h *RuleHandler) ListRules(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	rules, err := h.service.List(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// GetRule возвращает правило пользователя по id.
+// @Summary Получить правило
+// @Tags rules
+// @Produce json
+// @Param id path int true "ID правила"
+// @Success 200 {object} rule.Rule
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Правило не найдено"
+// @Security BearerAuth
+// @Router /rules/{id} [get]
+func (h *RuleHandler) GetRule(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	rl, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rl)
+}
+
+// CreateRule создает правило в конце списка правил пользователя.
+// @Summary Создать правило
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param input body request.RuleRequest true "Данные правила"
+// @Success 200 {object} rule.Rule
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт или категория не найдены"
+// @Security BearerAuth
+// @Router /rules [post]
+func (h *RuleHandler) CreateRule(c *gin.Context) {
+	rl, ok := bindRule(c)
+	if !ok {
+		return
+	}
+	rl.UserID = middleware.MustGetPrincipal(c).UserID
+	created, err := h.service.Create(context.Background(), rl)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// UpdateRule изменяет условия и действия правила; порядок правила не меняется.
+// @Summary Изменить правило
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param id path int true "ID правила"
+// @Param input body request.RuleRequest true "Данные правила"
+// @Success 200 {object} rule.Rule
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Правило, аккаунт или категория не найдены"
+// @Security BearerAuth
+// @Router /rules/{id} [put]
+func (h *RuleHandler) UpdateRule(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	rl, ok := bindRule(c)
+	if !ok {
+		return
+	}
+	rl.ID = id
+	rl.UserID = middleware.MustGetPrincipal(c).UserID
+	updated, err := h.service.Update(context.Background(), rl)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteRule удаляет правило пользователя.
+// @Summary Удалить правило
+// @Tags rules
+// @Param id path int true "ID правила"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Правило не найдено"
+// @Security BearerAuth
+// @Router /rules/{id} [delete]
+func (h *RuleHandler) DeleteRule(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// ReorderRules задаёт порядок применения правил.
+// @Summary Изменить порядок правил
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param input body request.RuleOrderRequest true "ID всех правил в новом порядке"
+// @Success 200 {array} rule.Rule
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /rules/order [put]
+func (h *RuleHandler) ReorderRules(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var reqBody req.RuleOrderRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	if err := h.service.Reorder(context.Background(), userID, reqBody.IDs); err != nil {
+		c.Error(err)
+		return
+	}
+	rules, err := h.service.List(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, rules)
+}
+
+// ApplyRules повторно применяет правила к доходам и расходам за период.
+// По умолчанию выполняется пробный запуск: изменения возвращаются, но не сохраняются.
+// @Summary Применить правила к истории операций
+// @Tags rules
+// @Accept json
+// @Produce json
+// @Param input body request.RuleApplyRequest true "Параметры применения"
+// @Success 200 {object} rule.ApplyResult
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /rules/apply [post]
+func (h *RuleHandler) ApplyRules(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var reqBody req.RuleApplyRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	opts := service.RuleApplyOptions{AccountID: reqBody.AccountID, Overwrite: reqBody.Overwrite, DryRun: true}
+	if reqBody.DryRun != nil {
+		opts.DryRun = *reqBody.DryRun
+	}
+	var err error
+	if reqBody.From != "" {
+		if opts.From, err = time.Parse(time.DateOnly, reqBody.From); err != nil {
+			c.Error(apperror.Validation("request.invalid_date"))
+			return
+		}
+	}
+	if reqBody.To != "" {
+		if opts.To, err = time.Parse(time.DateOnly, reqBody.To); err != nil {
+			c.Error(apperror.Validation("request.invalid_date"))
+			return
+		}
+	}
+	result, err := h.service.Apply(context.Background(), userID, opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// bindRule разбирает тело запроса правила. При ошибке передаёт её в c.Error и возвращает false.
+func bindRule(c *gin.Context) (rule.Rule, bool) {
+	var reqBody req.RuleRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return rule.Rule{}, false
+	}
+	rl := rule.Rule{
+		Name:         reqBody.Name,
+		Enabled:      reqBody.Enabled == nil || *reqBody.Enabled,
+		PayeePattern: reqBody.PayeePattern,
+		NotePattern:  reqBody.NotePattern,
+		AccountID:    reqBody.AccountID,
+		Type:         reqBody.Type,
+		CategoryID:   reqBody.CategoryID,
+		Tags:         reqBody.Tags,
+		RenamePayee:  reqBody.RenamePayee,
+	}
+	if reqBody.MinAmount.IsSet() {
+		rl.MinAmount = &reqBody.MinAmount
+	}
+	if reqBody.MaxAmount.IsSet() {
+		rl.MaxAmount = &reqBody.MaxAmount
+	}
+	return rl, true
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/response"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// TransactionHandler содержит обработчики HTTP-запросов для операций по банковским аккаунтам.
+type TransactionHandler struct {
+	service *service.TransactionService // Сервис операций
+}
+
+// NewTransactionHandler создает новый экземпляр TransactionHandler.
+func NewTransactionHandler(service *service.TransactionService) *TransactionHandler {
+	return &TransactionHandler{service: service}
+}
+
+// CreateTransaction создает операцию (поступление или расход) и изменяет баланс аккаунта.
+// @Summary Создать операцию
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param input body request.TransactionRequest true "Данные операции"
+// @Success 200 {object} transaction.Transaction
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /transactions [post]
+func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	t, ok := bindTransaction(c)
+	if !ok {
+		return
+	}
+	created, err := h.service.Create(context.Background(), userID, t)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// ListTransactions возвращает страницу операций пользователя от новых к старым.
+// @Summary Список операций
+// @Tags transactions
+// @Produce json
+// @Param account_id query int false "Фильтр по аккаунту"
+// @Param type query string false "Фильтр по типу: income, expense, adjustment, transfer_in, transfer_out"
+// @Param category_id query int false "Фильтр по категории (включая подкатегории)"
+// @Param tag query string false "Фильтр по тегу"
+// @Param from query string false "Начало периода (YYYY-MM-DD)"
+// @Param to query string false "Конец периода (YYYY-MM-DD)"
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 50)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Success 200 {object} response.TransactionListResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /transactions [get]
+func (h *TransactionHandler) ListTransactions(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.TransactionListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	filter := transaction.ListFilter{
+		AccountID:  query.AccountID,
+		CategoryID: query.CategoryID,
+		Tag:        query.Tag,
+		Type:       query.Type,
+		From:       query.From,
+		To:         query.To,
+		Limit:      query.Limit,
+		Cursor:     query.Cursor,
+	}
+	transactions, nextCursor, err := h.service.List(context.Background(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.TransactionListResponse{Items: transactions, NextCursor: nextCursor})
+}
+
+// GetTransaction возвращает операцию пользователя по id.
+// @Summary Получить операцию
+// @Tags transactions
+// @Produce json
+// @Param id path int true "ID операции"
+// @Success 200 {object} transaction.Transaction
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Операция не найдена"
+// @Security BearerAuth
+// @Router /transactions/{id} [get]
+func (h *TransactionHandler) GetTransaction(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	t, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+// UpdateTransaction изменяет операцию и пересчитывает балансы затронутых аккаунтов.
+// @Summary Обновить операцию
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "ID операции"
+// @Param input body request.TransactionRequest true "Данные операции"
+// @Success 200 {object} transaction.Transaction
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Операция или аккаунт не найдены"
+// @Security BearerAuth
+// @Router /transactions/{id} [put]
+func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	t, ok := bindTransaction(c)
+	if !ok {
+		return
+	}
+	updated, err := h.service.Update(context.Background(), id, userID, t)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteTransaction удаляет операцию и возвращает её сумму с баланса аккаунта.
+// @Summary Удалить операцию
+// @Tags transactions
+// @Param id path int true "ID операции"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Операция не найдена"
+// @Failure 409 {object} common.ErrorResponse "Операция является частью перевода"
+// @Security BearerAuth
+// @Router /transactions/{id} [delete]
+func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
+
+// ListDuplicates ищет пары вероятных дубликатов среди доходов и расходов пользователя:
+// одинаковая сумма на одном аккаунте, близкие даты и похожий контрагент (или совпадающий идентификатор банка).
+// @Summary Вероятные дубликаты операций
+// @Tags transactions
+// @Produce json
+// @Param account_id query int false "Фильтр по аккаунту"
+// @Param from query string false "Начало периода (YYYY-MM-DD), по умолчанию 90 дней назад"
+// @Param to query string false "Конец периода (YYYY-MM-DD), по умолчанию сегодня"
+// @Success 200 {array} transaction.Duplicate
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /transactions/duplicates [get]
+func (h *TransactionHandler) ListDuplicates(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.DuplicateListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	duplicates, err := h.service.FindDuplicates(context.Background(), userID, query.AccountID, query.From, query.To)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, duplicates)
+}
+
+// MergeTransaction объединяет операцию с её дубликатом: операция удаляется, её сумма возвращается с баланса,
+// а пустые поля оставшейся операции заполняются из удалённой. Копия удалённой операции сохраняется в журнале.
+// @Summary Объединить дубликаты
+// @Tags transactions
+// @Accept json
+// @Produce json
+// @Param id path int true "ID операции-дубликата, которая будет удалена"
+// @Param input body request.TransactionMergeRequest true "Операция, которая останется"
+// @Success 200 {object} transaction.Transaction
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Операция не найдена"
+// @Security BearerAuth
+// @Router /transactions/{id}/merge [post]
+func (h *TransactionHandler) MergeTransaction(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	var reqBody req.TransactionMergeRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	merged, err := h.service.Merge(context.Background(), userID, id, reqBody.TargetID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, merged)
+}
+
+// ListMerges возвращает журнал объединений дубликатов от новых записей к старым.
+// @Summary Журнал объединений операций
+// @Tags transactions
+// @Produce json
+// @Success 200 {array} transaction.Merge
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /transactions/merges [get]
+func (h *TransactionHandler) ListMerges(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	merges, err := h.service.ListMerges(context.Background(), userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, merges)
+}
+
+// bindTransaction разбирает тело запроса операции. При ошибке передаёт её в c.Error и возвращает false.
+func bindTransaction(c *gin.Context) (transaction.Transaction, bool) {
+	var reqBody req.TransactionRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return transaction.Transaction{}, false
+	}
+	date, err := time.Parse(time.DateOnly, reqBody.Date)
+	if err != nil {
+		c.Error(apperror.Validation("request.invalid_date"))
+		return transaction.Transaction{}, false
+	}
+	return transaction.Transaction{
+		AccountID:  reqBody.AccountID,
+		Type:       reqBody.Type,
+		Amount:     reqBody.Amount,
+		Date:       date,
+		Payee:      reqBody.Payee,
+		Note:       reqBody.Note,
+		CategoryID: reqBody.CategoryID,
+		Tags:       reqBody.Tags,
+	}, true
+}
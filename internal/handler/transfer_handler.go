@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/middleware"
+	req "github.com/stepanpotapov/moneyflow-go-backend/internal/models/request"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/response"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transfer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/service"
+)
+
+// TransferHandler содержит обработчики HTTP-запросов для переводов между аккаунтами.
+type TransferHandler struct {
+	service *service.TransferService // Сервис переводов
+}
+
+// NewTransferHandler создает новый экземпляр TransferHandler.
+func NewTransferHandler(service *service.TransferService) *TransferHandler {
+	return &TransferHandler{service: service}
+}
+
+// CreateTransfer выполняет перевод между своими аккаунтами, в том числе в разных валютах.
+// @Summary Перевод между аккаунтами
+// @Tags transfers
+// @Accept json
+// @Produce json
+// @Param input body request.TransferRequest true "Данные перевода"
+// @Success 200 {object} transfer.Transfer
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 403 {object} common.ErrorResponse "Аккаунт принадлежит другому пользователю"
+// @Failure 404 {object} common.ErrorResponse "Аккаунт не найден"
+// @Security BearerAuth
+// @Router /transfers [post]
+func (h *TransferHandler) CreateTransfer(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var reqBody req.TransferRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	date, err := time.Parse(time.DateOnly, reqBody.Date)
+	if err != nil {
+		c.Error(apperror.Validation("request.invalid_date"))
+		return
+	}
+	created, err := h.service.Create(context.Background(), userID, transfer.Transfer{
+		FromAccountID: reqBody.FromAccountID,
+		ToAccountID:   reqBody.ToAccountID,
+		FromAmount:    reqBody.Amount,
+		ToAmount:      reqBody.ToAmount,
+		Rate:          reqBody.Rate,
+		Date:          date,
+		Note:          reqBody.Note,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, created)
+}
+
+// ListTransfers возвращает страницу переводов пользователя от новых к старым.
+// @Summary Список переводов
+// @Tags transfers
+// @Produce json
+// @Param account_id query int false "Фильтр по аккаунту"
+// @Param limit query int false "Размер страницы (1-100, по умолчанию 50)"
+// @Param cursor query string false "Курсор следующей страницы"
+// @Success 200 {object} response.TransferListResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Security BearerAuth
+// @Router /transfers [get]
+func (h *TransferHandler) ListTransfers(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	var query req.TransferListQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(bindError(err))
+		return
+	}
+	filter := transfer.ListFilter{AccountID: query.AccountID, Limit: query.Limit, Cursor: query.Cursor}
+	transfers, nextCursor, err := h.service.List(context.Background(), userID, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, response.TransferListResponse{Items: transfers, NextCursor: nextCursor})
+}
+
+// GetTransfer возвращает перевод пользователя по id.
+// @Summary Получить перевод
+// @Tags transfers
+// @Produce json
+// @Param id path int true "ID перевода"
+// @Success 200 {object} transfer.Transfer
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Перевод не найден"
+// @Security BearerAuth
+// @Router /transfers/{id} [get]
+func (h *TransferHandler) GetTransfer(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	t, err := h.service.Get(context.Background(), id, userID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, t)
+}
+
+// DeleteTransfer отменяет перевод и возвращает суммы на балансы аккаунтов.
+// @Summary Отменить перевод
+// @Tags transfers
+// @Param id path int true "ID перевода"
+// @Success 200 {object} response.MessageResponse
+// @Failure 400 {object} common.ErrorResponse "ошибка"
+// @Failure 401 {object} common.ErrorResponse "Неавторизован"
+// @Failure 404 {object} common.ErrorResponse "Перевод не найден"
+// @Security BearerAuth
+// @Router /transfers/{id} [delete]
+func (h *TransferHandler) DeleteTransfer(c *gin.Context) {
+	userID := middleware.MustGetPrincipal(c).UserID
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.Error(errInvalidID)
+		return
+	}
+	if err := h.service.Delete(context.Background(), id, userID); err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "ok"})
+}
@@ -0,0 +1,215 @@
+package i18n
+
+// en — каталог сообщений на английском языке.
+var en = map[string]string{
+	// Ошибки с машиночитаемым кодом: ключ совпадает с кодом
+	"validation_failed":           "Invalid request data",
+	"internal_error":              "Internal server error",
+	"not_found":                   "Not found",
+	"unauthorized":                "Unauthorized",
+	"forbidden":                   "Insufficient permissions",
+	"weak_password":               "Password is too weak",
+	"email_taken":                 "A user with this email is already registered",
+	"invalid_credentials":         "Invalid email or password",
+	"invalid_refresh_token":       "Invalid refresh token",
+	"refresh_token_reused":        "Refresh token has already been used, the session has been revoked",
+	"refresh_token_expired":       "Refresh token has expired",
+	"session_not_found":           "Session not found",
+	"invalid_language":            "Unsupported language: use ru or en",
+	"invalid_reset_token":         "The password reset link is invalid or has expired, request a new one",
+	"invalid_verification_token":  "The email verification link is invalid or has expired, request a new one",
+	"email_not_verified":          "Email is not verified: follow the link from the email or request a new one",
+	"account_not_found":           "Account not found",
+	"version_conflict":            "The account was changed on another device: fetch the latest version and retry",
+	"invalid_cursor":              "Invalid cursor",
+	"invalid_currency":            "Invalid currency code: use an ISO 4217 code such as USD",
+	"account_has_transactions":    "Cannot change the currency of an account that has transactions",
+	"iban_taken":                  "An account with this IBAN already exists",
+	"budget_not_found":            "Budget not found",
+	"category_not_found":          "Category not found",
+	"category_exists":             "A category with this name already exists",
+	"import_profile_not_found":    "Import profile not found",
+	"import_not_found":            "Import not found",
+	"import_profile_exists":       "A profile with this name already exists",
+	"statement_account_not_found": "No account with IBAN %s",
+	"import_committed":            "The import has already been committed",
+	"invalid_statement":           "Could not parse the statement",
+	"rate_not_found":              "Exchange rate not found",
+	"recurrence_not_found":        "Recurring transaction not found",
+	"occurrence_not_found":        "Payment not found",
+	"occurrence_processed":        "The payment has already been processed",
+	"rule_not_found":              "Rule not found",
+	"transaction_not_found":       "Transaction not found",
+	"transaction_in_transfer":     "The transaction is part of a transfer; delete the whole transfer",
+	"transfer_not_found":          "Transfer not found",
+	"foreign_account":             "Transfers are only allowed between your own accounts",
+
+	// Общие
+	"request.invalid_id":      "Invalid id",
+	"request.invalid_json":    "Malformed JSON",
+	"request.invalid_date":    "Invalid date",
+	"request.invalid_amount":  "Invalid amount",
+	"period.start_after_end":  "The period starts after it ends",
+	"money.too_many_decimals": "Too many decimal places for the currency",
+	"money.too_many_digits":   "The number is too large: at most %d digits are allowed in the integer part",
+
+	// Поля запроса
+	"field.integer_expected": "An integer is expected",
+	"field.wrong_type":       "Invalid value type",
+	"field.required":         "This field is required",
+	"field.email":            "Invalid email",
+	"field.oneof":            "Allowed values: %s",
+	"field.min_length":       "Must be at least %s characters long",
+	"field.min_items":        "Must contain at least %s items",
+	"field.min":              "Must be at least %s",
+	"field.max_length":       "Must be at most %s characters long",
+	"field.max_items":        "Must contain at most %s items",
+	"field.max":              "Must be at most %s",
+	"field.invalid":          "Invalid value",
+
+	// Аккаунты
+	"account.invalid_sort":              "Invalid sort field",
+	"account.invalid_type_filter":       "Invalid account type",
+	"account.name_currency_required":    "Name and currency are required",
+	"account.invalid_iban":              "Invalid IBAN",
+	"account.invalid_bic":               "Invalid BIC",
+	"account.invalid_type":              "Invalid account type: use checking, cash, credit_card, savings, loan or investment",
+	"account.credit_params_only_card":   "Credit limit and statement day are only allowed for credit cards",
+	"account.credit_limit_negative":     "Credit limit cannot be negative",
+	"account.statement_day_range":       "Statement day must be between 1 and 31",
+	"account.interest_rate_not_allowed": "Interest rate is only allowed for savings and loans",
+	"account.interest_rate_range":       "Interest rate must be between 0 and 100",
+	"account.interest_rate_precision":   "Interest rate must have at most 4 decimal places",
+
+	// Бюджеты
+	"budget.date_before_start":       "The date is before the budget starts",
+	"budget.name_currency_required":  "Name and currency are required",
+	"budget.invalid_period":          "Invalid budget period",
+	"budget.limits_required":         "At least one limit is required",
+	"budget.duplicate_limit":         "A category limit is specified more than once",
+	"budget.limit_not_positive":      "A limit must be greater than zero",
+	"budget.expense_categories_only": "Limits can only be set for expense categories",
+
+	// Категории
+	"category.name_required":        "Category name is required",
+	"category.kind_mismatch_parent": "The category kind must match the parent category kind",
+	"category.move_into_itself":     "A category cannot be moved into itself",
+	"category.merge_into_itself":    "A category cannot be merged into itself",
+	"category.merge_kind_mismatch":  "Income and expense categories cannot be merged",
+	"category.merge_into_child":     "A category cannot be merged into its subcategory",
+
+	// Категории по умолчанию
+	"category.default.groceries":        "Groceries",
+	"category.default.restaurants":      "Cafes and restaurants",
+	"category.default.transport":        "Transport",
+	"category.default.taxi":             "Taxi",
+	"category.default.public_transport": "Public transport",
+	"category.default.fuel":             "Fuel",
+	"category.default.housing":          "Housing",
+	"category.default.rent":             "Rent",
+	"category.default.utilities":        "Utilities",
+	"category.default.health":           "Health",
+	"category.default.entertainment":    "Entertainment",
+	"category.default.clothing":         "Clothing",
+	"category.default.telecom":          "Phone and internet",
+	"category.default.salary":           "Salary",
+	"category.default.gifts":            "Gifts",
+	"category.default.interest":         "Interest",
+	"category.default.other_income":     "Other income",
+
+	// Импорт
+	"import.multiple_accounts":            "The file contains statements for several accounts; upload a statement for one account",
+	"import.invalid_date_order":           "Invalid date order",
+	"import.invalid_decimal_separator":    "The decimal separator must be a dot or a comma",
+	"import.account_linked_elsewhere":     "Statement account %s is linked to another account",
+	"import.account_not_specified":        "The statement has no account number; specify the account",
+	"import.account_mismatch":             "The statement for account %s does not belong to the selected account",
+	"import.currency_mismatch":            "The statement currency does not match the account currency",
+	"import.profile_name_required":        "Profile name is required",
+	"import.invalid_delimiter":            "Invalid field delimiter",
+	"import.unsupported_encoding":         "Unsupported encoding",
+	"import.negative_skip_rows":           "The number of rows to skip cannot be negative",
+	"import.unknown_column_role":          "Unknown column role: %s",
+	"import.negative_column":              "A column number cannot be negative",
+	"import.invalid_sign_convention":      "Invalid amount sign convention",
+	"import.missing_column":               "Missing column: %s",
+	"import.file_required":                "A statement file is required",
+	"import.file_too_large":               "The statement file is too large",
+	"import.file_unreadable":              "Could not read the file",
+	"import.invalid_date_format":          "The date format must contain DD, MM and YYYY (or YY)",
+	"import.unsupported_charset":          "Unsupported encoding: %s",
+	"import.xml_unreadable":               "Could not read XML: %v",
+	"import.csv_unreadable":               "Could not read CSV: %v",
+	"import.too_many_rows":                "The statement has too many rows",
+	"import.no_camt_statements":           "The file contains no camt.053 statements",
+	"import.no_mt940_statements":          "The file contains no MT940 statements",
+	"import.no_statements":                "The file contains no statements",
+	"import.no_transactions":              "The file contains no transactions",
+	"import.not_ofx":                      "The file does not look like OFX",
+	"import.row_invalid_date":             "Invalid date",
+	"import.row_invalid_amount":           "Invalid amount",
+	"import.row_zero_amount":              "Zero amount",
+	"import.row_invalid_line":             "Invalid statement line",
+	"import.row_currency_mismatch":        "The transaction currency does not match the account currency",
+	"import.row_too_many_decimals":        "Too many decimal places for the currency",
+	"import.row_duplicate_external_id":    "Duplicate transaction ID in the statement",
+	"import.invalid_balance_amount":       "Invalid balance amount in the statement",
+	"import.invalid_balance_date":         "Invalid balance date in the statement",
+	"import.invalid_mt940_balance":        "Invalid balance in the MT940 statement: %s",
+	"import.invalid_mt940_balance_date":   "Invalid balance date in the MT940 statement: %s",
+	"import.invalid_mt940_balance_amount": "Invalid balance amount in the MT940 statement: %s",
+
+	// Капитал и курсы
+	"networth.invalid_granularity": "Invalid granularity: use day, week or month",
+	"networth.too_many_points":     "Too many points: shorten the period or increase the granularity",
+	"rate.period_too_long":         "The period cannot be longer than 366 days",
+
+	// Регулярные операции
+	"recurrence.invalid_frequency":     "Invalid frequency",
+	"recurrence.interval_range":        "The interval must be between 1 and 366",
+	"recurrence.day_of_month_range":    "Day of month must be between 1 and 31, or -1 for the last day",
+	"recurrence.invalid_weekend_shift": "Invalid weekend shift",
+	"recurrence.invalid_mode":          "Invalid posting mode",
+	"recurrence.end_before_start":      "The end date is before the start date",
+	"recurrence.payee_too_long":        "Payee cannot be longer than 255 characters",
+
+	// Правила
+	"rule.order_mismatch":         "The new order must list every rule exactly once",
+	"rule.too_many_transactions":  "Too many transactions in the period; shorten the period",
+	"rule.name_invalid":           "Rule name is required and must be at most 100 characters",
+	"rule.invalid_payee_pattern":  "Invalid payee regular expression",
+	"rule.invalid_note_pattern":   "Invalid note regular expression",
+	"rule.negative_amount_bounds": "Amount bounds cannot be negative",
+	"rule.min_above_max":          "The minimum amount is greater than the maximum",
+	"rule.conditions_required":    "Specify at least one rule condition",
+	"rule.rename_payee_too_long":  "The new payee cannot be longer than 255 characters",
+	"rule.actions_required":       "Specify at least one rule action",
+	"tag.too_long":                "A tag cannot be longer than 50 characters",
+	"tag.too_many":                "At most 20 tags are allowed",
+
+	// Операции
+	"transaction.invalid_type":               "Invalid transaction type",
+	"transaction.amount_not_positive":        "The transaction amount must be greater than zero",
+	"transaction.category_kind_mismatch":     "The category kind does not match the transaction type",
+	"transaction.not_editable":               "This transaction cannot be changed",
+	"transaction.duplicates_period_too_long": "The duplicate search period cannot exceed one year",
+	"transaction.merge_with_itself":          "A transaction cannot be merged with itself",
+	"transaction.merge_not_allowed":          "Only income and expense transactions outside transfers can be merged",
+	"transaction.merge_mismatch":             "Merged transactions must belong to the same account and have the same amount",
+
+	// Переводы
+	"transfer.same_account":           "The source and destination accounts must differ",
+	"transfer.amount_not_positive":    "The transfer amount must be greater than zero",
+	"transfer.amounts_differ":         "The credited amount must equal the debited amount for the same currency",
+	"transfer.to_amount_not_positive": "The credited amount must be greater than zero",
+	"transfer.rate_not_positive":      "The rate must be greater than zero",
+	"transfer.rate_required":          "For a cross-currency transfer, specify the rate or the credited amount",
+	"transfer.rate_same_currency":     "For a transfer in the same currency, the rate must be 1",
+	"transfer.rate_mismatch":          "The credited amount does not equal the debited amount multiplied by the rate",
+
+	// Письма
+	"mail.password_reset.subject":     "MoneyFlow password reset",
+	"mail.password_reset.body":        "Hello!\n\nTo set a new password, follow the link:\n%s\n\nThe link is valid for %d minutes and can be used only once. After the password is changed, you will be signed out on all devices.\n\nIf you did not request a password reset, just ignore this email.",
+	"mail.email_verification.subject": "Verify your email for MoneyFlow",
+	"mail.email_verification.body":    "Hello!\n\nTo verify your email, follow the link:\n%s\n\nThe link is valid for %d hours and can be used only once.\n\nIf you did not sign up for MoneyFlow, just ignore this email.",
+}
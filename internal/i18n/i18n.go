@@ -0,0 +1,57 @@
+// Package i18n содержит каталоги сообщений API на поддерживаемых языках и выбор языка ответа.
+package i18n
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// Поддерживаемые языки.
+const (
+	LangRU = "ru" // Русский
+	LangEN = "en" // Английский
+
+	Default = LangRU // Язык, если клиент не указал поддерживаемый
+)
+
+// catalogs — каталоги сообщений по языкам.
+var catalogs = map[string]map[string]string{
+	LangRU: ru,
+	LangEN: en,
+}
+
+// supported — поддерживаемые языки в порядке matcher; первый используется, если совпадений нет.
+var supported = []string{LangRU, LangEN}
+
+// matcher подбирает поддерживаемый язык по предпочтениям клиента (en-US → en, ru-RU → ru).
+var matcher = language.NewMatcher([]language.Tag{language.Russian, language.English})
+
+// Supported сообщает, поддерживается ли язык lang.
+func Supported(lang string) bool {
+	_, ok := catalogs[lang]
+	return ok
+}
+
+// Match возвращает поддерживаемый язык, наиболее подходящий под заголовок Accept-Language;
+// Default, если заголовок пуст или ни один язык из него не поддерживается.
+func Match(acceptLanguage string) string {
+	_, index := language.MatchStrings(matcher, acceptLanguage)
+	return supported[index]
+}
+
+// Translate возвращает сообщение key на языке lang, подставляя args как в fmt.Sprintf.
+// Если сообщения нет в каталоге языка, используется каталог Default, а если нет и там — сам ключ.
+func Translate(lang, key string, args ...any) string {
+	message, ok := catalogs[lang][key]
+	if !ok {
+		message, ok = catalogs[Default][key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
@@ -0,0 +1,215 @@
+package i18n
+
+// ru — каталог сообщений на русском языке.
+var ru = map[string]string{
+	// Ошибки с машиночитаемым кодом: ключ совпадает с кодом
+	"validation_failed":           "Некорректные данные",
+	"internal_error":              "Внутренняя ошибка сервера",
+	"not_found":                   "Не найдено",
+	"unauthorized":                "Неавторизован",
+	"forbidden":                   "Недостаточно прав",
+	"weak_password":               "Пароль слишком простой",
+	"email_taken":                 "Пользователь с таким email уже зарегистрирован",
+	"invalid_credentials":         "Неверный email или пароль",
+	"invalid_refresh_token":       "Недействительный refresh токен",
+	"refresh_token_reused":        "Refresh токен уже был использован, вход отозван",
+	"refresh_token_expired":       "Срок действия refresh токена истёк",
+	"session_not_found":           "Сессия не найдена",
+	"invalid_language":            "Неподдерживаемый язык: допустимы ru, en",
+	"invalid_reset_token":         "Ссылка для сброса пароля недействительна или устарела, запросите новую",
+	"invalid_verification_token":  "Ссылка для подтверждения email недействительна или устарела, запросите новую",
+	"email_not_verified":          "Email не подтверждён: перейдите по ссылке из письма или запросите новое письмо",
+	"account_not_found":           "Аккаунт не найден",
+	"version_conflict":            "Аккаунт изменён на другом устройстве: получите актуальную версию и повторите изменение",
+	"invalid_cursor":              "Некорректный курсор",
+	"invalid_currency":            "Некорректный код валюты: укажите код ISO 4217, например RUB",
+	"account_has_transactions":    "Нельзя изменить валюту аккаунта, по которому есть операции",
+	"iban_taken":                  "Аккаунт с таким IBAN уже существует",
+	"budget_not_found":            "Бюджет не найден",
+	"category_not_found":          "Категория не найдена",
+	"category_exists":             "Категория с таким названием уже существует",
+	"import_profile_not_found":    "Профиль импорта не найден",
+	"import_not_found":            "Импорт не найден",
+	"import_profile_exists":       "Профиль с таким названием уже существует",
+	"statement_account_not_found": "Не найден аккаунт с IBAN %s",
+	"import_committed":            "Импорт уже подтверждён",
+	"invalid_statement":           "Не удалось разобрать выписку",
+	"rate_not_found":              "Курс валюты не найден",
+	"recurrence_not_found":        "Регулярная операция не найдена",
+	"occurrence_not_found":        "Платёж не найден",
+	"occurrence_processed":        "Платёж уже обработан",
+	"rule_not_found":              "Правило не найдено",
+	"transaction_not_found":       "Операция не найдена",
+	"transaction_in_transfer":     "Операция является частью перевода, удалите перевод целиком",
+	"transfer_not_found":          "Перевод не найден",
+	"foreign_account":             "Переводы возможны только между своими аккаунтами",
+
+	// Общие
+	"request.invalid_id":      "Некорректный id",
+	"request.invalid_json":    "Некорректный JSON",
+	"request.invalid_date":    "Некорректная дата",
+	"request.invalid_amount":  "Некорректная сумма",
+	"period.start_after_end":  "Начало периода позже конца",
+	"money.too_many_decimals": "Слишком много знаков после запятой для валюты",
+	"money.too_many_digits":   "Слишком большое число: в целой части допускается не больше %d цифр",
+
+	// Поля запроса
+	"field.integer_expected": "Ожидается целое число",
+	"field.wrong_type":       "Некорректный тип значения",
+	"field.required":         "Обязательное поле",
+	"field.email":            "Некорректный email",
+	"field.oneof":            "Допустимые значения: %s",
+	"field.min_length":       "Не короче %s символов",
+	"field.min_items":        "Не меньше %s элементов",
+	"field.min":              "Не меньше %s",
+	"field.max_length":       "Не длиннее %s символов",
+	"field.max_items":        "Не больше %s элементов",
+	"field.max":              "Не больше %s",
+	"field.invalid":          "Некорректное значение",
+
+	// Аккаунты
+	"account.invalid_sort":              "Некорректное поле сортировки",
+	"account.invalid_type_filter":       "Некорректный тип аккаунта",
+	"account.name_currency_required":    "Название и валюта обязательны",
+	"account.invalid_iban":              "Некорректный IBAN",
+	"account.invalid_bic":               "Некорректный BIC",
+	"account.invalid_type":              "Некорректный тип аккаунта: допустимы checking, cash, credit_card, savings, loan, investment",
+	"account.credit_params_only_card":   "Кредитный лимит и день выписки указываются только для кредитных карт",
+	"account.credit_limit_negative":     "Кредитный лимит не может быть отрицательным",
+	"account.statement_day_range":       "День выписки должен быть от 1 до 31",
+	"account.interest_rate_not_allowed": "Процентная ставка указывается только для вкладов и кредитов",
+	"account.interest_rate_range":       "Процентная ставка должна быть от 0 до 100",
+	"account.interest_rate_precision":   "Процентная ставка указывается с точностью не больше 4 знаков после запятой",
+
+	// Бюджеты
+	"budget.date_before_start":       "Дата раньше начала бюджета",
+	"budget.name_currency_required":  "Название и валюта обязательны",
+	"budget.invalid_period":          "Некорректный период бюджета",
+	"budget.limits_required":         "Нужно указать хотя бы один лимит",
+	"budget.duplicate_limit":         "Лимит для категории указан несколько раз",
+	"budget.limit_not_positive":      "Лимит должен быть больше нуля",
+	"budget.expense_categories_only": "Лимиты задаются только для категорий расходов",
+
+	// Категории
+	"category.name_required":        "Название категории обязательно",
+	"category.kind_mismatch_parent": "Вид категории должен совпадать с видом родительской категории",
+	"category.move_into_itself":     "Нельзя перенести категорию внутрь неё самой",
+	"category.merge_into_itself":    "Нельзя объединить категорию с самой собой",
+	"category.merge_kind_mismatch":  "Нельзя объединить категории доходов и расходов",
+	"category.merge_into_child":     "Нельзя объединить категорию с её подкатегорией",
+
+	// Категории по умолчанию
+	"category.default.groceries":        "Продукты",
+	"category.default.restaurants":      "Кафе и рестораны",
+	"category.default.transport":        "Транспорт",
+	"category.default.taxi":             "Такси",
+	"category.default.public_transport": "Общественный транспорт",
+	"category.default.fuel":             "Топливо",
+	"category.default.housing":          "Жильё",
+	"category.default.rent":             "Аренда",
+	"category.default.utilities":        "Коммунальные услуги",
+	"category.default.health":           "Здоровье",
+	"category.default.entertainment":    "Развлечения",
+	"category.default.clothing":         "Одежда",
+	"category.default.telecom":          "Связь и интернет",
+	"category.default.salary":           "Зарплата",
+	"category.default.gifts":            "Подарки",
+	"category.default.interest":         "Проценты",
+	"category.default.other_income":     "Прочие доходы",
+
+	// Импорт
+	"import.multiple_accounts":            "Файл содержит выписки по нескольким счетам; загрузите выписку по одному счёту",
+	"import.invalid_date_order":           "Некорректный порядок частей даты",
+	"import.invalid_decimal_separator":    "Десятичный разделитель должен быть точкой или запятой",
+	"import.account_linked_elsewhere":     "Счёт %s из выписки привязан к другому аккаунту",
+	"import.account_not_specified":        "В выписке не указан счёт; укажите аккаунт",
+	"import.account_mismatch":             "Выписка по счёту %s не относится к выбранному аккаунту",
+	"import.currency_mismatch":            "Валюта выписки не совпадает с валютой аккаунта",
+	"import.profile_name_required":        "Название профиля обязательно",
+	"import.invalid_delimiter":            "Некорректный разделитель полей",
+	"import.unsupported_encoding":         "Неподдерживаемая кодировка",
+	"import.negative_skip_rows":           "Количество пропускаемых строк не может быть отрицательным",
+	"import.unknown_column_role":          "Неизвестная роль колонки: %s",
+	"import.negative_column":              "Номер колонки не может быть отрицательным",
+	"import.invalid_sign_convention":      "Некорректное соглашение о знаке суммы",
+	"import.missing_column":               "Не указана колонка: %s",
+	"import.file_required":                "Файл выписки обязателен",
+	"import.file_too_large":               "Файл выписки слишком большой",
+	"import.file_unreadable":              "Не удалось прочитать файл",
+	"import.invalid_date_format":          "Формат даты должен содержать DD, MM и YYYY (или YY)",
+	"import.unsupported_charset":          "Неподдерживаемая кодировка: %s",
+	"import.xml_unreadable":               "Не удалось прочитать XML: %v",
+	"import.csv_unreadable":               "Не удалось прочитать CSV: %v",
+	"import.too_many_rows":                "Слишком много строк в выписке",
+	"import.no_camt_statements":           "В файле нет выписок camt.053",
+	"import.no_mt940_statements":          "В файле нет выписок MT940",
+	"import.no_statements":                "В файле нет выписок",
+	"import.no_transactions":              "В файле нет операций",
+	"import.not_ofx":                      "Файл не похож на OFX",
+	"import.row_invalid_date":             "Некорректная дата",
+	"import.row_invalid_amount":           "Некорректная сумма",
+	"import.row_zero_amount":              "Нулевая сумма",
+	"import.row_invalid_line":             "Некорректная строка выписки",
+	"import.row_currency_mismatch":        "Валюта операции не совпадает с валютой счёта",
+	"import.row_too_many_decimals":        "Слишком много знаков после запятой для валюты",
+	"import.row_duplicate_external_id":    "Повторяющийся идентификатор операции в выписке",
+	"import.invalid_balance_amount":       "Некорректная сумма баланса в выписке",
+	"import.invalid_balance_date":         "Некорректная дата баланса в выписке",
+	"import.invalid_mt940_balance":        "Некорректный баланс в выписке MT940: %s",
+	"import.invalid_mt940_balance_date":   "Некорректная дата баланса в выписке MT940: %s",
+	"import.invalid_mt940_balance_amount": "Некорректная сумма баланса в выписке MT940: %s",
+
+	// Капитал и курсы
+	"networth.invalid_granularity": "Некорректный шаг: допустимы day, week, month",
+	"networth.too_many_points":     "Слишком много точек: сократите период или увеличьте шаг",
+	"rate.period_too_long":         "Период не может быть длиннее 366 дней",
+
+	// Регулярные операции
+	"recurrence.invalid_frequency":     "Некорректная частота повторения",
+	"recurrence.interval_range":        "Шаг повторения должен быть от 1 до 366",
+	"recurrence.day_of_month_range":    "День месяца должен быть от 1 до 31 или -1 для последнего дня",
+	"recurrence.invalid_weekend_shift": "Некорректный перенос с выходных",
+	"recurrence.invalid_mode":          "Некорректный режим создания операций",
+	"recurrence.end_before_start":      "Дата окончания раньше даты начала",
+	"recurrence.payee_too_long":        "Контрагент не может быть длиннее 255 символов",
+
+	// Правила
+	"rule.order_mismatch":         "Новый порядок должен содержать все правила ровно по одному разу",
+	"rule.too_many_transactions":  "Слишком много операций за период; сократите период",
+	"rule.name_invalid":           "Название правила обязательно и не длиннее 100 символов",
+	"rule.invalid_payee_pattern":  "Некорректное регулярное выражение для контрагента",
+	"rule.invalid_note_pattern":   "Некорректное регулярное выражение для комментария",
+	"rule.negative_amount_bounds": "Границы суммы не могут быть отрицательными",
+	"rule.min_above_max":          "Минимальная сумма больше максимальной",
+	"rule.conditions_required":    "Укажите хотя бы одно условие правила",
+	"rule.rename_payee_too_long":  "Новый контрагент не может быть длиннее 255 символов",
+	"rule.actions_required":       "Укажите хотя бы одно действие правила",
+	"tag.too_long":                "Тег не может быть длиннее 50 символов",
+	"tag.too_many":                "Не больше 20 тегов",
+
+	// Операции
+	"transaction.invalid_type":               "Некорректный тип операции",
+	"transaction.amount_not_positive":        "Сумма операции должна быть больше нуля",
+	"transaction.category_kind_mismatch":     "Вид категории не соответствует типу операции",
+	"transaction.not_editable":               "Эту операцию нельзя изменить",
+	"transaction.duplicates_period_too_long": "Период поиска дубликатов не может быть больше года",
+	"transaction.merge_with_itself":          "Нельзя объединить операцию саму с собой",
+	"transaction.merge_not_allowed":          "Объединять можно только доходы и расходы, не входящие в перевод",
+	"transaction.merge_mismatch":             "Объединяемые операции должны относиться к одному аккаунту и иметь одинаковую сумму",
+
+	// Переводы
+	"transfer.same_account":           "Аккаунты списания и зачисления должны различаться",
+	"transfer.amount_not_positive":    "Сумма перевода должна быть больше нуля",
+	"transfer.amounts_differ":         "Сумма зачисления должна совпадать с суммой списания для одной валюты",
+	"transfer.to_amount_not_positive": "Сумма зачисления должна быть больше нуля",
+	"transfer.rate_not_positive":      "Курс должен быть больше нуля",
+	"transfer.rate_required":          "Для перевода между валютами укажите курс или сумму зачисления",
+	"transfer.rate_same_currency":     "Для перевода в одной валюте курс должен быть равен 1",
+	"transfer.rate_mismatch":          "Сумма зачисления не совпадает с суммой списания, умноженной на курс",
+
+	// Письма
+	"mail.password_reset.subject":     "Сброс пароля MoneyFlow",
+	"mail.password_reset.body":        "Здравствуйте!\n\nЧтобы задать новый пароль, перейдите по ссылке:\n%s\n\nСсылка действует %d мин. и может быть использована только один раз. После смены пароля вход на всех устройствах будет завершён.\n\nЕсли вы не запрашивали сброс пароля, просто проигнорируйте это письмо.",
+	"mail.email_verification.subject": "Подтвердите email для MoneyFlow",
+	"mail.email_verification.body":    "Здравствуйте!\n\nЧтобы подтвердить email, перейдите по ссылке:\n%s\n\nСсылка действует %d ч. и может быть использована только один раз.\n\nЕсли вы не регистрировались в MoneyFlow, просто проигнорируйте это письмо.",
+}
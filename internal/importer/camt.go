@@ -0,0 +1,279 @@
+package importer
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// Коды типов балансов camt.053, которые используются при сверке.
+const (
+	camtOpeningBooked         = "OPBD" // Входящий баланс
+	camtPreviousClosingBooked = "PRCD" // Исходящий баланс предыдущей выписки — замена OPBD
+	camtClosingBooked         = "CLBD" // Исходящий баланс
+)
+
+// camtAmount — сумма с атрибутом валюты.
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtDate — дата или дата со временем; используется дата.
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+type camtAccount struct {
+	IBAN     string `xml:"Id>IBAN"`
+	Other    string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+}
+
+type camtBalance struct {
+	Type      string     `xml:"Tp>CdOrPrtry>Cd"`
+	Amount    camtAmount `xml:"Amt"`
+	CdtDbtInd string     `xml:"CdtDbtInd"`
+	Date      camtDate   `xml:"Dt"`
+}
+
+// camtStatus — статус проводки: в версиях до 001.08 это текст, начиная с 001.08 — вложенный Cd.
+type camtStatus struct {
+	Text string `xml:",chardata"`
+	Code string `xml:"Cd"`
+}
+
+type camtTxDetails struct {
+	AcctSvcrRef    string   `xml:"Refs>AcctSvcrRef"`
+	CreditorName   string   `xml:"RltdPties>Cdtr>Nm"`
+	CreditorParty  string   `xml:"RltdPties>Cdtr>Pty>Nm"`
+	DebtorName     string   `xml:"RltdPties>Dbtr>Nm"`
+	DebtorParty    string   `xml:"RltdPties>Dbtr>Pty>Nm"`
+	Unstructured   []string `xml:"RmtInf>Ustrd"`
+	AdditionalInfo string   `xml:"AddtlTxInf"`
+}
+
+type camtEntry struct {
+	Amount         camtAmount      `xml:"Amt"`
+	CdtDbtInd      string          `xml:"CdtDbtInd"`
+	Status         camtStatus      `xml:"Sts"`
+	BookingDate    camtDate        `xml:"BookgDt"`
+	ValueDate      camtDate        `xml:"ValDt"`
+	AcctSvcrRef    string          `xml:"AcctSvcrRef"`
+	EntryRef       string          `xml:"NtryRef"`
+	AdditionalInfo string          `xml:"AddtlNtryInf"`
+	Details        []camtTxDetails `xml:"NtryDtls>TxDtls"`
+}
+
+// ParseCAMT053 разбирает выписку ISO 20022 camt.053 (BankToCustomerStatement) любой версии.
+// Каждый элемент Stmt даёт отдельную выписку: номер счёта — IBAN (или прочий идентификатор),
+// входящий и исходящий балансы — OPBD (PRCD) и CLBD. В строки попадают только проведённые (BOOK) записи.
+func ParseCAMT053(r io.Reader) ([]Statement, error) {
+	decoded, err := decode(r, imports.EncodingUTF8)
+	if err != nil {
+		return nil, err
+	}
+	d := xml.NewDecoder(decoded)
+	d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if strings.EqualFold(charset, "windows-1251") {
+			return charmap.Windows1251.NewDecoder().Reader(input), nil
+		}
+		return nil, apperror.Validation("import.unsupported_charset", charset)
+	}
+
+	statements := []Statement{}
+	var st *Statement
+	var opening *imports.Balance
+	rows := 0
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, apperror.Validation("import.xml_unreadable", err)
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			if el.Name.Local == "Stmt" {
+				st, opening = &Statement{Rows: []imports.Row{}}, nil
+				continue
+			}
+			if st == nil {
+				continue
+			}
+			switch el.Name.Local {
+			case "Acct":
+				var acc camtAccount
+				if err := d.DecodeElement(&acc, &el); err != nil {
+					return nil, apperror.Validation("import.xml_unreadable", err)
+				}
+				st.AccountNumber = acc.IBAN
+				if st.AccountNumber == "" {
+					st.AccountNumber = acc.Other
+				}
+				st.Currency = acc.Currency
+			case "Bal":
+				var bal camtBalance
+				if err := d.DecodeElement(&bal, &el); err != nil {
+					return nil, apperror.Validation("import.xml_unreadable", err)
+				}
+				balance, err := bal.balance()
+				if err != nil {
+					return nil, err
+				}
+				if st.Currency == "" {
+					st.Currency = bal.Amount.Currency
+				}
+				switch bal.Type {
+				case camtOpeningBooked:
+					st.OpeningBalance = balance
+				case camtPreviousClosingBooked:
+					opening = balance
+				case camtClosingBooked:
+					st.ClosingBalance = balance
+				}
+			case "Ntry":
+				line, _ := d.InputPos()
+				var entry camtEntry
+				if err := d.DecodeElement(&entry, &el); err != nil {
+					return nil, apperror.Validation("import.xml_unreadable", err)
+				}
+				if !entry.booked() {
+					continue
+				}
+				if rows++; rows > MaxRows {
+					return nil, ErrTooManyRows
+				}
+				st.Rows = append(st.Rows, entry.row(line, st.Currency))
+			}
+		case xml.EndElement:
+			if el.Name.Local == "Stmt" && st != nil {
+				if st.OpeningBalance == nil {
+					st.OpeningBalance = opening
+				}
+				statements = append(statements, *st)
+				st = nil
+			}
+		}
+	}
+	if len(statements) == 0 {
+		return nil, apperror.Validation("import.no_camt_statements")
+	}
+	return statements, nil
+}
+
+// balance переводит баланс camt.053 в imports.Balance: дебетовый баланс отрицателен.
+func (b camtBalance) balance() (*imports.Balance, error) {
+	amount, err := camtSignedAmount(b.Amount.Value, b.CdtDbtInd)
+	if err != nil {
+		return nil, apperror.Validation("import.invalid_balance_amount")
+	}
+	date, err := b.Date.parse()
+	if err != nil {
+		return nil, apperror.Validation("import.invalid_balance_date")
+	}
+	return &imports.Balance{Amount: amount, Date: date}, nil
+}
+
+// booked сообщает, проведена ли запись. Ожидающие (PDNG) и информационные (INFO) записи не импортируются.
+func (e camtEntry) booked() bool {
+	status := strings.TrimSpace(e.Status.Code)
+	if status == "" {
+		status = strings.TrimSpace(e.Status.Text)
+	}
+	return status == "" || status == "BOOK"
+}
+
+// row превращает запись camt.053 в строку выписки.
+// Контрагент — получатель для списаний и плательщик для зачислений.
+func (e camtEntry) row(line int, currency string) imports.Row {
+	row := imports.Row{Line: line, Note: strings.TrimSpace(e.AdditionalInfo)}
+	var details camtTxDetails
+	if len(e.Details) > 0 {
+		details = e.Details[0]
+	}
+	row.ExternalID = firstNonEmpty(e.AcctSvcrRef, e.EntryRef, details.AcctSvcrRef)
+	row.ExternalID = truncate(row.ExternalID, maxExternalIDLength)
+	if remittance := strings.TrimSpace(strings.Join(details.Unstructured, " ")); remittance != "" {
+		row.Note = remittance
+	} else if info := strings.TrimSpace(details.AdditionalInfo); info != "" {
+		row.Note = info
+	}
+
+	date, err := e.BookingDate.parse()
+	if err != nil {
+		date, err = e.ValueDate.parse()
+	}
+	if err != nil {
+		row.Error = "import.row_invalid_date"
+		return row
+	}
+	row.Date = date
+	amount, err := camtSignedAmount(e.Amount.Value, e.CdtDbtInd)
+	if err != nil {
+		row.Error = "import.row_invalid_amount"
+		return row
+	}
+	if amount.IsZero() {
+		row.Error = "import.row_zero_amount"
+		return row
+	}
+	if currency != "" && e.Amount.Currency != "" && !strings.EqualFold(currency, e.Amount.Currency) {
+		row.Error = "import.row_currency_mismatch"
+		return row
+	}
+	row.Amount = amount
+	if amount.IsNegative() {
+		row.Payee = firstNonEmpty(details.CreditorName, details.CreditorParty)
+	} else {
+		row.Payee = firstNonEmpty(details.DebtorName, details.DebtorParty)
+	}
+	row.Payee = truncate(row.Payee, maxPayeeLength)
+	return row
+}
+
+// parse возвращает дату без времени.
+func (d camtDate) parse() (time.Time, error) {
+	s := strings.TrimSpace(d.Date)
+	if s == "" {
+		s = strings.TrimSpace(d.DateTime)
+	}
+	if len(s) < 10 {
+		return time.Time{}, errors.New("invalid camt date")
+	}
+	return time.Parse("2006-01-02", s[:10])
+}
+
+// camtSignedAmount разбирает неотрицательную сумму camt и применяет знак по индикатору CRDT/DBIT.
+func camtSignedAmount(value, indicator string) (money.Decimal, error) {
+	amount, err := parseDecimal(strings.TrimSpace(value))
+	if err != nil {
+		return money.Decimal{}, err
+	}
+	switch strings.TrimSpace(indicator) {
+	case "CRDT":
+		return amount, nil
+	case "DBIT":
+		return amount.Neg(), nil
+	}
+	return money.Decimal{}, errors.New("invalid credit/debit indicator")
+}
+
+// firstNonEmpty возвращает первую непустую после обрезки пробелов строку.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package importer
+
+import "testing"
+
+func TestParseCAMT053(t *testing.T) {
+	statements, err := ParseCAMT053(openTestdata(t, "bank.camt053.xml"))
+	if err != nil {
+		t.Fatalf("ParseCAMT053 error: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	st := statements[0]
+	if st.AccountNumber != "DE89370400440532013000" || st.Currency != "EUR" {
+		t.Errorf("account = %q %q, want DE89370400440532013000 EUR", st.AccountNumber, st.Currency)
+	}
+	checkBalance(t, "OpeningBalance", st.OpeningBalance, "1000.00", "2024-03-01")
+	checkBalance(t, "ClosingBalance", st.ClosingBalance, "2450.50", "2024-03-31")
+	// Ожидающая (PDNG) запись REF-PENDING пропускается.
+	checkRows(t, st.Rows, []wantRow{
+		{date: "2024-03-05", amount: "-49.50", payee: "REWE Markt GmbH", note: "Einkauf Filiale 42", externalID: "REF-0001"},
+		{date: "2024-03-15", amount: "1500.00", payee: "ACME AG", note: "Gehalt Maerz", externalID: "REF-0002"},
+		{externalID: "REF-0003", err: "import.row_invalid_amount"},
+		{externalID: "REF-0004", err: "import.row_currency_mismatch"},
+	})
+}
@@ -0,0 +1,197 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// dateTokens переводит токены формата даты профиля в раскладку time.Parse.
+var dateTokens = strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02", "HH", "15", "mm", "04", "ss", "05")
+
+// utf8BOM — метка порядка байт, которую добавляют в начало CSV многие выгрузки.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseCSV разбирает CSV-выписку по профилю сопоставления колонок.
+// Ошибки в отдельных строках не прерывают разбор: они сохраняются в Row.Error.
+// Ошибка возвращается, только если файл целиком нельзя прочитать или он слишком большой.
+func ParseCSV(r io.Reader, p imports.Profile) ([]imports.Row, error) {
+	layout, err := DateLayout(p.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := decode(r, p.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	delimiter, _ := utf8.DecodeRuneInString(p.Delimiter)
+
+	reader := csv.NewReader(decoded)
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	rows := []imports.Row{}
+	for skipped := 0; ; {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, apperror.Validation("import.csv_unreadable", err)
+		}
+		if skipped < p.SkipRows {
+			skipped++
+			continue
+		}
+		if len(rows) >= MaxRows {
+			return nil, ErrTooManyRows
+		}
+		line, _ := reader.FieldPos(0)
+		rows = append(rows, parseRecord(record, line, layout, p))
+	}
+	return rows, nil
+}
+
+// DateLayout переводит формат даты профиля (например, DD.MM.YYYY) в раскладку time.Parse.
+func DateLayout(format string) (string, error) {
+	if !strings.Contains(format, "YY") || !strings.Contains(format, "MM") || !strings.Contains(format, "DD") {
+		return "", apperror.Validation("import.invalid_date_format")
+	}
+	return dateTokens.Replace(format), nil
+}
+
+// ParseAmount разбирает сумму из выписки: убирает разделители разрядов и пробелы,
+// понимает оба десятичных разделителя и отрицательные суммы в скобках. Экспонента не допускается
+// (см. parseDecimal).
+func ParseAmount(s, decimalSeparator string) (money.Decimal, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = s[1 : len(s)-1]
+	}
+	thousands := ","
+	if decimalSeparator == "," {
+		thousands = "."
+	}
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "'", "", thousands, "").Replace(s)
+	s = strings.Replace(s, decimalSeparator, ".", 1)
+	s = strings.TrimPrefix(s, "+")
+	amount, err := parseDecimal(s)
+	if err != nil {
+		return money.Decimal{}, err
+	}
+	if negative {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
+
+// parseDecimal разбирает число из выписки. Банки не записывают суммы с экспонентой, поэтому «1e5» считается
+// ошибкой, а не суммой 100000; размер числа ограничивает money.Parse.
+func parseDecimal(s string) (money.Decimal, error) {
+	if strings.ContainsAny(s, "eE") {
+		return money.Decimal{}, fmt.Errorf("importer: exponent is not allowed in amount %q", s)
+	}
+	return money.Parse(s)
+}
+
+// parseRecord превращает одну запись CSV в строку выписки.
+func parseRecord(record []string, line int, layout string, p imports.Profile) imports.Row {
+	row := imports.Row{Line: line}
+	field := func(role string) string {
+		i, ok := p.Columns[role]
+		if !ok || i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	row.Payee = truncate(field(imports.ColumnPayee), maxPayeeLength)
+	row.Note = field(imports.ColumnNote)
+
+	date, err := time.Parse(layout, field(imports.ColumnDate))
+	if err != nil {
+		row.Error = "import.row_invalid_date"
+		return row
+	}
+	row.Date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	amount, err := recordAmount(field, p)
+	if err != nil {
+		row.Error = "import.row_invalid_amount"
+		return row
+	}
+	if amount.IsZero() {
+		row.Error = "import.row_zero_amount"
+		return row
+	}
+	row.Amount = amount
+	return row
+}
+
+// recordAmount вычисляет сумму со знаком по соглашению профиля: положительная — поступление.
+func recordAmount(field func(string) string, p imports.Profile) (money.Decimal, error) {
+	switch p.SignConvention {
+	case imports.SignDebitCredit:
+		debit, err := optionalAmount(field(imports.ColumnDebit), p.DecimalSeparator)
+		if err != nil {
+			return money.Decimal{}, err
+		}
+		credit, err := optionalAmount(field(imports.ColumnCredit), p.DecimalSeparator)
+		if err != nil {
+			return money.Decimal{}, err
+		}
+		return credit.Abs().Sub(debit.Abs()), nil
+	case imports.SignInverted:
+		amount, err := ParseAmount(field(imports.ColumnAmount), p.DecimalSeparator)
+		return amount.Neg(), err
+	default:
+		return ParseAmount(field(imports.ColumnAmount), p.DecimalSeparator)
+	}
+}
+
+// optionalAmount разбирает сумму, пустое значение считается нулём.
+func optionalAmount(s, decimalSeparator string) (money.Decimal, error) {
+	if s == "" {
+		return money.Zero, nil
+	}
+	return ParseAmount(s, decimalSeparator)
+}
+
+// decode возвращает reader, отдающий текст в UTF-8 без BOM.
+func decode(r io.Reader, encoding string) (io.Reader, error) {
+	switch encoding {
+	case imports.EncodingWindows1251:
+		return charmap.Windows1251.NewDecoder().Reader(r), nil
+	case imports.EncodingUTF8, "":
+		br := bufio.NewReader(r)
+		if head, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(head, utf8BOM) {
+			br.Discard(len(utf8BOM))
+		}
+		return br, nil
+	default:
+		return nil, apperror.Validation("import.unsupported_encoding")
+	}
+}
+
+// truncate обрезает строку до n символов.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,107 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in        string
+		separator string
+		want      string
+		wantErr   bool
+	}{
+		{in: "1 234,56", separator: ",", want: "1234.56"},
+		{in: "1.234,56", separator: ",", want: "1234.56"},
+		{in: "1,234.56", separator: ".", want: "1234.56"},
+		{in: "-1 000,00", separator: ",", want: "-1000.00"},
+		{in: "1'000.5", separator: ".", want: "1000.5"},
+		{in: "(42,10)", separator: ",", want: "-42.10"},
+		{in: "+15", separator: ".", want: "15"},
+		{in: "1e5", separator: ".", wantErr: true},
+		{in: "1E-2", separator: ".", wantErr: true},
+		{in: "1e100000000", separator: ".", wantErr: true},
+		{in: "9999999999999999999", separator: ".", wantErr: true},
+		{in: "0,0000000000001", separator: ",", wantErr: true},
+		{in: "", separator: ".", wantErr: true},
+		{in: "abc", separator: ".", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in, tt.separator)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q) = %s, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseAmount(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	profile := imports.Profile{
+		Delimiter:        ";",
+		Encoding:         imports.EncodingUTF8,
+		SkipRows:         1,
+		DateFormat:       "DD.MM.YYYY",
+		DecimalSeparator: ",",
+		SignConvention:   imports.SignSigned,
+		Columns: map[string]int{
+			imports.ColumnDate:   0,
+			imports.ColumnPayee:  1,
+			imports.ColumnAmount: 2,
+			imports.ColumnNote:   3,
+		},
+	}
+	rows, err := ParseCSV(openTestdata(t, "sberbank.csv"), profile)
+	if err != nil {
+		t.Fatalf("ParseCSV error: %v", err)
+	}
+	checkRows(t, rows, []wantRow{
+		{line: 2, date: "2024-03-05", amount: "-1234.50", payee: `ООО "Магнит"`, note: "Покупка продуктов"},
+		{line: 3, date: "2024-03-06", amount: "85000.00", payee: "ООО Ромашка", note: "Заработная плата за февраль"},
+		{line: 4, payee: "Кафе", err: "import.row_invalid_amount"},
+		{line: 5, payee: "Неверная дата", err: "import.row_invalid_date"},
+		{line: 6, payee: "Возврат", err: "import.row_zero_amount"},
+		{line: 7, payee: "Экспонента", err: "import.row_invalid_amount"},
+		{line: 8, date: "2024-03-10", amount: "-500.00", payee: "Перевод", note: "Перевод по номеру телефона"},
+	})
+}
+
+func TestParseCSVSignConventions(t *testing.T) {
+	profile := imports.Profile{
+		Delimiter:        ",",
+		DateFormat:       "YYYY-MM-DD",
+		DecimalSeparator: ".",
+		SignConvention:   imports.SignDebitCredit,
+		Columns:          map[string]int{imports.ColumnDate: 0, imports.ColumnDebit: 1, imports.ColumnCredit: 2},
+	}
+	body := "2024-03-01,10.50,\n2024-03-02,,20\n2024-03-03,-5,1\n2024-03-04,1e3,\n"
+	rows, err := ParseCSV(strings.NewReader(body), profile)
+	if err != nil {
+		t.Fatalf("ParseCSV error: %v", err)
+	}
+	checkRows(t, rows, []wantRow{
+		{amount: "-10.50"},
+		{amount: "20"},
+		{amount: "-4"},
+		{err: "import.row_invalid_amount"},
+	})
+
+	profile.SignConvention = imports.SignInverted
+	profile.Columns = map[string]int{imports.ColumnDate: 0, imports.ColumnAmount: 1}
+	rows, err = ParseCSV(strings.NewReader("2024-03-01,10.50\n2024-03-02,-3\n"), profile)
+	if err != nil {
+		t.Fatalf("ParseCSV error: %v", err)
+	}
+	checkRows(t, rows, []wantRow{{amount: "-10.50"}, {amount: "3"}})
+}
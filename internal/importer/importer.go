@@ -0,0 +1,17 @@
+// Package importer разбирает банковские выписки в строки импорта (imports.Row).
+// Парсеры не обращаются к БД: проверка аккаунта и создание операций выполняются в ImportService.
+package importer
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+
+const (
+	// MaxRows — максимальное количество строк в одной выписке.
+	MaxRows = 10000
+	// maxPayeeLength — максимальная длина контрагента, как в таблице transactions.
+	maxPayeeLength = 255
+	// maxExternalIDLength — максимальная длина идентификатора операции в банке, как в таблице transactions.
+	maxExternalIDLength = 255
+)
+
+// ErrTooManyRows возвращается, если в выписке больше MaxRows строк.
+var ErrTooManyRows = apperror.Validation("import.too_many_rows")
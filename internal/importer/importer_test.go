@@ -0,0 +1,72 @@
+package importer
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+)
+
+// wantRow — ожидаемые поля строки выписки; пустая сумма означает, что сумма не проверяется.
+type wantRow struct {
+	line       int
+	date       string
+	amount     string
+	payee      string
+	note       string
+	externalID string
+	err        string
+}
+
+func openTestdata(t *testing.T, name string) *os.File {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func checkRows(t *testing.T, got []imports.Row, want []wantRow) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Error != w.err {
+			t.Errorf("row %d: Error = %q, want %q", i, g.Error, w.err)
+		}
+		if w.line != 0 && g.Line != w.line {
+			t.Errorf("row %d: Line = %d, want %d", i, g.Line, w.line)
+		}
+		if w.date != "" && g.Date.Format(time.DateOnly) != w.date {
+			t.Errorf("row %d: Date = %s, want %s", i, g.Date.Format(time.DateOnly), w.date)
+		}
+		if w.amount != "" && g.Amount.String() != w.amount {
+			t.Errorf("row %d: Amount = %s, want %s", i, g.Amount, w.amount)
+		}
+		if g.Payee != w.payee {
+			t.Errorf("row %d: Payee = %q, want %q", i, g.Payee, w.payee)
+		}
+		if g.Note != w.note {
+			t.Errorf("row %d: Note = %q, want %q", i, g.Note, w.note)
+		}
+		if g.ExternalID != w.externalID {
+			t.Errorf("row %d: ExternalID = %q, want %q", i, g.ExternalID, w.externalID)
+		}
+	}
+}
+
+func checkBalance(t *testing.T, name string, got *imports.Balance, amount, date string) {
+	t.Helper()
+	if got == nil {
+		t.Errorf("%s = nil, want %s on %s", name, amount, date)
+		return
+	}
+	if got.Amount.String() != amount || got.Date.Format(time.DateOnly) != date {
+		t.Errorf("%s = %s on %s, want %s on %s", name, got.Amount, got.Date.Format(time.DateOnly), amount, date)
+	}
+}
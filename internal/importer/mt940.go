@@ -0,0 +1,251 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+)
+
+// mt940Tag находит начало поля MT940 вида :61: или :28C: в начале строки.
+var mt940Tag = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+
+// mt940Line разбирает поле :61: — дата валютирования, необязательная дата проводки (MMDD),
+// признак дебета/кредита (D, C, RD, RC), необязательный код средств, сумма, тип операции,
+// референс клиента и после // — референс банка.
+var mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(RD|RC|D|C)([A-Z])?(\d+,\d*)([A-Z][A-Z0-9]{3})(.*?)(?://(.*))?$`)
+
+// mt940Balance разбирает поля балансов :60F:, :62F: и т. п. — признак D/C, дата YYMMDD, валюта и сумма.
+var mt940Balance = regexp.MustCompile(`^([DC])(\d{6})([A-Z]{3})(\d+,\d*)$`)
+
+// mt940Field — поле MT940 с номером строки, на которой оно начинается.
+type mt940Field struct {
+	tag   string
+	line  int
+	lines []string
+}
+
+// ParseMT940 разбирает выписку SWIFT MT940. Файл может содержать несколько сообщений,
+// в том числе с заголовками блоков {1:}{2:}{4:; каждое поле :20: начинает новую выписку.
+// Номер счёта берётся из :25: (часть после «/», если указан код банка), балансы — из :60F:/:60M: и :62F:/:62M:,
+// референс банка из :61: (после //) сохраняется в Row.ExternalID, а назначение :86: — в Note.
+func ParseMT940(r io.Reader) ([]Statement, error) {
+	decoded, err := decode(r, imports.EncodingUTF8)
+	if err != nil {
+		return nil, err
+	}
+	fields, err := mt940Fields(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	statements := []Statement{}
+	var st *Statement
+	var last *imports.Row
+	rows := 0
+	for _, f := range fields {
+		if f.tag == "20" {
+			if st != nil {
+				statements = append(statements, *st)
+			}
+			st, last = &Statement{Rows: []imports.Row{}}, nil
+			continue
+		}
+		if st == nil {
+			continue
+		}
+		value := strings.TrimSpace(f.lines[0])
+		switch f.tag {
+		case "25":
+			account := value
+			if i := strings.LastIndex(account, "/"); i >= 0 {
+				account = account[i+1:]
+			}
+			st.AccountNumber = strings.TrimSpace(account)
+		case "60F", "60M":
+			balance, currency, err := parseMT940Balance(value)
+			if err != nil {
+				return nil, err
+			}
+			if st.OpeningBalance == nil {
+				st.OpeningBalance = balance
+			}
+			st.Currency = currency
+		case "62F", "62M":
+			balance, currency, err := parseMT940Balance(value)
+			if err != nil {
+				return nil, err
+			}
+			st.ClosingBalance = balance
+			st.Currency = currency
+		case "61":
+			if rows++; rows > MaxRows {
+				return nil, ErrTooManyRows
+			}
+			st.Rows = append(st.Rows, parseMT940Line(value, f.line))
+			last = &st.Rows[len(st.Rows)-1]
+		case "86":
+			if last != nil {
+				last.Payee, last.Note = parseMT940Info(f.lines)
+				last.Payee = truncate(last.Payee, maxPayeeLength)
+				last = nil
+			}
+		}
+	}
+	if st != nil {
+		statements = append(statements, *st)
+	}
+	if len(statements) == 0 {
+		return nil, apperror.Validation("import.no_mt940_statements")
+	}
+	return statements, nil
+}
+
+// mt940Fields делит файл на поля; строки без тега продолжают предыдущее поле.
+// Служебные строки SWIFT-конверта ({1:...}, {4:, -}) пропускаются.
+func mt940Fields(r io.Reader) ([]mt940Field, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	fields := []mt940Field{}
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r ")
+		if i := strings.Index(text, "{4:"); i >= 0 {
+			text = text[i+3:]
+		}
+		if text == "" || text == "-" || text == "-}" || strings.HasPrefix(text, "{") {
+			continue
+		}
+		if m := mt940Tag.FindStringSubmatch(text); m != nil {
+			fields = append(fields, mt940Field{tag: m[1], line: line, lines: []string{text[len(m[0]):]}})
+			continue
+		}
+		if len(fields) > 0 {
+			last := &fields[len(fields)-1]
+			last.lines = append(last.lines, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// parseMT940Balance разбирает значение поля баланса и возвращает баланс и валюту.
+func parseMT940Balance(s string) (*imports.Balance, string, error) {
+	m := mt940Balance.FindStringSubmatch(s)
+	if m == nil {
+		return nil, "", apperror.Validation("import.invalid_mt940_balance", s)
+	}
+	date, err := parseMT940Date(m[2])
+	if err != nil {
+		return nil, "", apperror.Validation("import.invalid_mt940_balance_date", s)
+	}
+	amount, err := ParseAmount(m[4], ",")
+	if err != nil {
+		return nil, "", apperror.Validation("import.invalid_mt940_balance_amount", s)
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+	return &imports.Balance{Amount: amount, Date: date}, m[3], nil
+}
+
+// parseMT940Line превращает поле :61: в строку выписки. Датой операции считается дата проводки,
+// если она указана, иначе дата валютирования.
+func parseMT940Line(s string, line int) imports.Row {
+	row := imports.Row{Line: line}
+	m := mt940Line.FindStringSubmatch(s)
+	if m == nil {
+		row.Error = "import.row_invalid_line"
+		return row
+	}
+	row.ExternalID = truncate(strings.TrimSpace(m[8]), maxExternalIDLength)
+	date, err := parseMT940Date(m[1])
+	if err != nil {
+		row.Error = "import.row_invalid_date"
+		return row
+	}
+	if m[2] != "" {
+		date, err = mt940EntryDate(date, m[2])
+		if err != nil {
+			row.Error = "import.row_invalid_date"
+			return row
+		}
+	}
+	row.Date = date
+	amount, err := ParseAmount(m[5], ",")
+	if err != nil {
+		row.Error = "import.row_invalid_amount"
+		return row
+	}
+	if amount.IsZero() {
+		row.Error = "import.row_zero_amount"
+		return row
+	}
+	// RD — сторно дебета (зачисление), RC — сторно кредита (списание).
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+	row.Amount = amount
+	return row
+}
+
+// parseMT940Info разбирает поле :86:. Структурированный формат с подполями ?NN (немецкий стандарт)
+// даёт контрагента (?32, ?33) и назначение (?20–?29, ?60–?63); иначе весь текст считается назначением.
+func parseMT940Info(lines []string) (payee, note string) {
+	text := strings.Join(lines, "")
+	if len(text) < 4 || text[3] != '?' || !isDigits(text[:3]) {
+		return "", strings.TrimSpace(strings.Join(lines, " "))
+	}
+	var purpose, name []string
+	for _, part := range strings.Split(text[4:], "?") {
+		if len(part) < 2 || !isDigits(part[:2]) {
+			continue
+		}
+		code, _ := strconv.Atoi(part[:2])
+		value := part[2:]
+		switch {
+		case code >= 20 && code <= 29, code >= 60 && code <= 63:
+			purpose = append(purpose, value)
+		case code == 32 || code == 33:
+			name = append(name, value)
+		}
+	}
+	return strings.TrimSpace(strings.Join(name, "")), strings.TrimSpace(strings.Join(purpose, ""))
+}
+
+// parseMT940Date разбирает дату YYMMDD.
+func parseMT940Date(s string) (time.Time, error) {
+	return time.Parse("060102", s)
+}
+
+// mt940EntryDate восстанавливает год даты проводки MMDD по дате валютирования:
+// проводка может быть в соседнем году, если операция пришлась на рубеж года.
+func mt940EntryDate(valueDate time.Time, mmdd string) (time.Time, error) {
+	date, err := time.Parse("20060102", strconv.Itoa(valueDate.Year())+mmdd)
+	if err != nil {
+		return time.Time{}, err
+	}
+	switch diff := date.Sub(valueDate); {
+	case diff > 180*24*time.Hour:
+		date = date.AddDate(-1, 0, 0)
+	case diff < -180*24*time.Hour:
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, nil
+}
+
+// isDigits сообщает, состоит ли строка только из цифр.
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,38 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMT940(t *testing.T) {
+	statements, err := ParseMT940(openTestdata(t, "bank.mt940"))
+	if err != nil {
+		t.Fatalf("ParseMT940 error: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	st := statements[0]
+	if st.AccountNumber != "0532013000" || st.Currency != "EUR" {
+		t.Errorf("account = %q %q, want 0532013000 EUR", st.AccountNumber, st.Currency)
+	}
+	checkBalance(t, "OpeningBalance", st.OpeningBalance, "1000.00", "2024-03-01")
+	checkBalance(t, "ClosingBalance", st.ClosingBalance, "2450.50", "2024-03-31")
+	checkRows(t, st.Rows, []wantRow{
+		{line: 6, date: "2024-03-05", amount: "-49.50", payee: "REWE MarktGmbH", note: "Einkauf Filiale 42Kartenzahlung", externalID: "BANKREF1"},
+		{line: 9, date: "2024-03-15", amount: "1500.00", note: "Gehalt Maerz ACME AG", externalID: "BANKREF2"},
+		{line: 11, err: "import.row_invalid_line"},
+		{line: 12, date: "2024-01-01", amount: "-100.00", externalID: "BANKREF3"},
+	})
+}
+
+func TestParseMT940InvalidBalance(t *testing.T) {
+	body := ":20:X\n:25:123\n:60F:C240301EUR1e5,00\n"
+	if _, err := ParseMT940(strings.NewReader(body)); err == nil {
+		t.Error("ParseMT940 with invalid balance should fail")
+	}
+	if _, err := ParseMT940(strings.NewReader("garbage\n")); err == nil {
+		t.Error("ParseMT940 without :20: should fail")
+	}
+}
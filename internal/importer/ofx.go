@@ -0,0 +1,237 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// ParseOFX разбирает выписку OFX/QFX версии 1 (SGML, без закрывающих тегов у значений) или версии 2 (XML).
+// Возвращает по одной выписке на каждый блок STMTRS/CCSTMTRS; FITID операций сохраняется в Row.ExternalID,
+// а LEDGERBAL — в ClosingBalance.
+func ParseOFX(r io.Reader) ([]Statement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	body, err := decodeOFX(data)
+	if err != nil {
+		return nil, err
+	}
+	start := strings.Index(strings.ToUpper(body), "<OFX>")
+	if start < 0 {
+		return nil, apperror.Validation("import.not_ofx")
+	}
+
+	p := ofxParser{line: 1 + strings.Count(body[:start], "\n")}
+	if err := p.run(body[start:]); err != nil {
+		return nil, err
+	}
+	if len(p.statements) == 0 {
+		return nil, apperror.Validation("import.no_statements")
+	}
+	return p.statements, nil
+}
+
+// ofxParser последовательно обходит теги OFX и собирает выписки.
+// SGML и XML обрабатываются одинаково: значение листового элемента — текст сразу после открывающего тега.
+type ofxParser struct {
+	statements []Statement
+	statement  *Statement
+	trn        *ofxTransaction
+	ledger     *imports.Balance
+	inLedger   bool
+	line       int
+	rows       int // Количество операций во всех выписках файла
+}
+
+// ofxTransaction накапливает поля одного STMTTRN.
+type ofxTransaction struct {
+	line   int
+	posted string
+	amount string
+	fitID  string
+	name   string
+	memo   string
+}
+
+// run обходит теги файла. Возвращает ErrTooManyRows, если операций в файле больше MaxRows.
+func (p *ofxParser) run(body string) error {
+	pos := 0
+	for {
+		lt := strings.IndexByte(body[pos:], '<')
+		if lt < 0 {
+			return nil
+		}
+		p.line += strings.Count(body[pos:pos+lt], "\n")
+		start := pos + lt
+		gt := strings.IndexByte(body[start:], '>')
+		if gt < 0 {
+			return nil
+		}
+		tag := strings.ToUpper(strings.TrimSpace(body[start+1 : start+gt]))
+		pos = start + gt + 1
+		end := strings.IndexByte(body[pos:], '<')
+		if end < 0 {
+			end = len(body) - pos
+		}
+		text := strings.TrimSpace(html.UnescapeString(body[pos : pos+end]))
+
+		switch {
+		case strings.HasPrefix(tag, "?"), strings.HasPrefix(tag, "!"):
+		case strings.HasPrefix(tag, "/"):
+			if err := p.close(tag[1:]); err != nil {
+				return err
+			}
+		default:
+			p.open(tag, text)
+		}
+	}
+}
+
+func (p *ofxParser) open(tag, text string) {
+	switch tag {
+	case "STMTRS", "CCSTMTRS":
+		p.statement = &Statement{}
+		return
+	case "STMTTRN":
+		p.trn = &ofxTransaction{line: p.line}
+		return
+	case "LEDGERBAL":
+		p.inLedger = true
+		p.ledger = &imports.Balance{}
+		return
+	}
+	if p.statement == nil || text == "" {
+		return
+	}
+	switch {
+	case p.trn != nil:
+		switch tag {
+		case "DTPOSTED":
+			p.trn.posted = text
+		case "TRNAMT":
+			p.trn.amount = text
+		case "FITID":
+			p.trn.fitID = text
+		case "NAME":
+			p.trn.name = text
+		case "MEMO":
+			p.trn.memo = text
+		}
+	case p.inLedger:
+		switch tag {
+		case "BALAMT":
+			if amount, err := parseOFXAmount(text); err == nil {
+				p.ledger.Amount = amount
+			}
+		case "DTASOF":
+			if date, err := parseOFXDate(text); err == nil {
+				p.ledger.Date = date
+			}
+		}
+	default:
+		switch tag {
+		case "CURDEF":
+			p.statement.Currency = text
+		case "ACCTID":
+			p.statement.AccountNumber = text
+		}
+	}
+}
+
+func (p *ofxParser) close(tag string) error {
+	switch tag {
+	case "STMTTRN":
+		if p.trn != nil && p.statement != nil {
+			if p.rows++; p.rows > MaxRows {
+				return ErrTooManyRows
+			}
+			p.statement.Rows = append(p.statement.Rows, p.trn.row())
+		}
+		p.trn = nil
+	case "LEDGERBAL":
+		if p.statement != nil && p.ledger != nil && p.ledger.Amount.IsSet() && !p.ledger.Date.IsZero() {
+			p.statement.ClosingBalance = p.ledger
+		}
+		p.inLedger = false
+		p.ledger = nil
+	case "STMTRS", "CCSTMTRS":
+		if p.statement != nil {
+			p.statements = append(p.statements, *p.statement)
+		}
+		p.statement = nil
+	}
+	return nil
+}
+
+// row превращает накопленный STMTTRN в строку выписки.
+func (t *ofxTransaction) row() imports.Row {
+	row := imports.Row{
+		Line:       t.line,
+		Payee:      truncate(t.name, maxPayeeLength),
+		Note:       t.memo,
+		ExternalID: truncate(t.fitID, maxExternalIDLength),
+	}
+	date, err := parseOFXDate(t.posted)
+	if err != nil {
+		row.Error = "import.row_invalid_date"
+		return row
+	}
+	row.Date = date
+	amount, err := parseOFXAmount(t.amount)
+	if err != nil {
+		row.Error = "import.row_invalid_amount"
+		return row
+	}
+	if amount.IsZero() {
+		row.Error = "import.row_zero_amount"
+		return row
+	}
+	row.Amount = amount
+	return row
+}
+
+// parseOFXDate разбирает дату OFX вида YYYYMMDD[HHMMSS[.XXX]][[gmt offset:tz name]], время отбрасывается.
+func parseOFXDate(s string) (time.Time, error) {
+	if len(s) < 8 {
+		return time.Time{}, errors.New("invalid OFX date")
+	}
+	return time.Parse("20060102", s[:8])
+}
+
+// parseOFXAmount разбирает сумму OFX; часть банков использует запятую как десятичный разделитель.
+func parseOFXAmount(s string) (money.Decimal, error) {
+	if strings.Contains(s, ",") && !strings.Contains(s, ".") {
+		return ParseAmount(s, ",")
+	}
+	return ParseAmount(s, ".")
+}
+
+// decodeOFX переводит файл в UTF-8: кодировка берётся из заголовка SGML (CHARSET:1251)
+// или из XML-декларации (encoding="windows-1251").
+func decodeOFX(data []byte) (string, error) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+	headerEnd := bytes.Index(bytes.ToUpper(data), []byte("<OFX>"))
+	if headerEnd < 0 {
+		headerEnd = len(data)
+	}
+	header := strings.ToUpper(string(data[:headerEnd]))
+	header = strings.NewReplacer(" ", "", "'", `"`).Replace(header)
+	if strings.Contains(header, "CHARSET:1251") || strings.Contains(header, `ENCODING="WINDOWS-1251"`) {
+		decoded, err := charmap.Windows1251.NewDecoder().Bytes(data)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	}
+	return string(data), nil
+}
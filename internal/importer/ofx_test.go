@@ -0,0 +1,71 @@
+package importer
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseOFXTooManyRows(t *testing.T) {
+	body := "<OFX><STMTRS><CURDEF>RUB" + strings.Repeat("<STMTTRN></STMTTRN>", MaxRows+1) + "</STMTRS></OFX>"
+	if _, err := ParseOFX(strings.NewReader(body)); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("ParseOFX error = %v, want ErrTooManyRows", err)
+	}
+
+	body = "<OFX><STMTRS><CURDEF>RUB" + strings.Repeat("<STMTTRN></STMTTRN>", MaxRows) + "</STMTRS></OFX>"
+	statements, err := ParseOFX(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("ParseOFX error: %v", err)
+	}
+	if len(statements) != 1 || len(statements[0].Rows) != MaxRows {
+		t.Fatalf("ParseOFX returned %d statements", len(statements))
+	}
+}
+
+// TestParseOFXTooManyRowsAcrossStatements проверяет, что лимит считается по всему файлу, а не по одной выписке.
+func TestParseOFXTooManyRowsAcrossStatements(t *testing.T) {
+	statement := "<STMTRS><CURDEF>RUB" + strings.Repeat("<STMTTRN></STMTTRN>", MaxRows/2+1) + "</STMTRS>"
+	body := "<OFX>" + statement + statement + "</OFX>"
+	if _, err := ParseOFX(strings.NewReader(body)); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("ParseOFX error = %v, want ErrTooManyRows", err)
+	}
+}
+
+func TestParseOFXSGML(t *testing.T) {
+	statements, err := ParseOFX(openTestdata(t, "bank.ofx"))
+	if err != nil {
+		t.Fatalf("ParseOFX error: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	st := statements[0]
+	if st.AccountNumber != "1234567890" || st.Currency != "USD" {
+		t.Errorf("account = %q %q, want 1234567890 USD", st.AccountNumber, st.Currency)
+	}
+	checkBalance(t, "ClosingBalance", st.ClosingBalance, "1457.85", "2024-03-10")
+	checkRows(t, st.Rows, []wantRow{
+		{date: "2024-03-05", amount: "-42.15", payee: "WHOLE FOODS & CO #123", note: "POS PURCHASE", externalID: "202403050001"},
+		{date: "2024-03-06", amount: "2500.00", payee: "ACME PAYROLL", externalID: "202403060001"},
+		{payee: "BROKEN AMOUNT", externalID: "202403070001", err: "import.row_invalid_amount"},
+		{externalID: "202403080001", err: "import.row_invalid_date"},
+	})
+}
+
+func TestParseOFXXML(t *testing.T) {
+	statements, err := ParseOFX(openTestdata(t, "bank_v2.ofx"))
+	if err != nil {
+		t.Fatalf("ParseOFX error: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("got %d statements, want 1", len(statements))
+	}
+	st := statements[0]
+	if st.AccountNumber != "4111111111111111" || st.Currency != "EUR" {
+		t.Errorf("account = %q %q, want 4111111111111111 EUR", st.AccountNumber, st.Currency)
+	}
+	checkBalance(t, "ClosingBalance", st.ClosingBalance, "-19.99", "2024-04-30")
+	checkRows(t, st.Rows, []wantRow{
+		{date: "2024-04-02", amount: "-19.99", payee: "Spotify", externalID: "CC-0001"},
+	})
+}
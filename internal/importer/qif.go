@@ -0,0 +1,166 @@
+package importer
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+)
+
+// Порядок частей даты в QIF. Формат не фиксирует локаль: американские программы пишут месяц первым.
+const (
+	DateOrderMDY = "MDY" // 12/31/2024, 12/31'24
+	DateOrderDMY = "DMY" // 31/12/2024
+	DateOrderYMD = "YMD" // 2024-12-31
+)
+
+// QIFOptions описывает параметры разбора QIF, которые не содержатся в самом файле.
+type QIFOptions struct {
+	DateOrder        string // Порядок частей даты (по умолчанию MDY)
+	DecimalSeparator string // Десятичный разделитель (по умолчанию точка)
+	Encoding         string // Кодировка файла (по умолчанию UTF-8)
+}
+
+// qifTransactionTypes — разделы QIF с операциями по счёту. Инвестиционные разделы и списки
+// (категории, классы, счета) пропускаются.
+var qifTransactionTypes = map[string]bool{
+	"BANK":  true,
+	"CASH":  true,
+	"CCARD": true,
+	"OTH A": true,
+	"OTH L": true,
+}
+
+// ParseQIF разбирает выписку QIF. QIF не содержит идентификаторов операций и баланса,
+// поэтому ExternalID строк и ClosingBalance остаются пустыми.
+func ParseQIF(r io.Reader, opts QIFOptions) (Statement, error) {
+	if opts.DateOrder == "" {
+		opts.DateOrder = DateOrderMDY
+	}
+	if opts.DecimalSeparator == "" {
+		opts.DecimalSeparator = "."
+	}
+	decoded, err := decode(r, opts.Encoding)
+	if err != nil {
+		return Statement{}, err
+	}
+
+	statement := Statement{Rows: []imports.Row{}}
+	scanner := bufio.NewScanner(decoded)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	inTransactions := false
+	var record map[byte]string
+	recordLine := 0
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if text[0] == '!' {
+			header := strings.ToUpper(strings.TrimSpace(text[1:]))
+			if strings.HasPrefix(header, "TYPE:") {
+				inTransactions = qifTransactionTypes[strings.TrimSpace(strings.TrimPrefix(header, "TYPE:"))]
+			} else if header == "ACCOUNT" {
+				inTransactions = false
+			}
+			record = nil
+			continue
+		}
+		if !inTransactions {
+			continue
+		}
+		if text[0] == '^' {
+			if record != nil {
+				if len(statement.Rows) >= MaxRows {
+					return Statement{}, ErrTooManyRows
+				}
+				statement.Rows = append(statement.Rows, qifRow(record, recordLine, opts))
+			}
+			record = nil
+			continue
+		}
+		if record == nil {
+			record = map[byte]string{}
+			recordLine = line
+		}
+		code, value := text[0], strings.TrimSpace(text[1:])
+		// Строки разбиения (S, E, $) повторяются; сумма операции берётся из T, поэтому хранится только первое значение.
+		if _, exists := record[code]; !exists {
+			record[code] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Statement{}, err
+	}
+	if len(statement.Rows) == 0 {
+		return Statement{}, apperror.Validation("import.no_transactions")
+	}
+	return statement, nil
+}
+
+// qifRow превращает запись QIF в строку выписки.
+func qifRow(record map[byte]string, line int, opts QIFOptions) imports.Row {
+	row := imports.Row{
+		Line:  line,
+		Payee: truncate(record['P'], maxPayeeLength),
+		Note:  record['M'],
+	}
+	date, err := parseQIFDate(record['D'], opts.DateOrder)
+	if err != nil {
+		row.Error = "import.row_invalid_date"
+		return row
+	}
+	row.Date = date
+	amountText := record['T']
+	if amountText == "" {
+		amountText = record['U']
+	}
+	amount, err := ParseAmount(amountText, opts.DecimalSeparator)
+	if err != nil {
+		row.Error = "import.row_invalid_amount"
+		return row
+	}
+	if amount.IsZero() {
+		row.Error = "import.row_zero_amount"
+		return row
+	}
+	row.Amount = amount
+	return row
+}
+
+// parseQIFDate разбирает дату QIF в заданном порядке частей. Разделителем может быть любой нецифровой символ;
+// апостроф перед двузначным годом (12/31'24) означает 2000-е годы, иначе годы до 70 считаются 2000-ми.
+func parseQIFDate(s, order string) (time.Time, error) {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+	if len(parts) != 3 || len(order) != 3 {
+		return time.Time{}, errors.New("invalid QIF date")
+	}
+	values := map[byte]int{}
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, err
+		}
+		values[order[i]] = v
+	}
+	year := values['Y']
+	if year < 100 {
+		if strings.Contains(s, "'") || year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+	date := time.Date(year, time.Month(values['M']), values['D'], 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(values['M']) || date.Day() != values['D'] {
+		return time.Time{}, errors.New("invalid QIF date")
+	}
+	return date, nil
+}
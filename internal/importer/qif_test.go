@@ -0,0 +1,36 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseQIF(t *testing.T) {
+	st, err := ParseQIF(openTestdata(t, "bank.qif"), QIFOptions{})
+	if err != nil {
+		t.Fatalf("ParseQIF error: %v", err)
+	}
+	if st.ClosingBalance != nil {
+		t.Errorf("ClosingBalance = %+v, want nil", st.ClosingBalance)
+	}
+	checkRows(t, st.Rows, []wantRow{
+		{line: 6, date: "2024-03-05", amount: "-1234.50", payee: "Whole Foods", note: "Weekly shopping"},
+		{line: 12, date: "2024-03-06", amount: "2500.00", payee: "ACME Payroll"},
+		{line: 17, payee: "Bad date", err: "import.row_invalid_date"},
+		{line: 21, payee: "Huge", err: "import.row_invalid_amount"},
+		{line: 25, date: "2024-03-09", amount: "-100.00", payee: "Split purchase"},
+	})
+}
+
+func TestParseQIFOptions(t *testing.T) {
+	body := "!Type:CCard\nD31.12.2023\nT-1.234,50\nPShop\n^\n"
+	st, err := ParseQIF(strings.NewReader(body), QIFOptions{DateOrder: DateOrderDMY, DecimalSeparator: ","})
+	if err != nil {
+		t.Fatalf("ParseQIF error: %v", err)
+	}
+	checkRows(t, st.Rows, []wantRow{{date: "2023-12-31", amount: "-1234.50", payee: "Shop"}})
+
+	if _, err := ParseQIF(strings.NewReader("!Type:Invst\nD1/1/2024\nT5\n^\n"), QIFOptions{}); err == nil {
+		t.Error("ParseQIF without bank transactions should fail")
+	}
+}
@@ -0,0 +1,12 @@
+package importer
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+
+// Statement — результат разбора выписки по одному счёту.
+type Statement struct {
+	AccountNumber  string           // Номер счёта из выписки (пусто, если формат его не содержит)
+	Currency       string           // Валюта счёта из выписки (пусто, если формат её не содержит)
+	OpeningBalance *imports.Balance // Входящий баланс по выписке (nil, если его нет)
+	ClosingBalance *imports.Balance // Итоговый баланс по выписке (nil, если его нет)
+	Rows           []imports.Row    // Строки выписки
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// LogMailer не отправляет письма, а записывает их целиком в w (файл или стандартный вывод).
+// Используется для локальной разработки и тестов: ссылки из писем можно взять из журнала.
+type LogMailer struct {
+	mu sync.Mutex // Защищает w от перемешивания параллельно записываемых писем
+	w  io.Writer
+}
+
+// NewLogMailer создает новый экземпляр LogMailer.
+func NewLogMailer(w io.Writer) *LogMailer {
+	return &LogMailer{w: w}
+}
+
+// Send записывает письмо в журнал.
+func (m *LogMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, err := fmt.Fprintf(m.w, "--- письмо %s ---\nTo: %s\nSubject: %s\n\n%s\n--- конец письма ---\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	return err
+}
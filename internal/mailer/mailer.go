@@ -0,0 +1,18 @@
+// Package mailer отправляет письма пользователям.
+// Способ доставки подключается реализацией Mailer: SMTP для продакшена, журнал для локальной разработки и тестов.
+package mailer
+
+import "context"
+
+// Message описывает текстовое письмо.
+type Message struct {
+	To      string // Адрес получателя
+	Subject string // Тема письма
+	Body    string // Текст письма (text/plain, UTF-8)
+}
+
+// Mailer — способ доставки писем.
+type Mailer interface {
+	// Send отправляет письмо. Ошибка означает, что письмо не было принято к доставке.
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,141 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// smtpTimeout ограничивает отправку одного письма, если у контекста нет более раннего дедлайна.
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig содержит параметры подключения к SMTP-серверу.
+type SMTPConfig struct {
+	Host     string // Адрес сервера
+	Port     string // Порт сервера
+	Username string // Логин; пустой — без аутентификации
+	Password string // Пароль
+	From     string // Адрес отправителя, например "MoneyFlow <no-reply@example.com>"
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер.
+type SMTPMailer struct {
+	config SMTPConfig
+	from   *mail.Address // Разобранный адрес отправителя
+}
+
+// NewSMTPMailer создает новый экземпляр SMTPMailer. Возвращает ошибку, если адрес отправителя некорректен.
+func NewSMTPMailer(config SMTPConfig) (*SMTPMailer, error) {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("mailer: invalid sender address %q: %w", config.From, err)
+	}
+	return &SMTPMailer{config: config, from: from}, nil
+}
+
+// Send отправляет письмо. Если сервер поддерживает STARTTLS, соединение шифруется;
+// логин и пароль передаются только по зашифрованному соединению или на localhost.
+// Подключение и обмен с сервером прерываются по дедлайну или отмене ctx, но не позже чем через smtpTimeout.
+func (m *SMTPMailer) Send(ctx context.Context, msg Message) error {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return fmt.Errorf("mailer: invalid recipient address: %w", err)
+	}
+	data, err := m.compose(to, msg)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.config.Host, m.config.Port))
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	// Отмена контекста закрывает соединение и прерывает ожидание ответа сервера
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if err := m.send(conn, to.Address, data); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return err
+	}
+	return nil
+}
+
+// send проводит SMTP-сессию по установленному соединению: конверт содержит только адреса, без имён.
+func (m *SMTPMailer) send(conn net.Conn, to string, data []byte) error {
+	c, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return err
+		}
+	}
+	if m.config.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("mailer: server does not support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.from.Address); err != nil {
+		return err
+	}
+	if err := c.Rcpt(to); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// compose собирает письмо в формате RFC 5322: имена в адресах и тема кодируются по RFC 2047, текст — quoted-printable.
+func (m *SMTPMailer) compose(to *mail.Address, msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", to.String())
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write([]byte(msg.Body)); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
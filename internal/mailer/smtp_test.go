@@ -0,0 +1,125 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeSMTPServer принимает одно соединение и отвечает на команды SMTP без расширений.
+// Возвращает адрес сервера и канал с полученными командами и текстом письма.
+func fakeSMTPServer(t *testing.T) (host, port string, session <-chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	lines := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r, received := bufio.NewReader(conn), []string{}
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+		inData := false
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				break
+			}
+			line = strings.TrimRight(line, "\r\n")
+			received = append(received, line)
+			switch {
+			case inData && line == ".":
+				inData = false
+				reply("250 OK")
+			case inData:
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				reply("250 localhost")
+			case line == "DATA":
+				inData = true
+				reply("354 go ahead")
+			case line == "QUIT":
+				reply("221 bye")
+				lines <- received
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+		lines <- received
+	}()
+	host, port, _ = net.SplitHostPort(ln.Addr().String())
+	return host, port, lines
+}
+
+func TestSMTPMailerSend(t *testing.T) {
+	host, port, session := fakeSMTPServer(t)
+	m, err := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "Денежный поток <no-reply@moneyflow.local>"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Send(context.Background(), Message{To: "user@example.com", Subject: "Тема", Body: "Текст"}); err != nil {
+		t.Fatalf("Send error: %v", err)
+	}
+	received := strings.Join(<-session, "\n")
+	for _, want := range []string{
+		"MAIL FROM:<no-reply@moneyflow.local>",
+		"RCPT TO:<user@example.com>",
+		"From: =?utf-8?q?",
+		"<no-reply@moneyflow.local>",
+		"To: <user@example.com>",
+	} {
+		if !strings.Contains(received, want) {
+			t.Errorf("session does not contain %q:\n%s", want, received)
+		}
+	}
+}
+
+// TestSMTPMailerSendContextDeadline проверяет, что зависший сервер не блокирует отправку дольше дедлайна контекста.
+func TestSMTPMailerSendContextDeadline(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		// Соединение принимается, но приветствие сервера не отправляется
+		if conn, err := ln.Accept(); err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	m, err := NewSMTPMailer(SMTPConfig{Host: host, Port: port, From: "no-reply@moneyflow.local"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = m.Send(ctx, Message{To: "user@example.com", Subject: "s", Body: "b"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Send error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Send took %s", elapsed)
+	}
+}
+
+func TestNewSMTPMailerInvalidFrom(t *testing.T) {
+	for _, from := range []string{"", "no-reply", "MoneyFlow <no-reply@moneyflow.local", "a@b.c\r\nBcc: x@y.z"} {
+		if _, err := NewSMTPMailer(SMTPConfig{From: from}); err == nil {
+			t.Errorf("NewSMTPMailer(From: %q) should fail", from)
+		}
+	}
+}
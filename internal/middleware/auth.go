@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
+)
+
+// principalKey — ключ, под которым Principal хранится в контексте gin.
+const principalKey = "principal"
+
+var (
+	// errUnauthorized — ответ на запрос без действительного access токена.
+	errUnauthorized = apperror.Unauthorized("unauthorized")
+	// errForbidden — ответ на запрос без нужного разрешения.
+	errForbidden = apperror.Forbidden("forbidden")
+	// errEmailNotVerified — ответ на запрос без нужного разрешения от пользователя с неподтверждённым email.
+	errEmailNotVerified = apperror.Forbidden("email_not_verified")
+)
+
+// Principal описывает аутентифицированного пользователя, от имени которого выполняется запрос.
+type Principal struct {
+	UserID     int      // ID пользователя
+	Email      string   // Email пользователя
+	SessionID  string   // Идентификатор сессии (семейства refresh токенов)
+	Scopes     []string // Разрешения access токена
+	Language   string   // Язык из профиля пользователя; пусто — язык выбирается по Accept-Language
+	Unverified bool     // Email пользователя не подтверждён
+}
+
+// HasScope проверяет, выдано ли пользователю указанное разрешение.
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// Authenticate возвращает middleware, которое проверяет access токен из заголовка Authorization
+// (алгоритм HS256, exp, nbf, iss, aud, тип токена) и кладёт Principal в контекст запроса.
+func Authenticate(cfg token.JWTConfig) gin.HandlerFunc {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(cfg.Issuer),
+		jwt.WithAudience(cfg.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	keyFunc := func(*jwt.Token) (interface{}, error) {
+		return []byte(cfg.Secret), nil
+	}
+
+	return func(c *gin.Context) {
+		tokenStr, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c)
+			return
+		}
+		var claims token.Claims
+		parsed, err := parser.ParseWithClaims(tokenStr, &claims, keyFunc)
+		if err != nil || !parsed.Valid || claims.TokenType != token.TypeAccess || claims.UserID == 0 {
+			abortUnauthorized(c)
+			return
+		}
+		c.Set(principalKey, &Principal{
+			UserID:     claims.UserID,
+			Email:      claims.Email,
+			SessionID:  claims.SessionID,
+			Scopes:     strings.Fields(claims.Scope),
+			Language:   claims.Language,
+			Unverified: claims.Unverified,
+		})
+		c.Next()
+	}
+}
+
+// RequireScope возвращает middleware, которое пропускает только запросы с указанным разрешением.
+// Если разрешения нет у пользователя с неподтверждённым email, ответ содержит код email_not_verified.
+// Должно подключаться после Authenticate.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := GetPrincipal(c)
+		if !ok {
+			abortUnauthorized(c)
+			return
+		}
+		if !principal.HasScope(scope) {
+			if principal.Unverified {
+				abortWithError(c, errEmailNotVerified)
+				return
+			}
+			abortWithError(c, errForbidden)
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetPrincipal возвращает Principal текущего запроса, если он был установлен Authenticate.
+func GetPrincipal(c *gin.Context) (*Principal, bool) {
+	value, ok := c.Get(principalKey)
+	if !ok {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok
+}
+
+// MustGetPrincipal возвращает Principal текущего запроса и паникует, если Authenticate не был подключен к маршруту.
+func MustGetPrincipal(c *gin.Context) *Principal {
+	principal, ok := GetPrincipal(c)
+	if !ok {
+		panic("middleware: principal is not set, Authenticate middleware is missing")
+	}
+	return principal
+}
+
+// bearerToken извлекает токен из заголовка вида "Bearer <token>".
+func bearerToken(header string) (string, bool) {
+	scheme, tokenStr, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || tokenStr == "" {
+		return "", false
+	}
+	return tokenStr, true
+}
+
+// abortUnauthorized прерывает обработку запроса с ответом 401.
+func abortUnauthorized(c *gin.Context) {
+	abortWithError(c, errUnauthorized)
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+)
+
+// errNotFound — ответ на pgx.ErrNoRows, не преобразованную сервисом в более точную ошибку.
+var errNotFound = apperror.NotFound("not_found")
+
+// HandleErrors возвращает middleware, которое отвечает на ошибку, добавленную обработчиком через c.Error:
+// статус и код берутся из *apperror.Error, pgx.ErrNoRows даёт 404, остальные ошибки — 500 без деталей
+// (детали пишутся в лог). Сообщения переводятся на язык запроса (см. Language). Подключается к роутеру до Authenticate и остальных middleware маршрутов.
+func HandleErrors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		last := c.Errors.Last()
+		if last == nil || c.Writer.Written() {
+			return
+		}
+		err := last.Err
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = errNotFound
+		}
+		appErr := apperror.From(err)
+		if appErr.Kind == apperror.KindInternal {
+			log.Printf("Ошибка обработки %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+		}
+		lang := Language(c)
+		c.Header("Content-Language", lang)
+		c.JSON(appErr.Status(), appErr.Response(lang))
+	}
+}
+
+// abortWithError прерывает обработку запроса и передаёт ошибку err в HandleErrors.
+func abortWithError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.Abort()
+}
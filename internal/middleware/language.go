@@ -0,0 +1,15 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+)
+
+// Language возвращает язык ответа на запрос: язык из профиля пользователя, если он задан
+// (передаётся в access токене), иначе наиболее подходящий под заголовок Accept-Language.
+func Language(c *gin.Context) string {
+	if principal, ok := GetPrincipal(c); ok && i18n.Supported(principal.Language) {
+		return principal.Language
+	}
+	return i18n.Match(c.GetHeader("Accept-Language"))
+}
@@ -1,14 +1,30 @@
 package account
 
-import "time"
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
 
 // BankAccount описывает банковский аккаунт пользователя.
 type BankAccount struct {
-	ID        int       // Уникальный идентификатор аккаунта
-	UserID    int       // ID пользователя
-	Name      string    // Название аккаунта
-	Balance   float64   // Баланс
-	Currency  string    // Валюта
-	CreatedAt time.Time // Дата создания
-	UpdatedAt time.Time // Дата обновления
+	ID              int            // Уникальный идентификатор аккаунта
+	UserID          int            // ID пользователя
+	Name            string         // Название аккаунта
+	Balance         money.Decimal  `swaggertype:"string"` // Баланс (в JSON — строка с точностью валюты)
+	Currency        string         // Валюта
+	IBAN            string         // IBAN счёта в банке (пусто, если не указан); по нему сопоставляются выписки camt.053 и MT940
+	BIC             string         // BIC (SWIFT-код) банка (пусто, если не указан)
+	Type            string         // Тип аккаунта: checking, cash, credit_card, savings, loan, investment
+	Liability       bool           // Аккаунт — обязательство (кредитная карта, кредит): долг хранится отрицательным балансом
+	CreditLimit     *money.Decimal `swaggertype:"string"` // Кредитный лимит (только для кредитных карт)
+	AvailableCredit *money.Decimal `swaggertype:"string"` // Доступный остаток лимита: лимит плюс баланс (null, если лимит не задан)
+	StatementDay    *int           // День формирования выписки по карте (1–31; в коротких месяцах — последний день месяца)
+	InterestRate    *money.Decimal `swaggertype:"string"` // Годовая процентная ставка в процентах (для вкладов и кредитов)
+	InvalidCurrency bool           // Код валюты вне справочника ISO 4217 (аккаунт создан до проверки кодов); исправляется через PUT /accounts/{id}
+	ArchivedAt      *time.Time     // Дата переноса в архив (null — аккаунт не в архиве)
+	DeletedAt       *time.Time     // Дата удаления (null — аккаунт не удалён); до окончательного удаления аккаунт можно восстановить
+	Version         int            // Версия аккаунта: увеличивается при каждом изменении, передаётся в ETag
+	CreatedAt       time.Time      // Дата создания
+	UpdatedAt       time.Time      // Дата обновления
 }
@@ -0,0 +1,53 @@
+package account
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeIBAN приводит IBAN к машинному виду: без пробелов и в верхнем регистре.
+func NormalizeIBAN(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+// ValidIBAN проверяет нормализованный IBAN: код страны, контрольные цифры и контрольную сумму по модулю 97 (ISO 13616).
+func ValidIBAN(iban string) bool {
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+	for i, r := range iban {
+		switch {
+		case i < 2 && !unicode.IsUpper(r):
+			return false
+		case i >= 2 && i < 4 && !unicode.IsDigit(r):
+			return false
+		case r > unicode.MaxASCII || !(unicode.IsUpper(r) || unicode.IsDigit(r)):
+			return false
+		}
+	}
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		if unicode.IsDigit(r) {
+			remainder = (remainder*10 + int(r-'0')) % 97
+		} else {
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		}
+	}
+	return remainder == 1
+}
+
+// ValidBIC проверяет BIC (SWIFT-код банка, ISO 9362): 8 или 11 символов, код банка и страны — буквы.
+func ValidBIC(bic string) bool {
+	if len(bic) != 8 && len(bic) != 11 {
+		return false
+	}
+	for i, r := range bic {
+		switch {
+		case i < 6 && !unicode.IsUpper(r):
+			return false
+		case r > unicode.MaxASCII || !(unicode.IsUpper(r) || unicode.IsDigit(r)):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package account
+
+// Поля, по которым допускается сортировка списка аккаунтов.
+const (
+	SortByCreatedAt = "created_at"
+	SortByName      = "name"
+	SortByBalance   = "balance"
+)
+
+// ListFilter описывает параметры выборки списка банковских аккаунтов пользователя.
+type ListFilter struct {
+	Currency        string // Фильтр по валюте (точное совпадение)
+	Name            string // Фильтр по подстроке названия (без учёта регистра)
+	Type            string // Фильтр по типу аккаунта
+	IncludeArchived bool   // Включать архивные аккаунты
+	InvalidCurrency bool   // Только аккаунты с кодом валюты вне справочника ISO 4217
+	SortBy          string // Поле сортировки
+	SortDesc        bool   // Сортировка по убыванию
+	Limit           int    // Размер страницы
+	Cursor          string // Курсор, полученный с предыдущей страницы
+}
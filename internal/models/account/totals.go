@@ -0,0 +1,30 @@
+package account
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Totals описывает сумму балансов всех аккаунтов пользователя в одной валюте.
+type Totals struct {
+	Currency     string             // Валюта итога
+	Date         time.Time          // Дата курсов пересчёта
+	Total        money.Decimal      `swaggertype:"string"` // Капитал: активы минус обязательства
+	Assets       money.Decimal      `swaggertype:"string"` // Сумма балансов аккаунтов-активов
+	Liabilities  money.Decimal      `swaggertype:"string"` // Долг по аккаунтам-обязательствам (положительное число при отрицательных балансах)
+	Accounts     []ConvertedBalance // Балансы по аккаунтам
+	MissingRates []string           // Валюты, для которых нет курса; их аккаунты не вошли в Total
+}
+
+// ConvertedBalance описывает баланс аккаунта, пересчитанный в валюту итога.
+type ConvertedBalance struct {
+	AccountID int            // ID аккаунта
+	Name      string         // Название аккаунта
+	Type      string         // Тип аккаунта
+	Liability bool           // Аккаунт — обязательство
+	Currency  string         // Валюта аккаунта
+	Balance   money.Decimal  `swaggertype:"string"` // Баланс в валюте аккаунта
+	Converted *money.Decimal `swaggertype:"string"` // Баланс в валюте итога (null, если нет курса)
+	Rate      *money.Decimal `swaggertype:"string"` // Применённый курс (null, если нет курса)
+}
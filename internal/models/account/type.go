@@ -0,0 +1,31 @@
+package account
+
+// Типы аккаунтов.
+const (
+	TypeChecking   = "checking"    // Текущий (расчётный) счёт или дебетовая карта
+	TypeCash       = "cash"        // Наличные
+	TypeCreditCard = "credit_card" // Кредитная карта
+	TypeSavings    = "savings"     // Сберегательный счёт или вклад
+	TypeLoan       = "loan"        // Кредит или займ
+	TypeInvestment = "investment"  // Брокерский или инвестиционный счёт
+)
+
+// ValidType сообщает, является ли t известным типом аккаунта.
+func ValidType(t string) bool {
+	switch t {
+	case TypeChecking, TypeCash, TypeCreditCard, TypeSavings, TypeLoan, TypeInvestment:
+		return true
+	}
+	return false
+}
+
+// IsLiability сообщает, является ли аккаунт типа t обязательством (долгом), а не активом.
+// Баланс обязательства хранится со знаком, как у любого аккаунта: долг — отрицательный баланс.
+func IsLiability(t string) bool {
+	return t == TypeCreditCard || t == TypeLoan
+}
+
+// HasInterestRate сообщает, указывается ли для аккаунта типа t процентная ставка.
+func HasInterestRate(t string) bool {
+	return t == TypeSavings || t == TypeLoan
+}
@@ -0,0 +1,91 @@
+package budget
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Периоды бюджета.
+const (
+	PeriodWeek    = "week"    // Неделя, начиная с дня недели даты начала
+	PeriodMonth   = "month"   // Календарный месяц
+	PeriodQuarter = "quarter" // Три месяца, начиная с месяца даты начала
+	PeriodYear    = "year"    // Двенадцать месяцев, начиная с месяца даты начала
+)
+
+// Budget описывает повторяющийся бюджет пользователя с лимитами по категориям расходов.
+// Лимиты действуют в каждом периоде, начиная с StartDate.
+type Budget struct {
+	ID        int       // Уникальный идентификатор бюджета
+	UserID    int       // ID пользователя
+	Name      string    // Название
+	Period    string    // Период: week, month, quarter или year
+	Currency  string    // Валюта; учитываются только операции по аккаунтам в этой валюте
+	StartDate time.Time // Начало первого периода
+	Rollover  bool      // Переносить неизрасходованный остаток на следующий период
+	Limits    []Limit   // Лимиты по категориям
+	CreatedAt time.Time // Дата создания
+	UpdatedAt time.Time // Дата обновления
+}
+
+// Limit описывает лимит расходов по категории на один период.
+// Лимит распространяется на категорию вместе с её подкатегориями.
+type Limit struct {
+	CategoryID int           // ID категории расходов
+	Amount     money.Decimal `swaggertype:"string"` // Лимит на период
+}
+
+// PeriodStart возвращает начало периода бюджета, содержащего дату date.
+// Для дат раньше StartDate возвращается начало первого периода.
+func (b Budget) PeriodStart(date time.Time) time.Time {
+	start := b.StartDate
+	for {
+		next := b.NextPeriodStart(start)
+		if next.After(date) {
+			return start
+		}
+		start = next
+	}
+}
+
+// NextPeriodStart возвращает начало периода, следующего за периодом, который начинается в start.
+func (b Budget) NextPeriodStart(start time.Time) time.Time {
+	switch b.Period {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodQuarter:
+		return start.AddDate(0, 3, 0)
+	case PeriodYear:
+		return start.AddDate(1, 0, 0)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+// Progress описывает исполнение бюджета за один период.
+type Progress struct {
+	BudgetID    int                // ID бюджета
+	Currency    string             // Валюта бюджета
+	PeriodStart time.Time          // Начало периода
+	PeriodEnd   time.Time          // Конец периода включительно
+	Categories  []CategoryProgress // Исполнение по категориям
+}
+
+// CategoryProgress описывает исполнение лимита по категории за период.
+type CategoryProgress struct {
+	CategoryID int            // ID категории
+	Limit      money.Decimal  `swaggertype:"string"` // Лимит на период
+	RolledOver money.Decimal  `swaggertype:"string"` // Остаток, перенесённый с предыдущих периодов
+	Available  money.Decimal  `swaggertype:"string"` // Доступно в периоде: лимит плюс перенесённый остаток
+	Spent      money.Decimal  `swaggertype:"string"` // Потрачено в периоде (с учётом подкатегорий)
+	Remaining  money.Decimal  `swaggertype:"string"` // Остаток; отрицательный при перерасходе
+	Percent    *money.Decimal `swaggertype:"string"` // Доля потраченного от доступного в процентах (null, если доступно 0)
+}
+
+// Spending описывает сумму расходов по категории лимита за один день.
+type Spending struct {
+	CategoryID int           // ID категории лимита
+	Date       time.Time     // День
+	Amount     money.Decimal // Потрачено за день (положительное число)
+}
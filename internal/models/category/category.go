@@ -0,0 +1,28 @@
+package category
+
+import "time"
+
+// Виды категорий.
+const (
+	KindIncome  = "income"  // Категория доходов
+	KindExpense = "expense" // Категория расходов
+)
+
+// Category описывает категорию доходов или расходов пользователя.
+// Категории образуют дерево: у дочерней категории тот же вид, что и у родительской.
+type Category struct {
+	ID        int       // Уникальный идентификатор категории
+	UserID    int       // ID пользователя
+	ParentID  *int      // ID родительской категории (nil для категории верхнего уровня)
+	Name      string    // Название
+	Kind      string    // Вид: income или expense
+	CreatedAt time.Time // Дата создания
+	UpdatedAt time.Time // Дата обновления
+}
+
+// Default описывает категорию из набора по умолчанию, создаваемого при регистрации.
+type Default struct {
+	Name     string   // Название
+	Kind     string   // Вид: income или expense
+	Children []string // Названия дочерних категорий
+}
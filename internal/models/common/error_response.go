@@ -2,6 +2,14 @@ package common
 
 // ErrorResponse описывает структуру ответа с ошибкой.
 type ErrorResponse struct {
-	StatusCode int    `json:"statusCode"` // HTTP статус ошибки
-	Message    string `json:"message"`    // Сообщение с деталями ошибки
+	StatusCode int          `json:"statusCode"`       // HTTP статус ошибки
+	Code       string       `json:"code"`             // Машиночитаемый код ошибки, например account_not_found
+	Message    string       `json:"message"`          // Сообщение с деталями ошибки
+	Fields     []FieldError `json:"fields,omitempty"` // Ошибки отдельных полей запроса (только для ошибок валидации)
+}
+
+// FieldError описывает ошибку в значении одного поля запроса.
+type FieldError struct {
+	Field   string `json:"field"`   // Имя поля в запросе (ключ JSON или параметр запроса)
+	Message string `json:"message"` // Описание ошибки
 }
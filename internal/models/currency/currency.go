@@ -0,0 +1,218 @@
+// Package currency содержит справочник валют ISO 4217: коды, количество знаков после запятой и символы.
+package currency
+
+import (
+	"slices"
+	"strings"
+)
+
+// Currency описывает валюту из справочника ISO 4217.
+type Currency struct {
+	Code       string // Буквенный код ISO 4217
+	Name       string // Название
+	MinorUnits int32  // Количество знаков после запятой
+	Symbol     string // Символ для отображения
+}
+
+// registry — действующие валюты ISO 4217, отсортированные по коду.
+// Драгоценные металлы, расчётные единицы и фонды (XAU, XDR, BOV и т. п.) не входят.
+var registry = []Currency{
+	{Code: "AED", MinorUnits: 2, Symbol: "د.إ", Name: "Дирхам ОАЭ"},
+	{Code: "AFN", MinorUnits: 2, Symbol: "؋", Name: "Афгани"},
+	{Code: "ALL", MinorUnits: 2, Symbol: "L", Name: "Лек"},
+	{Code: "AMD", MinorUnits: 2, Symbol: "֏", Name: "Армянский драм"},
+	{Code: "ANG", MinorUnits: 2, Symbol: "ƒ", Name: "Нидерландский антильский гульден"},
+	{Code: "AOA", MinorUnits: 2, Symbol: "Kz", Name: "Кванза"},
+	{Code: "ARS", MinorUnits: 2, Symbol: "$", Name: "Аргентинское песо"},
+	{Code: "AUD", MinorUnits: 2, Symbol: "A$", Name: "Австралийский доллар"},
+	{Code: "AWG", MinorUnits: 2, Symbol: "ƒ", Name: "Арубанский флорин"},
+	{Code: "AZN", MinorUnits: 2, Symbol: "₼", Name: "Азербайджанский манат"},
+	{Code: "BAM", MinorUnits: 2, Symbol: "KM", Name: "Конвертируемая марка"},
+	{Code: "BBD", MinorUnits: 2, Symbol: "$", Name: "Барбадосский доллар"},
+	{Code: "BDT", MinorUnits: 2, Symbol: "৳", Name: "Така"},
+	{Code: "BGN", MinorUnits: 2, Symbol: "лв", Name: "Болгарский лев"},
+	{Code: "BHD", MinorUnits: 3, Symbol: ".د.ب", Name: "Бахрейнский динар"},
+	{Code: "BIF", MinorUnits: 0, Symbol: "FBu", Name: "Бурундийский франк"},
+	{Code: "BMD", MinorUnits: 2, Symbol: "$", Name: "Бермудский доллар"},
+	{Code: "BND", MinorUnits: 2, Symbol: "$", Name: "Брунейский доллар"},
+	{Code: "BOB", MinorUnits: 2, Symbol: "Bs", Name: "Боливиано"},
+	{Code: "BRL", MinorUnits: 2, Symbol: "R$", Name: "Бразильский реал"},
+	{Code: "BSD", MinorUnits: 2, Symbol: "$", Name: "Багамский доллар"},
+	{Code: "BTN", MinorUnits: 2, Symbol: "Nu.", Name: "Нгултрум"},
+	{Code: "BWP", MinorUnits: 2, Symbol: "P", Name: "Пула"},
+	{Code: "BYN", MinorUnits: 2, Symbol: "Br", Name: "Белорусский рубль"},
+	{Code: "BZD", MinorUnits: 2, Symbol: "$", Name: "Белизский доллар"},
+	{Code: "CAD", MinorUnits: 2, Symbol: "C$", Name: "Канадский доллар"},
+	{Code: "CDF", MinorUnits: 2, Symbol: "FC", Name: "Конголезский франк"},
+	{Code: "CHF", MinorUnits: 2, Symbol: "Fr", Name: "Швейцарский франк"},
+	{Code: "CLF", MinorUnits: 4, Symbol: "UF", Name: "Условная расчётная единица Чили"},
+	{Code: "CLP", MinorUnits: 0, Symbol: "$", Name: "Чилийское песо"},
+	{Code: "CNY", MinorUnits: 2, Symbol: "¥", Name: "Юань"},
+	{Code: "COP", MinorUnits: 2, Symbol: "$", Name: "Колумбийское песо"},
+	{Code: "CRC", MinorUnits: 2, Symbol: "₡", Name: "Костариканский колон"},
+	{Code: "CUP", MinorUnits: 2, Symbol: "$", Name: "Кубинское песо"},
+	{Code: "CVE", MinorUnits: 2, Symbol: "$", Name: "Эскудо Кабо-Верде"},
+	{Code: "CZK", MinorUnits: 2, Symbol: "Kč", Name: "Чешская крона"},
+	{Code: "DJF", MinorUnits: 0, Symbol: "Fdj", Name: "Франк Джибути"},
+	{Code: "DKK", MinorUnits: 2, Symbol: "kr", Name: "Датская крона"},
+	{Code: "DOP", MinorUnits: 2, Symbol: "$", Name: "Доминиканское песо"},
+	{Code: "DZD", MinorUnits: 2, Symbol: "د.ج", Name: "Алжирский динар"},
+	{Code: "EGP", MinorUnits: 2, Symbol: "E£", Name: "Египетский фунт"},
+	{Code: "ERN", MinorUnits: 2, Symbol: "Nfk", Name: "Накфа"},
+	{Code: "ETB", MinorUnits: 2, Symbol: "Br", Name: "Эфиопский быр"},
+	{Code: "EUR", MinorUnits: 2, Symbol: "€", Name: "Евро"},
+	{Code: "FJD", MinorUnits: 2, Symbol: "$", Name: "Доллар Фиджи"},
+	{Code: "FKP", MinorUnits: 2, Symbol: "£", Name: "Фунт Фолклендских островов"},
+	{Code: "GBP", MinorUnits: 2, Symbol: "£", Name: "Фунт стерлингов"},
+	{Code: "GEL", MinorUnits: 2, Symbol: "₾", Name: "Лари"},
+	{Code: "GHS", MinorUnits: 2, Symbol: "₵", Name: "Ганский седи"},
+	{Code: "GIP", MinorUnits: 2, Symbol: "£", Name: "Гибралтарский фунт"},
+	{Code: "GMD", MinorUnits: 2, Symbol: "D", Name: "Даласи"},
+	{Code: "GNF", MinorUnits: 0, Symbol: "FG", Name: "Гвинейский франк"},
+	{Code: "GTQ", MinorUnits: 2, Symbol: "Q", Name: "Кетсаль"},
+	{Code: "GYD", MinorUnits: 2, Symbol: "$", Name: "Гайанский доллар"},
+	{Code: "HKD", MinorUnits: 2, Symbol: "HK$", Name: "Гонконгский доллар"},
+	{Code: "HNL", MinorUnits: 2, Symbol: "L", Name: "Лемпира"},
+	{Code: "HTG", MinorUnits: 2, Symbol: "G", Name: "Гурд"},
+	{Code: "HUF", MinorUnits: 2, Symbol: "Ft", Name: "Форинт"},
+	{Code: "IDR", MinorUnits: 2, Symbol: "Rp", Name: "Рупия"},
+	{Code: "ILS", MinorUnits: 2, Symbol: "₪", Name: "Новый израильский шекель"},
+	{Code: "INR", MinorUnits: 2, Symbol: "₹", Name: "Индийская рупия"},
+	{Code: "IQD", MinorUnits: 3, Symbol: "ع.د", Name: "Иракский динар"},
+	{Code: "IRR", MinorUnits: 2, Symbol: "﷼", Name: "Иранский риал"},
+	{Code: "ISK", MinorUnits: 0, Symbol: "kr", Name: "Исландская крона"},
+	{Code: "JMD", MinorUnits: 2, Symbol: "$", Name: "Ямайский доллар"},
+	{Code: "JOD", MinorUnits: 3, Symbol: "د.ا", Name: "Иорданский динар"},
+	{Code: "JPY", MinorUnits: 0, Symbol: "¥", Name: "Иена"},
+	{Code: "KES", MinorUnits: 2, Symbol: "KSh", Name: "Кенийский шиллинг"},
+	{Code: "KGS", MinorUnits: 2, Symbol: "с", Name: "Сом"},
+	{Code: "KHR", MinorUnits: 2, Symbol: "៛", Name: "Риель"},
+	{Code: "KMF", MinorUnits: 0, Symbol: "CF", Name: "Франк Комор"},
+	{Code: "KPW", MinorUnits: 2, Symbol: "₩", Name: "Северокорейская вона"},
+	{Code: "KRW", MinorUnits: 0, Symbol: "₩", Name: "Вона"},
+	{Code: "KWD", MinorUnits: 3, Symbol: "د.ك", Name: "Кувейтский динар"},
+	{Code: "KYD", MinorUnits: 2, Symbol: "$", Name: "Доллар Островов Кайман"},
+	{Code: "KZT", MinorUnits: 2, Symbol: "₸", Name: "Тенге"},
+	{Code: "LAK", MinorUnits: 2, Symbol: "₭", Name: "Кип"},
+	{Code: "LBP", MinorUnits: 2, Symbol: "ل.ل", Name: "Ливанский фунт"},
+	{Code: "LKR", MinorUnits: 2, Symbol: "Rs", Name: "Шри-ланкийская рупия"},
+	{Code: "LRD", MinorUnits: 2, Symbol: "$", Name: "Либерийский доллар"},
+	{Code: "LSL", MinorUnits: 2, Symbol: "L", Name: "Лоти"},
+	{Code: "LYD", MinorUnits: 3, Symbol: "ل.د", Name: "Ливийский динар"},
+	{Code: "MAD", MinorUnits: 2, Symbol: "د.م.", Name: "Марокканский дирхам"},
+	{Code: "MDL", MinorUnits: 2, Symbol: "L", Name: "Молдавский лей"},
+	{Code: "MGA", MinorUnits: 2, Symbol: "Ar", Name: "Ариари"},
+	{Code: "MKD", MinorUnits: 2, Symbol: "ден", Name: "Денар"},
+	{Code: "MMK", MinorUnits: 2, Symbol: "K", Name: "Кьят"},
+	{Code: "MNT", MinorUnits: 2, Symbol: "₮", Name: "Тугрик"},
+	{Code: "MOP", MinorUnits: 2, Symbol: "MOP$", Name: "Патака"},
+	{Code: "MRU", MinorUnits: 2, Symbol: "UM", Name: "Угия"},
+	{Code: "MUR", MinorUnits: 2, Symbol: "₨", Name: "Маврикийская рупия"},
+	{Code: "MVR", MinorUnits: 2, Symbol: "Rf", Name: "Руфия"},
+	{Code: "MWK", MinorUnits: 2, Symbol: "MK", Name: "Квача Малави"},
+	{Code: "MXN", MinorUnits: 2, Symbol: "$", Name: "Мексиканское песо"},
+	{Code: "MYR", MinorUnits: 2, Symbol: "RM", Name: "Малайзийский ринггит"},
+	{Code: "MZN", MinorUnits: 2, Symbol: "MT", Name: "Мозамбикский метикал"},
+	{Code: "NAD", MinorUnits: 2, Symbol: "$", Name: "Доллар Намибии"},
+	{Code: "NGN", MinorUnits: 2, Symbol: "₦", Name: "Найра"},
+	{Code: "NIO", MinorUnits: 2, Symbol: "C$", Name: "Золотая кордоба"},
+	{Code: "NOK", MinorUnits: 2, Symbol: "kr", Name: "Норвежская крона"},
+	{Code: "NPR", MinorUnits: 2, Symbol: "₨", Name: "Непальская рупия"},
+	{Code: "NZD", MinorUnits: 2, Symbol: "NZ$", Name: "Новозеландский доллар"},
+	{Code: "OMR", MinorUnits: 3, Symbol: "ر.ع.", Name: "Оманский риал"},
+	{Code: "PAB", MinorUnits: 2, Symbol: "B/.", Name: "Бальбоа"},
+	{Code: "PEN", MinorUnits: 2, Symbol: "S/", Name: "Соль"},
+	{Code: "PGK", MinorUnits: 2, Symbol: "K", Name: "Кина"},
+	{Code: "PHP", MinorUnits: 2, Symbol: "₱", Name: "Филиппинское песо"},
+	{Code: "PKR", MinorUnits: 2, Symbol: "₨", Name: "Пакистанская рупия"},
+	{Code: "PLN", MinorUnits: 2, Symbol: "zł", Name: "Злотый"},
+	{Code: "PYG", MinorUnits: 0, Symbol: "₲", Name: "Гуарани"},
+	{Code: "QAR", MinorUnits: 2, Symbol: "ر.ق", Name: "Катарский риал"},
+	{Code: "RON", MinorUnits: 2, Symbol: "lei", Name: "Румынский лей"},
+	{Code: "RSD", MinorUnits: 2, Symbol: "дин.", Name: "Сербский динар"},
+	{Code: "RUB", MinorUnits: 2, Symbol: "₽", Name: "Российский рубль"},
+	{Code: "RWF", MinorUnits: 0, Symbol: "FRw", Name: "Франк Руанды"},
+	{Code: "SAR", MinorUnits: 2, Symbol: "ر.س", Name: "Саудовский риял"},
+	{Code: "SBD", MinorUnits: 2, Symbol: "$", Name: "Доллар Соломоновых Островов"},
+	{Code: "SCR", MinorUnits: 2, Symbol: "₨", Name: "Сейшельская рупия"},
+	{Code: "SDG", MinorUnits: 2, Symbol: "ج.س.", Name: "Суданский фунт"},
+	{Code: "SEK", MinorUnits: 2, Symbol: "kr", Name: "Шведская крона"},
+	{Code: "SGD", MinorUnits: 2, Symbol: "S$", Name: "Сингапурский доллар"},
+	{Code: "SHP", MinorUnits: 2, Symbol: "£", Name: "Фунт Святой Елены"},
+	{Code: "SLE", MinorUnits: 2, Symbol: "Le", Name: "Леоне"},
+	{Code: "SOS", MinorUnits: 2, Symbol: "Sh", Name: "Сомалийский шиллинг"},
+	{Code: "SRD", MinorUnits: 2, Symbol: "$", Name: "Суринамский доллар"},
+	{Code: "SSP", MinorUnits: 2, Symbol: "£", Name: "Южносуданский фунт"},
+	{Code: "STN", MinorUnits: 2, Symbol: "Db", Name: "Добра"},
+	{Code: "SVC", MinorUnits: 2, Symbol: "₡", Name: "Сальвадорский колон"},
+	{Code: "SYP", MinorUnits: 2, Symbol: "£S", Name: "Сирийский фунт"},
+	{Code: "SZL", MinorUnits: 2, Symbol: "L", Name: "Лилангени"},
+	{Code: "THB", MinorUnits: 2, Symbol: "฿", Name: "Бат"},
+	{Code: "TJS", MinorUnits: 2, Symbol: "SM", Name: "Сомони"},
+	{Code: "TMT", MinorUnits: 2, Symbol: "m", Name: "Туркменский манат"},
+	{Code: "TND", MinorUnits: 3, Symbol: "د.ت", Name: "Тунисский динар"},
+	{Code: "TOP", MinorUnits: 2, Symbol: "T$", Name: "Паанга"},
+	{Code: "TRY", MinorUnits: 2, Symbol: "₺", Name: "Турецкая лира"},
+	{Code: "TTD", MinorUnits: 2, Symbol: "$", Name: "Доллар Тринидада и Тобаго"},
+	{Code: "TWD", MinorUnits: 2, Symbol: "NT$", Name: "Новый тайваньский доллар"},
+	{Code: "TZS", MinorUnits: 2, Symbol: "TSh", Name: "Танзанийский шиллинг"},
+	{Code: "UAH", MinorUnits: 2, Symbol: "₴", Name: "Гривна"},
+	{Code: "UGX", MinorUnits: 0, Symbol: "USh", Name: "Угандийский шиллинг"},
+	{Code: "USD", MinorUnits: 2, Symbol: "$", Name: "Доллар США"},
+	{Code: "UYU", MinorUnits: 2, Symbol: "$", Name: "Уругвайское песо"},
+	{Code: "UYW", MinorUnits: 4, Symbol: "UP", Name: "Индексированная единица заработной платы Уругвая"},
+	{Code: "UZS", MinorUnits: 2, Symbol: "soʻm", Name: "Узбекский сум"},
+	{Code: "VED", MinorUnits: 2, Symbol: "Bs.D", Name: "Цифровой боливар"},
+	{Code: "VES", MinorUnits: 2, Symbol: "Bs.S", Name: "Боливар Соберано"},
+	{Code: "VND", MinorUnits: 0, Symbol: "₫", Name: "Донг"},
+	{Code: "VUV", MinorUnits: 0, Symbol: "VT", Name: "Вату"},
+	{Code: "WST", MinorUnits: 2, Symbol: "T", Name: "Тала"},
+	{Code: "XAF", MinorUnits: 0, Symbol: "FCFA", Name: "Франк КФА BEAC"},
+	{Code: "XCD", MinorUnits: 2, Symbol: "$", Name: "Восточно-карибский доллар"},
+	{Code: "XOF", MinorUnits: 0, Symbol: "CFA", Name: "Франк КФА BCEAO"},
+	{Code: "XPF", MinorUnits: 0, Symbol: "₣", Name: "Франк КФП"},
+	{Code: "YER", MinorUnits: 2, Symbol: "﷼", Name: "Йеменский риал"},
+	{Code: "ZAR", MinorUnits: 2, Symbol: "R", Name: "Рэнд"},
+	{Code: "ZMW", MinorUnits: 2, Symbol: "K", Name: "Замбийская квача"},
+	{Code: "ZWG", MinorUnits: 2, Symbol: "ZiG", Name: "Зимбабвийский золотой"},
+}
+
+// byCode — индекс справочника по коду.
+var byCode = func() map[string]Currency {
+	m := make(map[string]Currency, len(registry))
+	for _, c := range registry {
+		m[c.Code] = c
+	}
+	return m
+}()
+
+// Normalize приводит введённый код валюты к виду справочника: без пробелов, в верхнем регистре.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Lookup возвращает валюту по коду. Код сравнивается точно: "usd" не найдётся, его нужно сначала нормализовать.
+func Lookup(code string) (Currency, bool) {
+	c, ok := byCode[code]
+	return c, ok
+}
+
+// Valid сообщает, является ли code действующим кодом ISO 4217.
+func Valid(code string) bool {
+	_, ok := byCode[code]
+	return ok
+}
+
+// All возвращает все валюты справочника, отсортированные по коду.
+func All() []Currency {
+	return slices.Clone(registry)
+}
+
+// Codes возвращает коды всех валют справочника.
+func Codes() []string {
+	codes := make([]string, 0, len(registry))
+	for _, c := range registry {
+		codes = append(codes, c.Code)
+	}
+	return codes
+}
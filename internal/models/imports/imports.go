@@ -0,0 +1,82 @@
+package imports
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Форматы выписок.
+const (
+	FormatCSV     = "csv"     // CSV с профилем сопоставления колонок
+	FormatOFX     = "ofx"     // OFX/QFX (SGML и XML)
+	FormatQIF     = "qif"     // Quicken Interchange Format
+	FormatCAMT053 = "camt053" // ISO 20022 camt.053 (BankToCustomerStatement)
+	FormatMT940   = "mt940"   // SWIFT MT940
+)
+
+// Статусы пакета импорта.
+const (
+	StatusPreview   = "preview"   // Выписка разобрана, операции ещё не созданы
+	StatusCommitted = "committed" // Операции созданы
+)
+
+// Batch описывает пакет импорта — одну загруженную выписку по банковскому аккаунту.
+// Сначала пакет создаётся в статусе preview для проверки, затем подтверждается и превращается в операции.
+type Batch struct {
+	ID                  int           // Уникальный идентификатор пакета
+	UserID              int           // ID пользователя
+	AccountID           int           // ID банковского аккаунта
+	Format              string        // Формат выписки
+	FileName            string        // Имя загруженного файла
+	Status              string        // Статус: preview или committed
+	OpeningBalance      *Balance      // Входящий баланс по выписке, если он в ней есть
+	OpeningBalanceCheck *BalanceCheck // Сверка входящего баланса с балансом аккаунта перед первой операцией выписки
+	ClosingBalance      *Balance      // Итоговый (ledger) баланс по выписке, если он в ней есть
+	BalanceCheck        *BalanceCheck // Сверка итогового баланса выписки с аккаунтом
+	Rows                []Row         // Строки выписки
+	CreatedAt           time.Time     // Дата загрузки
+	CommittedAt         *time.Time    // Дата подтверждения (nil для preview)
+}
+
+// Balance описывает баланс счёта по выписке на дату.
+type Balance struct {
+	Amount money.Decimal `swaggertype:"string"` // Сумма
+	Date   time.Time     // Дата, на которую указан баланс
+}
+
+// BalanceCheck описывает сверку баланса выписки с балансом аккаунта на ту же дату.
+// Расчётный баланс учитывает операции аккаунта по эту дату и ещё не импортированные строки пакета.
+type BalanceCheck struct {
+	Date       time.Time     // Дата баланса по выписке
+	Statement  money.Decimal `swaggertype:"string"` // Баланс по выписке
+	Expected   money.Decimal `swaggertype:"string"` // Баланс аккаунта на эту дату после импорта
+	Difference money.Decimal `swaggertype:"string"` // Разница: выписка минус расчёт
+	Matches    bool          // Балансы совпадают
+}
+
+// Row описывает одну строку выписки после разбора.
+// Строки с ошибкой разбора при подтверждении пропускаются.
+type Row struct {
+	Line            int           // Номер строки в файле (с 1)
+	Date            time.Time     // Дата операции
+	Amount          money.Decimal `swaggertype:"string"` // Сумма со знаком: положительная — поступление, отрицательная — расход
+	Payee           string        // Контрагент
+	Note            string        // Назначение платежа / комментарий
+	ExternalID      string        // Идентификатор операции в банке (FITID); пусто, если формат его не содержит
+	AlreadyImported bool          // Операция с таким ExternalID уже есть на аккаунте — строка будет пропущена
+	DuplicateOf     *int          // Вероятный дубликат: ID похожей операции на аккаунте (строка всё равно будет импортирована)
+	DuplicateScore  float64       // Оценка сходства с DuplicateOf от 0 до 1
+	Error           string        // Ключ сообщения об ошибке разбора строки в каталогах i18n (пусто, если строка корректна)
+	TransactionID   *int          // ID созданной операции (после подтверждения)
+}
+
+// Valid сообщает, можно ли создать операцию из строки.
+func (r Row) Valid() bool {
+	return r.Error == ""
+}
+
+// Importable сообщает, будет ли из строки создана операция при подтверждении.
+func (r Row) Importable() bool {
+	return r.Valid() && !r.AlreadyImported
+}
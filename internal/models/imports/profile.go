@@ -0,0 +1,42 @@
+package imports
+
+import "time"
+
+// Соглашения о знаке суммы в выписке.
+const (
+	SignSigned      = "signed"       // Одна колонка суммы: отрицательная — расход
+	SignInverted    = "inverted"     // Одна колонка суммы: положительная — расход (выписки по кредитным картам)
+	SignDebitCredit = "debit_credit" // Отдельные колонки списания и зачисления
+)
+
+// Роли колонок CSV.
+const (
+	ColumnDate   = "date"   // Дата операции
+	ColumnAmount = "amount" // Сумма (для signed и inverted)
+	ColumnDebit  = "debit"  // Сумма списания (для debit_credit)
+	ColumnCredit = "credit" // Сумма зачисления (для debit_credit)
+	ColumnPayee  = "payee"  // Контрагент
+	ColumnNote   = "note"   // Назначение платежа / комментарий
+)
+
+// Кодировки CSV-файлов.
+const (
+	EncodingUTF8        = "utf-8"        // UTF-8 (BOM допускается)
+	EncodingWindows1251 = "windows-1251" // Windows-1251, типична для выгрузок российских банков
+)
+
+// Profile описывает сохранённый профиль сопоставления колонок CSV-выписки.
+type Profile struct {
+	ID               int            // Уникальный идентификатор профиля
+	UserID           int            // ID пользователя
+	Name             string         // Название профиля
+	Delimiter        string         // Разделитель полей
+	Encoding         string         // Кодировка файла
+	SkipRows         int            // Сколько строк пропустить в начале файла (заголовок, шапка выписки)
+	DateFormat       string         // Формат даты, например DD.MM.YYYY
+	DecimalSeparator string         // Десятичный разделитель: точка или запятая
+	SignConvention   string         // Соглашение о знаке суммы
+	Columns          map[string]int // Роль колонки → номер колонки (с 0)
+	CreatedAt        time.Time      // Дата создания
+	UpdatedAt        time.Time      // Дата обновления
+}
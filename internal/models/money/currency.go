@@ -0,0 +1,20 @@
+package money
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+
+// defaultMinorUnits — количество знаков после запятой для кодов, которых нет в справочнике валют.
+const defaultMinorUnits = 2
+
+// MinorUnits возвращает количество знаков после запятой для валюты (JPY — 0, BHD — 3, большинство — 2).
+// Для кодов вне справочника ISO 4217 (аккаунты, созданные до проверки кодов) используется 2.
+func MinorUnits(code string) int32 {
+	if c, ok := currency.Lookup(currency.Normalize(code)); ok {
+		return c.MinorUnits
+	}
+	return defaultMinorUnits
+}
+
+// FitsCurrency сообщает, представима ли сумма в валюте без округления.
+func FitsCurrency(amount Decimal, currency string) bool {
+	return amount.DecimalPlaces() <= MinorUnits(currency)
+}
@@ -0,0 +1,369 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrDivisionByZero возвращается при делении на ноль.
+var ErrDivisionByZero = errors.New("money: division by zero")
+
+const (
+	// MaxIntegerDigits — максимальное количество цифр целой части разбираемого числа:
+	// столько помещается и в суммы NUMERIC(22,4), и в курсы NUMERIC(30,12).
+	MaxIntegerDigits = 18
+	// MaxScale — максимальное количество значащих знаков после запятой разбираемого числа (как у NUMERIC(30,12)).
+	MaxScale = 12
+	// maxInputDigits — максимальное количество цифр в записи числа (включая незначащие нули) и модуль экспоненты.
+	maxInputDigits = 64
+)
+
+var (
+	bigZero = big.NewInt(0)
+	bigTen  = big.NewInt(10)
+)
+
+// Decimal — точное десятичное число вида coef * 10^exp без потерь при округлении.
+// Нулевое значение Decimal равно нулю. Значения неизменяемы: все операции возвращают новое число.
+// В JSON сериализуется строкой, из БД читается напрямую из NUMERIC.
+type Decimal struct {
+	coef *big.Int // Мантисса (nil означает ноль)
+	exp  int32    // Десятичный порядок
+}
+
+// Zero — нулевое значение.
+var Zero = Decimal{}
+
+// New создает число coef * 10^exp.
+func New(coef int64, exp int32) Decimal {
+	return Decimal{coef: big.NewInt(coef), exp: exp}
+}
+
+// NewFromInt создает целое число.
+func NewFromInt(v int64) Decimal {
+	return New(v, 0)
+}
+
+// Parse разбирает десятичную строку вида "-123.45" (допускается экспонента: "1.5e3").
+// Принимаются только числа, которые помещаются в колонки NUMERIC(22,4) и NUMERIC(30,12):
+// не больше MaxIntegerDigits цифр в целой части и MaxScale значащих знаков после запятой.
+// Запись ограничена maxInputDigits цифрами и таким же порядком экспоненты, поэтому
+// арифметика над разобранным числом не может потребовать огромных степеней десяти.
+func Parse(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Zero, fmt.Errorf("money: invalid decimal %q", s)
+	}
+	var exp int64
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("money: invalid decimal %q", s)
+		}
+		if e < -maxInputDigits || e > maxInputDigits {
+			return Zero, fmt.Errorf("money: decimal %q is out of range", s)
+		}
+		exp = e
+		str = str[:i]
+	}
+	sign := ""
+	if str != "" && (str[0] == '-' || str[0] == '+') {
+		if str[0] == '-' {
+			sign = "-"
+		}
+		str = str[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	digits := intPart + fracPart
+	if digits == "" {
+		return Zero, fmt.Errorf("money: invalid decimal %q", s)
+	}
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Zero, fmt.Errorf("money: invalid decimal %q", s)
+		}
+	}
+	if len(digits) > maxInputDigits {
+		return Zero, fmt.Errorf("money: decimal %q is out of range", s)
+	}
+	exp -= int64(len(fracPart))
+	significant := strings.TrimLeft(digits, "0")
+	if significant == "" {
+		// Ноль: положительный порядок ничего не значит, а лишние нули в записи не нужны
+		return Decimal{coef: new(big.Int), exp: int32(min(exp, 0))}, nil
+	}
+	trailingZeros := len(significant) - len(strings.TrimRight(significant, "0"))
+	if int64(len(significant))+exp > MaxIntegerDigits || -(exp+int64(trailingZeros)) > MaxScale {
+		return Zero, fmt.Errorf("money: decimal %q is out of range", s)
+	}
+	coef, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("money: invalid decimal %q", s)
+	}
+	return Decimal{coef: coef, exp: int32(exp)}, nil
+}
+
+// MustParse разбирает строку и паникует при ошибке. Предназначен для констант в коде.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// int возвращает мантиссу, подставляя ноль вместо nil.
+func (d Decimal) int() *big.Int {
+	if d.coef == nil {
+		return bigZero
+	}
+	return d.coef
+}
+
+// IsSet сообщает, было ли значение задано явно (в том числе нулём), а не осталось нулевым значением типа.
+func (d Decimal) IsSet() bool {
+	return d.coef != nil
+}
+
+// Sign возвращает -1, 0 или 1 в зависимости от знака числа.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero сообщает, равно ли число нулю.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// IsNegative сообщает, меньше ли число нуля.
+func (d Decimal) IsNegative() bool {
+	return d.Sign() < 0
+}
+
+// IsPositive сообщает, больше ли число нуля.
+func (d Decimal) IsPositive() bool {
+	return d.Sign() > 0
+}
+
+// Neg возвращает число с противоположным знаком.
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.int()), exp: d.exp}
+}
+
+// Abs возвращает модуль числа.
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.int()), exp: d.exp}
+}
+
+// Add возвращает сумму d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, exp := align(d, other)
+	return Decimal{coef: a.Add(a, b), exp: exp}
+}
+
+// Sub возвращает разность d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, exp := align(d, other)
+	return Decimal{coef: a.Sub(a, b), exp: exp}
+}
+
+// Mul возвращает точное произведение d * other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.int(), other.int()), exp: d.exp + other.exp}
+}
+
+// Quo возвращает частное d / other, округлённое до places знаков после запятой (половина — от нуля).
+func (d Decimal) Quo(other Decimal, places int32) (Decimal, error) {
+	if other.IsZero() {
+		return Zero, ErrDivisionByZero
+	}
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(other.int())
+	shift := int64(d.exp) - int64(other.exp) + int64(places)
+	if shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	return Decimal{coef: divRound(num, den), exp: -places}, nil
+}
+
+// Round округляет число до places знаков после запятой (половина — от нуля).
+// Результат всегда имеет ровно places знаков, при необходимости дополняется нулями.
+func (d Decimal) Round(places int32) Decimal {
+	target := -places
+	switch {
+	case d.exp == target:
+		return Decimal{coef: new(big.Int).Set(d.int()), exp: target}
+	case d.exp > target:
+		return Decimal{coef: new(big.Int).Mul(d.int(), pow10(int64(d.exp-target))), exp: target}
+	default:
+		return Decimal{coef: divRound(d.int(), pow10(int64(target-d.exp))), exp: target}
+	}
+}
+
+// DecimalPlaces возвращает количество значащих знаков после запятой (без учёта хвостовых нулей).
+func (d Decimal) DecimalPlaces() int32 {
+	if d.exp >= 0 || d.IsZero() {
+		return 0
+	}
+	c := new(big.Int).Abs(d.int())
+	places := -d.exp
+	rem := new(big.Int)
+	for places > 0 {
+		q, r := new(big.Int).QuoRem(c, bigTen, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		c = q
+		places--
+	}
+	return places
+}
+
+// IntegerDigits возвращает количество цифр в целой части модуля числа (0 для чисел меньше единицы).
+func (d Decimal) IntegerDigits() int {
+	c := new(big.Int).Abs(d.int())
+	if d.exp > 0 {
+		c.Mul(c, pow10(int64(d.exp)))
+	} else if d.exp < 0 {
+		c.Quo(c, pow10(int64(-d.exp)))
+	}
+	if c.Sign() == 0 {
+		return 0
+	}
+	return len(c.String())
+}
+
+// Cmp сравнивает числа: -1, если d < other; 0, если равны; 1, если d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// Equal сообщает, равны ли числа по значению (1.50 == 1.5).
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// String возвращает число в обычной десятичной записи без экспоненты.
+func (d Decimal) String() string {
+	c := d.int()
+	digits := new(big.Int).Abs(c).String()
+	if d.exp > 0 {
+		digits += strings.Repeat("0", int(d.exp))
+	} else if d.exp < 0 {
+		places := int(-d.exp)
+		if len(digits) <= places {
+			digits = strings.Repeat("0", places-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+	}
+	if c.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// MarshalJSON сериализует число строкой, чтобы клиенты не теряли точность на float.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON принимает как строку ("10.50"), так и числовой литерал (10.50) без промежуточного float.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if len(data) > 2*maxInputDigits {
+		return fmt.Errorf("money: decimal %.20q... is out of range", data)
+	}
+	s := string(data)
+	if s == "null" {
+		*d = Zero
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalText возвращает текстовое представление числа.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText разбирает текстовое представление числа.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// ScanNumeric читает значение из pgx NUMERIC без преобразования через float.
+func (d *Decimal) ScanNumeric(v pgtype.Numeric) error {
+	if !v.Valid {
+		*d = Zero
+		return nil
+	}
+	if v.NaN || v.InfinityModifier != pgtype.Finite {
+		return errors.New("money: cannot scan NaN or infinity into Decimal")
+	}
+	*d = Decimal{coef: new(big.Int).Set(v.Int), exp: v.Exp}
+	return nil
+}
+
+// NumericValue возвращает значение для записи в pgx NUMERIC.
+func (d Decimal) NumericValue() (pgtype.Numeric, error) {
+	return pgtype.Numeric{Int: new(big.Int).Set(d.int()), Exp: d.exp, Valid: true}, nil
+}
+
+// align приводит два числа к общему (минимальному) порядку и возвращает копии мантисс.
+func align(a, b Decimal) (*big.Int, *big.Int, int32) {
+	x := new(big.Int).Set(a.int())
+	y := new(big.Int).Set(b.int())
+	switch {
+	case a.exp > b.exp:
+		x.Mul(x, pow10(int64(a.exp-b.exp)))
+		return x, y, b.exp
+	case b.exp > a.exp:
+		y.Mul(y, pow10(int64(b.exp-a.exp)))
+		return x, y, a.exp
+	default:
+		return x, y, a.exp
+	}
+}
+
+// pow10 возвращает 10^n.
+func pow10(n int64) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(n), nil)
+}
+
+// divRound делит num на den с округлением половины от нуля.
+func divRound(num, den *big.Int) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	twiceR := new(big.Int).Abs(r)
+	twiceR.Lsh(twiceR, 1)
+	if twiceR.Cmp(new(big.Int).Abs(den)) >= 0 {
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
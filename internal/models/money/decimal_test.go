@@ -0,0 +1,313 @@
+package money
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "0", want: "0"},
+		{in: "-123.45", want: "-123.45"},
+		{in: "+10.50", want: "10.50"},
+		{in: " 7 ", want: "7"},
+		{in: ".5", want: "0.5"},
+		{in: "5.", want: "5"},
+		{in: "1.5e3", want: "1500"},
+		{in: "1.5E-3", want: "0.0015"},
+		{in: "0e100000000", wantErr: true},
+		{in: "0e-50", want: "0.00000000000000000000000000000000000000000000000000"},
+		{in: "0e50", want: "0"},
+		{in: "999999999999999999.999999999999", want: "999999999999999999.999999999999"},
+		{in: "1.500000000000000000000", want: "1.500000000000000000000"},
+		{in: "0.000000000001", want: "0.000000000001"},
+		{in: "0.0000000000001", wantErr: true},
+		{in: "1000000000000000000", wantErr: true},
+		{in: "1e18", wantErr: true},
+		{in: "1e17", want: "100000000000000000"},
+		{in: "1e100000000", wantErr: true},
+		{in: "1e2000000000", wantErr: true},
+		{in: "1e-2000000000", wantErr: true},
+		{in: "1e99999999999", wantErr: true},
+		{in: "1" + strings.Repeat("0", 100) + "e-100", wantErr: true},
+		{in: "", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "1,5", wantErr: true},
+		{in: "1.2.3", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: "1e", wantErr: true},
+		{in: "--1", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Parse(%q) = %s, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Parse(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestParseHugeExponentIsFast проверяет, что числа с огромной экспонентой отклоняются сразу,
+// а не после вычисления степеней десяти.
+func TestParseHugeExponentIsFast(t *testing.T) {
+	start := time.Now()
+	for _, in := range []string{"1e100000000", "1e2000000000", "-9.9e2147483647"} {
+		if d, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %s, want error", in, d)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Parse took %s", elapsed)
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		in     string
+		places int32
+		want   string
+	}{
+		{in: "1.005", places: 2, want: "1.01"},
+		{in: "1.004", places: 2, want: "1.00"},
+		{in: "-1.005", places: 2, want: "-1.01"},
+		{in: "-1.004", places: 2, want: "-1.00"},
+		{in: "2.5", places: 0, want: "3"},
+		{in: "-2.5", places: 0, want: "-3"},
+		{in: "1.5", places: 4, want: "1.5000"},
+		{in: "15e2", places: 2, want: "1500.00"},
+		{in: "0", places: 2, want: "0.00"},
+		{in: "123.456", places: 3, want: "123.456"},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.in).Round(tt.places).String(); got != tt.want {
+			t.Errorf("Round(%s, %d) = %s, want %s", tt.in, tt.places, got, tt.want)
+		}
+	}
+	if got := Zero.Round(2).String(); got != "0.00" {
+		t.Errorf("Zero.Round(2) = %s, want 0.00", got)
+	}
+}
+
+func TestQuo(t *testing.T) {
+	tests := []struct {
+		a, b   string
+		places int32
+		want   string
+	}{
+		{a: "10", b: "3", places: 2, want: "3.33"},
+		{a: "20", b: "3", places: 2, want: "6.67"},
+		{a: "-20", b: "3", places: 2, want: "-6.67"},
+		{a: "20", b: "-3", places: 2, want: "-6.67"},
+		{a: "1", b: "8", places: 2, want: "0.13"},
+		{a: "1", b: "90.5", places: 12, want: "0.011049723757"},
+		{a: "1.5", b: "0.5", places: 0, want: "3"},
+		{a: "100", b: "0.001", places: 2, want: "100000.00"},
+	}
+	for _, tt := range tests {
+		got, err := MustParse(tt.a).Quo(MustParse(tt.b), tt.places)
+		if err != nil {
+			t.Errorf("Quo(%s, %s) error: %v", tt.a, tt.b, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("Quo(%s, %s, %d) = %s, want %s", tt.a, tt.b, tt.places, got, tt.want)
+		}
+	}
+	if _, err := MustParse("1").Quo(Zero, 2); err != ErrDivisionByZero {
+		t.Errorf("Quo by zero error = %v, want ErrDivisionByZero", err)
+	}
+	if _, err := MustParse("1").Quo(MustParse("0.00"), 2); err != ErrDivisionByZero {
+		t.Errorf("Quo by 0.00 error = %v, want ErrDivisionByZero", err)
+	}
+}
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		num, den, want int64
+	}{
+		{num: 7, den: 2, want: 4},
+		{num: -7, den: 2, want: -4},
+		{num: 7, den: -2, want: -4},
+		{num: -7, den: -2, want: 4},
+		{num: 5, den: 3, want: 2},
+		{num: 4, den: 3, want: 1},
+		{num: -4, den: 3, want: -1},
+		{num: 6, den: 3, want: 2},
+		{num: 0, den: 5, want: 0},
+	}
+	for _, tt := range tests {
+		got := divRound(big.NewInt(tt.num), big.NewInt(tt.den))
+		if got.Int64() != tt.want {
+			t.Errorf("divRound(%d, %d) = %s, want %d", tt.num, tt.den, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalPlaces(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int32
+	}{
+		{in: "0", want: 0},
+		{in: "0.000", want: 0},
+		{in: "12", want: 0},
+		{in: "1.5e3", want: 0},
+		{in: "1.50", want: 1},
+		{in: "1.05", want: 2},
+		{in: "-0.001", want: 3},
+		{in: "1.5e-3", want: 4},
+		{in: "100.100", want: 1},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.in).DecimalPlaces(); got != tt.want {
+			t.Errorf("DecimalPlaces(%s) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIntegerDigits(t *testing.T) {
+	tests := map[string]int{
+		"0":                    0,
+		"0.99":                 0,
+		"1":                    1,
+		"-99.5":                2,
+		"15e2":                 4,
+		"100000000000000000":   18,
+		"999999999999999999.9": 18,
+	}
+	for in, want := range tests {
+		if got := MustParse(in).IntegerDigits(); got != want {
+			t.Errorf("IntegerDigits(%s) = %d, want %d", in, got, want)
+		}
+	}
+	if got := MustParse("1e17").Mul(MustParse("1000")).IntegerDigits(); got != 21 {
+		t.Errorf("IntegerDigits(1e20) = %d, want 21", got)
+	}
+}
+
+func TestCmp(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.50", b: "1.5", want: 0},
+		{a: "1.5e1", b: "15.00", want: 0},
+		{a: "-1", b: "0", want: -1},
+		{a: "0.01", b: "0.001", want: 1},
+	}
+	for _, tt := range tests {
+		if got := MustParse(tt.a).Cmp(MustParse(tt.b)); got != tt.want {
+			t.Errorf("Cmp(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+	if !Zero.Equal(MustParse("0.00")) {
+		t.Error("Zero should equal 0.00")
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Amount Decimal  `json:"amount"`
+		Limit  *Decimal `json:"limit"`
+	}
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: `{"amount":"10.50"}`, want: `{"amount":"10.50","limit":null}`},
+		{in: `{"amount":10.50}`, want: `{"amount":"10.50","limit":null}`},
+		{in: `{"amount":-0.1,"limit":"1e3"}`, want: `{"amount":"-0.1","limit":"1000"}`},
+		{in: `{"amount":null}`, want: `{"amount":"0","limit":null}`},
+		{in: `{"amount":"1e100000000"}`, wantErr: true},
+		{in: `{"amount":1e2000000000}`, wantErr: true},
+		{in: `{"amount":"` + strings.Repeat("9", 1000) + `"}`, wantErr: true},
+		{in: `{"amount":"abc"}`, wantErr: true},
+		{in: `{"amount":true}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var p payload
+		err := json.Unmarshal([]byte(tt.in), &p)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Unmarshal(%s) = %+v, want error", tt.in, p)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tt.in, err)
+			continue
+		}
+		out, err := json.Marshal(p)
+		if err != nil {
+			t.Errorf("Marshal(%+v) error: %v", p, err)
+			continue
+		}
+		if string(out) != tt.want {
+			t.Errorf("round-trip %s = %s, want %s", tt.in, out, tt.want)
+		}
+	}
+}
+
+func TestText(t *testing.T) {
+	var d Decimal
+	if err := d.UnmarshalText([]byte("-42.10")); err != nil {
+		t.Fatalf("UnmarshalText error: %v", err)
+	}
+	text, _ := d.MarshalText()
+	if string(text) != "-42.10" {
+		t.Errorf("MarshalText = %s, want -42.10", text)
+	}
+	if err := d.UnmarshalText([]byte("1e100000000")); err == nil {
+		t.Error("UnmarshalText(1e100000000) should fail")
+	}
+}
+
+func TestNumericRoundTrip(t *testing.T) {
+	m := pgtype.NewMap()
+	for _, in := range []string{"0", "0.00", "-123.4500", "999999999999999999.9999", "0.000000000001", "15e2"} {
+		d := MustParse(in)
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			buf, err := m.Encode(pgtype.NumericOID, format, d, nil)
+			if err != nil {
+				t.Errorf("Encode(%s, %d) error: %v", in, format, err)
+				continue
+			}
+			var got Decimal
+			if err := m.Scan(pgtype.NumericOID, format, buf, &got); err != nil {
+				t.Errorf("Scan(%s, %d) error: %v", in, format, err)
+				continue
+			}
+			if !got.Equal(d) {
+				t.Errorf("round-trip %s (format %d) = %s", in, format, got)
+			}
+		}
+	}
+
+	var got Decimal
+	if err := got.ScanNumeric(pgtype.Numeric{}); err != nil || got.IsSet() {
+		t.Errorf("ScanNumeric(NULL) = %s, %v; want unset zero", got, err)
+	}
+	if err := got.ScanNumeric(pgtype.Numeric{NaN: true, Valid: true}); err == nil {
+		t.Error("ScanNumeric(NaN) should fail")
+	}
+}
@@ -0,0 +1,86 @@
+package networth
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Шаг ряда истории капитала.
+const (
+	GranularityDay   = "day"   // Значение на каждый день
+	GranularityWeek  = "week"  // Значение на конец каждой недели (воскресенье)
+	GranularityMonth = "month" // Значение на последний день каждого месяца
+)
+
+// Snapshot описывает баланс аккаунта на конец дня.
+type Snapshot struct {
+	AccountID int           // ID аккаунта
+	UserID    int           // ID пользователя
+	Date      time.Time     // Дата снимка
+	Balance   money.Decimal `swaggertype:"string"` // Баланс в валюте аккаунта
+	Currency  string        // Валюта аккаунта на дату снимка
+	Liability bool          // Аккаунт — обязательство (по текущему типу аккаунта)
+}
+
+// Point описывает капитал на одну дату ряда.
+type Point struct {
+	Date         time.Time     // Дата точки
+	Total        money.Decimal `swaggertype:"string"` // Капитал: активы минус обязательства
+	Assets       money.Decimal `swaggertype:"string"` // Сумма балансов аккаунтов-активов
+	Liabilities  money.Decimal `swaggertype:"string"` // Долг по аккаунтам-обязательствам
+	MissingRates []string      // Валюты, для которых на эту дату нет курса; их аккаунты не вошли в Total
+}
+
+// Series описывает историю капитала пользователя за период.
+type Series struct {
+	Currency    string    // Валюта ряда (домашняя валюта пользователя)
+	From        time.Time // Начало периода
+	To          time.Time // Конец периода
+	Granularity string    // Шаг ряда
+	Points      []Point   // Точки ряда по возрастанию даты
+}
+
+// PointCount возвращает количество точек ряда за период [from, to] с шагом granularity — len(Dates(...)),
+// но без построения самих дат: так слишком длинный период отклоняется до выделения памяти под ряд.
+func PointCount(from, to time.Time, granularity string) int {
+	if from.After(to) {
+		return 0
+	}
+	days := int((to.Unix() - from.Unix()) / (24 * 60 * 60))
+	switch granularity {
+	case GranularityWeek:
+		// Концы недель — воскресенья периода, плюс сам to, если он не воскресенье
+		firstSunday := (7 - int(from.Weekday())) % 7
+		if firstSunday > days {
+			return 1
+		}
+		count := (days-firstSunday)/7 + 1
+		if to.Weekday() != time.Sunday {
+			count++
+		}
+		return count
+	case GranularityMonth:
+		return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month()) + 1
+	}
+	return days + 1
+}
+
+// Dates возвращает даты точек ряда за период [from, to] с шагом granularity: конец каждого дня,
+// недели или месяца, пересекающего период. Последняя точка всегда приходится на to.
+func Dates(from, to time.Time, granularity string) []time.Time {
+	var dates []time.Time
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		switch granularity {
+		case GranularityWeek:
+			d = d.AddDate(0, 0, (7-int(d.Weekday()))%7)
+		case GranularityMonth:
+			d = time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		}
+		if d.After(to) {
+			d = to
+		}
+		dates = append(dates, d)
+	}
+	return dates
+}
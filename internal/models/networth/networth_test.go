@@ -0,0 +1,28 @@
+package networth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPointCount(t *testing.T) {
+	start := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 40; i++ {
+		for j := i; j < i+120; j++ {
+			from, to := start.AddDate(0, 0, i), start.AddDate(0, 0, j)
+			for _, g := range []string{GranularityDay, GranularityWeek, GranularityMonth} {
+				if got, want := PointCount(from, to, g), len(Dates(from, to, g)); got != want {
+					t.Fatalf("PointCount(%s, %s, %s) = %d, want %d", from.Format(time.DateOnly), to.Format(time.DateOnly), g, got, want)
+				}
+			}
+		}
+	}
+
+	from, to := time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	if got := PointCount(from, to, GranularityDay); got != 738_946 {
+		t.Errorf("PointCount from year 1 = %d, want 738946", got)
+	}
+	if got := PointCount(to, from, GranularityDay); got != 0 {
+		t.Errorf("PointCount of reversed period = %d, want 0", got)
+	}
+}
@@ -0,0 +1,33 @@
+package rate
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Scale — количество знаков после запятой в курсах валют.
+const Scale = 12
+
+// Rate описывает курс валюты за день: 1 единица Base стоит Rate единиц Quote.
+// Курсы общие для всех пользователей и загружаются провайдерами.
+type Rate struct {
+	Base      string        // Валюта, курс которой указан
+	Quote     string        // Валюта, в которой указан курс
+	Date      time.Time     // Дата курса
+	Rate      money.Decimal `swaggertype:"string"` // Стоимость 1 единицы Base в Quote
+	Source    string        // Провайдер, загрузивший курс
+	UpdatedAt time.Time     // Дата загрузки
+}
+
+// Conversion описывает пересчёт суммы из одной валюты в другую.
+type Conversion struct {
+	From     string        // Исходная валюта
+	To       string        // Целевая валюта
+	Date     time.Time     // Дата, на которую выполнен пересчёт
+	Amount   money.Decimal `swaggertype:"string"` // Исходная сумма
+	Result   money.Decimal `swaggertype:"string"` // Сумма в целевой валюте с точностью её валюты
+	Rate     money.Decimal `swaggertype:"string"` // Применённый курс: 1 единица From в To
+	RateDate time.Time     // Дата самого старого из использованных курсов (курс берётся на последний день до Date)
+	Path     []string      // Валюты пересчёта: [From, To] — прямой или обратный курс, [From, база, To] — кросс-курс
+}
@@ -0,0 +1,211 @@
+package recurrence
+
+import (
+	"slices"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+// Частота повторения.
+const (
+	FrequencyDaily   = "daily"   // Каждые Interval дней
+	FrequencyWeekly  = "weekly"  // Каждые Interval недель в день недели даты начала
+	FrequencyMonthly = "monthly" // Каждые Interval месяцев в день MonthDay
+	FrequencyYearly  = "yearly"  // Каждые Interval лет в месяц даты начала и день MonthDay
+)
+
+// Перенос даты, выпавшей на выходной (субботу или воскресенье). Праздники не учитываются.
+const (
+	BusinessDayNone     = "none"     // Не переносить
+	BusinessDayPrevious = "previous" // На предыдущий рабочий день
+	BusinessDayNext     = "next"     // На следующий рабочий день
+)
+
+// Режимы создания операций.
+const (
+	ModeAuto    = "auto"    // Операция создаётся автоматически в день платежа
+	ModeSuggest = "suggest" // Создаётся предложение, которое пользователь подтверждает или пропускает
+)
+
+// Статусы платежей по расписанию.
+const (
+	StatusPending = "pending" // Ожидает подтверждения
+	StatusPosted  = "posted"  // Операция создана
+	StatusSkipped = "skipped" // Пропущен пользователем
+)
+
+// LastDay — значение MonthDay, означающее последний день месяца.
+const LastDay = -1
+
+// Recurrence описывает регулярную операцию: зарплату, аренду, подписку.
+// Даты платежей строятся от StartDate; «последний рабочий день месяца» — это MonthDay = -1
+// с переносом BusinessDayPrevious.
+type Recurrence struct {
+	ID          int           // Уникальный идентификатор
+	UserID      int           // ID пользователя
+	AccountID   int           // ID банковского аккаунта
+	Type        string        // Тип операции: income или expense
+	Amount      money.Decimal `swaggertype:"string"` // Положительная сумма операции
+	Currency    string        // Валюта (валюта аккаунта)
+	Payee       string        // Контрагент
+	Note        string        // Комментарий
+	CategoryID  *int          // ID категории (nil — без категории)
+	Tags        []string      // Теги
+	Frequency   string        // Частота: daily, weekly, monthly или yearly
+	Interval    int           // Шаг повторения в единицах частоты
+	MonthDay    int           // День месяца для monthly и yearly: 1-31, -1 — последний день, 0 — день даты начала
+	BusinessDay string        // Перенос с выходных: none, previous или next
+	StartDate   time.Time     // Дата начала
+	EndDate     *time.Time    // Дата окончания включительно (nil — бессрочно)
+	Mode        string        // Режим: auto или suggest
+	Active      bool          // Расписание включено
+	NextDate    *time.Time    // Плановая дата следующего платежа (nil — расписание завершено)
+	NextDue     *time.Time    // Дата следующего платежа с учётом переноса с выходных
+	CreatedAt   time.Time     // Дата создания
+	UpdatedAt   time.Time     // Дата обновления
+}
+
+// Occurrence описывает платёж по расписанию: созданную операцию или предложение.
+type Occurrence struct {
+	ID            int       // Уникальный идентификатор
+	RecurrenceID  int       // ID регулярной операции
+	UserID        int       // ID пользователя
+	Date          time.Time // Плановая дата по расписанию
+	DueDate       time.Time // Дата платежа с учётом переноса с выходных
+	Status        string    // Статус: pending, posted или skipped
+	TransactionID *int      // ID созданной операции
+	CreatedAt     time.Time // Дата создания
+	UpdatedAt     time.Time // Дата обновления
+}
+
+// Planned описывает предстоящий платёж по расписанию.
+type Planned struct {
+	Date    time.Time // Плановая дата по расписанию
+	DueDate time.Time // Дата платежа с учётом переноса с выходных
+}
+
+// First возвращает плановую дату первого платежа — не раньше StartDate.
+func (r Recurrence) First() time.Time {
+	switch r.Frequency {
+	case FrequencyMonthly, FrequencyYearly:
+		first := r.dayOf(r.StartDate.Year(), r.StartDate.Month())
+		if first.Before(r.StartDate) {
+			first = r.Next(first)
+		}
+		return first
+	default:
+		return r.StartDate
+	}
+}
+
+// Next возвращает плановую дату платежа, следующего за плановой датой date.
+func (r Recurrence) Next(date time.Time) time.Time {
+	switch r.Frequency {
+	case FrequencyDaily:
+		return date.AddDate(0, 0, r.Interval)
+	case FrequencyWeekly:
+		return date.AddDate(0, 0, 7*r.Interval)
+	case FrequencyYearly:
+		month := time.Date(date.Year(), date.Month()+time.Month(12*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		return r.dayOf(month.Year(), month.Month())
+	default:
+		month := time.Date(date.Year(), date.Month()+time.Month(r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		return r.dayOf(month.Year(), month.Month())
+	}
+}
+
+// After возвращает плановую дату первого платежа позже date или nil, если расписание к этому моменту завершено.
+func (r Recurrence) After(date time.Time) *time.Time {
+	next := r.First()
+	for !next.After(date) {
+		next = r.Next(next)
+	}
+	if !r.Within(next) {
+		return nil
+	}
+	return &next
+}
+
+// Schedule устанавливает NextDate и NextDue на первый платёж позже after (nil — первый платёж расписания).
+func (r *Recurrence) Schedule(after *time.Time) {
+	var next *time.Time
+	if after == nil {
+		if first := r.First(); r.Within(first) {
+			next = &first
+		}
+	} else {
+		next = r.After(*after)
+	}
+	r.NextDate, r.NextDue = next, nil
+	if next != nil {
+		due := r.Due(*next)
+		r.NextDue = &due
+	}
+}
+
+// Within сообщает, не выходит ли плановая дата за дату окончания расписания.
+func (r Recurrence) Within(date time.Time) bool {
+	return r.EndDate == nil || !date.After(*r.EndDate)
+}
+
+// Due возвращает дату платежа для плановой даты с учётом переноса с выходных.
+func (r Recurrence) Due(date time.Time) time.Time {
+	step := 0
+	switch r.BusinessDay {
+	case BusinessDayPrevious:
+		step = -1
+	case BusinessDayNext:
+		step = 1
+	}
+	for step != 0 && (date.Weekday() == time.Saturday || date.Weekday() == time.Sunday) {
+		date = date.AddDate(0, 0, step)
+	}
+	return date
+}
+
+// Upcoming возвращает до n предстоящих платежей, начиная с NextDate.
+func (r Recurrence) Upcoming(n int) []Planned {
+	planned := []Planned{}
+	if r.NextDate == nil {
+		return planned
+	}
+	for date := *r.NextDate; len(planned) < n && r.Within(date); date = r.Next(date) {
+		planned = append(planned, Planned{Date: date, DueDate: r.Due(date)})
+	}
+	return planned
+}
+
+// Transaction возвращает операцию платежа с датой date. Сумма получает знак по типу операции.
+func (r Recurrence) Transaction(date time.Time) transaction.Transaction {
+	amount := r.Amount
+	if r.Type == transaction.TypeExpense {
+		amount = amount.Neg()
+	}
+	return transaction.Transaction{
+		UserID:     r.UserID,
+		AccountID:  r.AccountID,
+		Type:       r.Type,
+		Amount:     amount,
+		Date:       date,
+		Payee:      r.Payee,
+		Note:       r.Note,
+		CategoryID: r.CategoryID,
+		Tags:       slices.Clone(r.Tags),
+	}
+}
+
+// dayOf возвращает дату платежа в заданном месяце. Если в месяце нет дня MonthDay (31 февраля),
+// берётся последний день месяца.
+func (r Recurrence) dayOf(year int, month time.Month) time.Time {
+	last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	day := r.MonthDay
+	if day == 0 {
+		day = r.StartDate.Day()
+	}
+	if day == LastDay || day > last {
+		day = last
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -1,8 +1,16 @@
 package request
 
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+
 // BankAccountRequest описывает структуру запроса для создания/обновления банковского аккаунта.
 type BankAccountRequest struct {
-	Name     string  `json:"name" binding:"required"`
-	Balance  float64 `json:"balance" binding:"required"`
-	Currency string  `json:"currency" binding:"required"`
+	Name         string         `json:"name" binding:"required"`
+	Balance      money.Decimal  `json:"balance" swaggertype:"string" example:"1500.50"` // Строка или число, без потерь точности
+	Currency     string         `json:"currency" binding:"required"`
+	IBAN         string         `json:"iban" example:"DE89 3704 0044 0532 0130 00"`            // Необязательно; пробелы допускаются
+	BIC          string         `json:"bic" example:"COBADEFFXXX"`                             // Необязательно
+	Type         string         `json:"type" example:"credit_card"`                            // checking, cash, credit_card, savings, loan, investment; по умолчанию checking
+	CreditLimit  *money.Decimal `json:"credit_limit" swaggertype:"string" example:"100000.00"` // Кредитный лимит (только credit_card)
+	StatementDay *int           `json:"statement_day" example:"25"`                            // День формирования выписки, 1–31 (только credit_card)
+	InterestRate *money.Decimal `json:"interest_rate" swaggertype:"string" example:"12.5"`     // Годовая ставка в процентах (только savings и loan)
 }
@@ -0,0 +1,18 @@
+package request
+
+// BankAccountListQuery описывает query-параметры запроса списка банковских аккаунтов.
+type BankAccountListQuery struct {
+	Currency        string `form:"currency"`                                // Фильтр по валюте
+	Type            string `form:"type"`                                    // Фильтр по типу аккаунта
+	Name            string `form:"name"`                                    // Фильтр по подстроке названия
+	IncludeArchived bool   `form:"include_archived"`                        // Включать архивные аккаунты
+	InvalidCurrency bool   `form:"invalid_currency"`                        // Только аккаунты с кодом валюты вне справочника ISO 4217
+	Sort            string `form:"sort"`                                    // Поле сортировки: created_at, name, balance; префикс "-" — по убыванию
+	Limit           int    `form:"limit" binding:"omitempty,min=1,max=100"` // Размер страницы (по умолчанию 20)
+	Cursor          string `form:"cursor"`                                  // Курсор следующей страницы
+}
+
+// AccountTotalsQuery описывает query-параметры запроса суммы балансов в одной валюте.
+type AccountTotalsQuery struct {
+	Currency string `form:"currency"` // Валюта итога (по умолчанию — домашняя валюта пользователя)
+}
@@ -2,17 +2,51 @@ package request
 
 // RegisterRequest описывает структуру запроса для регистрации.
 type RegisterRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email        string `json:"email" binding:"required,email"`
+	Password     string `json:"password" binding:"required"`
+	HomeCurrency string `json:"home_currency" example:"RUB"` // Домашняя валюта (код ISO 4217, по умолчанию RUB)
+}
+
+// ProfileUpdateRequest описывает структуру запроса для изменения профиля.
+type ProfileUpdateRequest struct {
+	HomeCurrency string  `json:"home_currency" example:"EUR"` // Домашняя валюта (код ISO 4217); пусто — не менять
+	Language     *string `json:"language" example:"en"`       // Язык сообщений API: ru, en; "" — по Accept-Language, не передан — не менять
 }
 
 // LoginRequest описывает структуру запроса для логина.
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email"`
-	Password string `json:"password" binding:"required"`
+	Email       string `json:"email" binding:"required,email"`
+	Password    string `json:"password" binding:"required"`
+	DeviceLabel string `json:"device_label" binding:"max=255"` // Необязательное название устройства для списка сессий
 }
 
 // LogoutRequest описывает структуру запроса для логаута.
 type LogoutRequest struct {
 	RefreshToken string `json:"refresh_token" binding:"required"`
 }
+
+// RefreshRequest описывает структуру запроса для обновления токенов.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// ForgotPasswordRequest описывает структуру запроса ссылки для сброса пароля.
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ResetPasswordRequest описывает структуру запроса установки нового пароля по токену из письма.
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`    // Токен из ссылки в письме
+	Password string `json:"password" binding:"required"` // Новый пароль
+}
+
+// VerifyEmailRequest описывает структуру запроса подтверждения email по токену из письма.
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"` // Токен из ссылки в письме
+}
+
+// ResendVerificationRequest описывает структуру запроса повторной отправки письма для подтверждения email.
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
@@ -0,0 +1,24 @@
+package request
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+
+// BudgetRequest описывает структуру запроса для создания/обновления бюджета.
+type BudgetRequest struct {
+	Name      string               `json:"name" binding:"required,max=100" example:"Расходы на жизнь"`
+	Period    string               `json:"period" binding:"required,oneof=week month quarter year" example:"month"` // Период бюджета
+	Currency  string               `json:"currency" binding:"required" example:"RUB"`                               // Валюта бюджета
+	StartDate string               `json:"start_date" binding:"required" example:"2024-05-01"`                      // Начало первого периода (YYYY-MM-DD)
+	Rollover  bool                 `json:"rollover"`                                                                // Переносить неизрасходованный остаток
+	Limits    []BudgetLimitRequest `json:"limits" binding:"required,min=1,dive"`                                    // Лимиты по категориям расходов
+}
+
+// BudgetLimitRequest описывает лимит по категории в запросе бюджета.
+type BudgetLimitRequest struct {
+	CategoryID int           `json:"category_id" binding:"required"`
+	Amount     money.Decimal `json:"amount" swaggertype:"string" example:"15000.00"` // Лимит на период
+}
+
+// BudgetProgressQuery описывает query-параметры запроса исполнения бюджета.
+type BudgetProgressQuery struct {
+	Date string `form:"date"` // Любая дата внутри нужного периода (YYYY-MM-DD), по умолчанию — сегодня
+}
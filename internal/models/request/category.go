@@ -0,0 +1,19 @@
+package request
+
+// CategoryRequest описывает структуру запроса для создания/обновления категории.
+// При обновлении вид категории не меняется и поле kind игнорируется.
+type CategoryRequest struct {
+	Name     string `json:"name" binding:"required,max=100" example:"Продукты"`
+	Kind     string `json:"kind" binding:"omitempty,oneof=income expense" example:"expense"` // income — доходы, expense — расходы
+	ParentID *int   `json:"parent_id"`                                                       // ID родительской категории (null — верхний уровень)
+}
+
+// CategoryMergeRequest описывает структуру запроса для объединения категорий.
+type CategoryMergeRequest struct {
+	TargetID int `json:"target_id" binding:"required"` // ID категории, в которую переносятся операции и подкатегории
+}
+
+// CategoryListQuery описывает query-параметры запроса списка категорий.
+type CategoryListQuery struct {
+	Kind string `form:"kind" binding:"omitempty,oneof=income expense"` // Фильтр по виду категории
+}
@@ -0,0 +1,38 @@
+package request
+
+// ImportProfileRequest описывает структуру запроса для создания/обновления профиля импорта CSV.
+type ImportProfileRequest struct {
+	Name             string         `json:"name" binding:"required,max=100" example:"Выписка Сбербанка"`
+	Delimiter        string         `json:"delimiter" example:";"`                                                                  // Разделитель полей (по умолчанию запятая)
+	Encoding         string         `json:"encoding" example:"windows-1251"`                                                        // utf-8 (по умолчанию) или windows-1251
+	SkipRows         int            `json:"skip_rows" example:"1"`                                                                  // Сколько строк пропустить в начале файла
+	DateFormat       string         `json:"date_format" binding:"required" example:"DD.MM.YYYY"`                                    // Формат даты из токенов DD, MM, YYYY/YY
+	DecimalSeparator string         `json:"decimal_separator" example:","`                                                          // Точка (по умолчанию) или запятая
+	SignConvention   string         `json:"sign_convention" binding:"required,oneof=signed inverted debit_credit" example:"signed"` // Соглашение о знаке суммы
+	Columns          map[string]int `json:"columns" binding:"required"`                                                             // Роль → номер колонки с 0: date, amount, debit, credit, payee, note
+}
+
+// ImportOFXForm описывает поля multipart-формы загрузки выписки OFX/QFX (кроме файла).
+type ImportOFXForm struct {
+	AccountID int `form:"account_id" binding:"required"` // Аккаунт, по которому импортируется выписка
+}
+
+// ImportStatementForm описывает поля multipart-формы загрузки выписки camt.053 или MT940 (кроме файла).
+type ImportStatementForm struct {
+	AccountID int `form:"account_id"` // Необязательно: аккаунт для выписки по одному счёту, если счёт не найден по IBAN
+}
+
+// ImportQIFForm описывает поля multipart-формы загрузки выписки QIF (кроме файла).
+// QIF не задаёт формат даты и чисел, поэтому их можно указать явно.
+type ImportQIFForm struct {
+	AccountID        int    `form:"account_id" binding:"required"`                         // Аккаунт, по которому импортируется выписка
+	DateOrder        string `form:"date_order" binding:"omitempty,oneof=MDY DMY YMD"`      // Порядок частей даты (по умолчанию MDY)
+	DecimalSeparator string `form:"decimal_separator"`                                     // Десятичный разделитель (по умолчанию точка)
+	Encoding         string `form:"encoding" binding:"omitempty,oneof=utf-8 windows-1251"` // Кодировка файла (по умолчанию utf-8)
+}
+
+// ImportCSVForm описывает поля multipart-формы загрузки CSV-выписки (кроме файла).
+type ImportCSVForm struct {
+	AccountID int `form:"account_id" binding:"required"` // Аккаунт, по которому импортируется выписка
+	ProfileID int `form:"profile_id" binding:"required"` // Профиль сопоставления колонок
+}
@@ -0,0 +1,10 @@
+package request
+
+import "time"
+
+// NetWorthHistoryQuery описывает query-параметры запроса истории капитала.
+type NetWorthHistoryQuery struct {
+	From        time.Time `form:"from" time_format:"2006-01-02"`                        // Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад
+	To          time.Time `form:"to" time_format:"2006-01-02"`                          // Конец периода (YYYY-MM-DD), по умолчанию сегодня
+	Granularity string    `form:"granularity" binding:"omitempty,oneof=day week month"` // Шаг ряда: day, week, month (по умолчанию day)
+}
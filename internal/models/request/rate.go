@@ -0,0 +1,19 @@
+package request
+
+import "time"
+
+// RateListQuery описывает query-параметры запроса истории курса валютной пары.
+type RateListQuery struct {
+	Base  string    `form:"base" binding:"required"`       // Валюта, курс которой запрашивается
+	Quote string    `form:"quote" binding:"required"`      // Валюта, в которой указан курс
+	From  time.Time `form:"from" time_format:"2006-01-02"` // Начало периода (YYYY-MM-DD), по умолчанию 30 дней назад
+	To    time.Time `form:"to" time_format:"2006-01-02"`   // Конец периода (YYYY-MM-DD), по умолчанию сегодня
+}
+
+// ConvertQuery описывает query-параметры запроса пересчёта суммы между валютами.
+type ConvertQuery struct {
+	Amount string    `form:"amount" binding:"required"`     // Сумма в исходной валюте
+	From   string    `form:"from" binding:"required"`       // Исходная валюта
+	To     string    `form:"to" binding:"required"`         // Целевая валюта
+	Date   time.Time `form:"date" time_format:"2006-01-02"` // Дата курса (YYYY-MM-DD), по умолчанию сегодня
+}
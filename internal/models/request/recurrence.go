@@ -0,0 +1,39 @@
+package request
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+
+// RecurrenceRequest описывает структуру запроса для создания/обновления регулярной операции.
+type RecurrenceRequest struct {
+	AccountID   int           `json:"account_id" binding:"required"`
+	Type        string        `json:"type" binding:"required,oneof=income expense" example:"expense"`                   // income — поступление, expense — расход
+	Amount      money.Decimal `json:"amount" swaggertype:"string" example:"45000.00"`                                   // Положительная сумма операции
+	Payee       string        `json:"payee" binding:"max=255" example:"Арендодатель"`                                   // Контрагент
+	Note        string        `json:"note"`                                                                             // Комментарий
+	CategoryID  *int          `json:"category_id"`                                                                      // ID категории того же вида, что и операция
+	Tags        []string      `json:"tags"`                                                                             // Теги
+	Frequency   string        `json:"frequency" binding:"required,oneof=daily weekly monthly yearly" example:"monthly"` // Частота повторения
+	Interval    int           `json:"interval" binding:"omitempty,min=1,max=366" example:"1"`                           // Шаг повторения (по умолчанию 1)
+	MonthDay    int           `json:"month_day" binding:"min=-1,max=31" example:"5"`                                    // День месяца для monthly и yearly: -1 — последний, 0 — день даты начала
+	BusinessDay string        `json:"business_day" binding:"omitempty,oneof=none previous next" example:"previous"`     // Перенос с выходных (по умолчанию none)
+	StartDate   string        `json:"start_date" binding:"required" example:"2024-06-01"`                               // Дата начала (YYYY-MM-DD)
+	EndDate     string        `json:"end_date" example:"2025-05-31"`                                                    // Дата окончания включительно (YYYY-MM-DD), пусто — бессрочно
+	Mode        string        `json:"mode" binding:"required,oneof=auto suggest" example:"auto"`                        // auto — создавать операции, suggest — предлагать
+	Active      *bool         `json:"active"`                                                                           // Расписание включено (по умолчанию true)
+}
+
+// RecurrenceUpcomingQuery описывает query-параметры запроса предстоящих платежей.
+type RecurrenceUpcomingQuery struct {
+	Count int `form:"count" binding:"omitempty,min=1,max=100"` // Количество платежей (по умолчанию 10)
+}
+
+// OccurrenceListQuery описывает query-параметры запроса платежей по расписанию.
+type OccurrenceListQuery struct {
+	RecurrenceID int    `form:"recurrence_id"`                                           // Фильтр по регулярной операции
+	Status       string `form:"status" binding:"omitempty,oneof=pending posted skipped"` // Фильтр по статусу
+}
+
+// OccurrenceConfirmRequest описывает структуру запроса для подтверждения предложенного платежа.
+type OccurrenceConfirmRequest struct {
+	Amount money.Decimal `json:"amount" swaggertype:"string" example:"3120.50"` // Фактическая сумма (по умолчанию — из расписания)
+	Date   string        `json:"date" example:"2024-06-05"`                     // Фактическая дата (YYYY-MM-DD, по умолчанию — дата платежа)
+}
@@ -0,0 +1,33 @@
+package request
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+
+// RuleRequest описывает структуру запроса для создания/обновления правила категоризации.
+// Должно быть задано хотя бы одно условие и хотя бы одно действие.
+type RuleRequest struct {
+	Name         string        `json:"name" binding:"required,max=100" example:"Такси"`
+	Enabled      *bool         `json:"enabled"`                                               // Правило включено (по умолчанию true)
+	PayeePattern string        `json:"payee_pattern" example:"yandex.*taxi|uber"`             // Условие: регулярное выражение для контрагента
+	NotePattern  string        `json:"note_pattern"`                                          // Условие: регулярное выражение для комментария
+	MinAmount    money.Decimal `json:"min_amount" swaggertype:"string" example:"100.00"`      // Условие: минимальная сумма по модулю
+	MaxAmount    money.Decimal `json:"max_amount" swaggertype:"string" example:"5000.00"`     // Условие: максимальная сумма по модулю
+	AccountID    *int          `json:"account_id"`                                            // Условие: аккаунт операции
+	Type         string        `json:"type" binding:"omitempty,oneof=income expense"`         // Условие: тип операции
+	CategoryID   *int          `json:"category_id"`                                           // Действие: установить категорию
+	Tags         []string      `json:"tags" example:"транспорт"`                              // Действие: добавить теги
+	RenamePayee  string        `json:"rename_payee" binding:"max=255" example:"Яндекс Такси"` // Действие: заменить контрагента
+}
+
+// RuleOrderRequest описывает структуру запроса для изменения порядка применения правил.
+type RuleOrderRequest struct {
+	IDs []int `json:"ids" binding:"required"` // ID всех правил пользователя в новом порядке
+}
+
+// RuleApplyRequest описывает структуру запроса для повторного применения правил к истории операций.
+type RuleApplyRequest struct {
+	AccountID int    `json:"account_id"`                // Только операции этого аккаунта
+	From      string `json:"from" example:"2024-01-01"` // Начало периода (YYYY-MM-DD), по умолчанию — с начала истории
+	To        string `json:"to" example:"2024-12-31"`   // Конец периода (YYYY-MM-DD), по умолчанию — сегодня
+	Overwrite bool   `json:"overwrite"`                 // Заменять уже установленные категории
+	DryRun    *bool  `json:"dry_run"`                   // Только показать изменения, не сохраняя их (по умолчанию true)
+}
@@ -0,0 +1,43 @@
+package request
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// TransactionRequest описывает структуру запроса для создания/обновления операции.
+type TransactionRequest struct {
+	AccountID  int           `json:"account_id" binding:"required"`
+	Type       string        `json:"type" binding:"required,oneof=income expense"` // income — поступление, expense — расход
+	Amount     money.Decimal `json:"amount" swaggertype:"string" example:"250.00"` // Положительная сумма операции
+	Date       string        `json:"date" binding:"required" example:"2024-05-31"` // Дата в формате YYYY-MM-DD
+	Payee      string        `json:"payee" binding:"max=255"`                      // Контрагент
+	Note       string        `json:"note"`                                         // Комментарий
+	CategoryID *int          `json:"category_id"`                                  // ID категории того же вида, что и операция
+	Tags       []string      `json:"tags" example:"отпуск"`                        // Теги операции
+}
+
+// TransactionListQuery описывает query-параметры запроса списка операций.
+type TransactionListQuery struct {
+	AccountID  int       `form:"account_id"`                                                                        // Фильтр по аккаунту
+	Type       string    `form:"type" binding:"omitempty,oneof=income expense adjustment transfer_in transfer_out"` // Фильтр по типу операции
+	CategoryID int       `form:"category_id"`                                                                       // Фильтр по категории (включая подкатегории)
+	Tag        string    `form:"tag"`                                                                               // Фильтр по тегу
+	From       time.Time `form:"from" time_format:"2006-01-02"`                                                     // Начало периода (YYYY-MM-DD)
+	To         time.Time `form:"to" time_format:"2006-01-02"`                                                       // Конец периода (YYYY-MM-DD)
+	Limit      int       `form:"limit" binding:"omitempty,min=1,max=100"`                                           // Размер страницы (по умолчанию 50)
+	Cursor     string    `form:"cursor"`                                                                            // Курсор следующей страницы
+}
+
+// TransactionMergeRequest описывает структуру запроса для объединения операции с её дубликатом.
+type TransactionMergeRequest struct {
+	TargetID int `json:"target_id" binding:"required"` // ID операции, которая остаётся после объединения
+}
+
+// DuplicateListQuery описывает query-параметры поиска дубликатов операций.
+type DuplicateListQuery struct {
+	AccountID int       `form:"account_id"`                    // Фильтр по аккаунту
+	From      time.Time `form:"from" time_format:"2006-01-02"` // Начало периода (YYYY-MM-DD), по умолчанию 90 дней назад
+	To        time.Time `form:"to" time_format:"2006-01-02"`   // Конец периода (YYYY-MM-DD), по умолчанию сегодня
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+
+// TransferRequest описывает структуру запроса для перевода между своими аккаунтами.
+// Для аккаунтов в разных валютах нужно указать to_amount или rate.
+type TransferRequest struct {
+	FromAccountID int           `json:"from_account_id" binding:"required"`
+	ToAccountID   int           `json:"to_account_id" binding:"required"`
+	Amount        money.Decimal `json:"amount" swaggertype:"string" example:"100.00"`     // Сумма списания
+	ToAmount      money.Decimal `json:"to_amount" swaggertype:"string" example:"9150.00"` // Сумма зачисления (для разных валют)
+	Rate          money.Decimal `json:"rate" swaggertype:"string" example:"91.5"`         // Курс (для разных валют)
+	Date          string        `json:"date" binding:"required" example:"2024-05-31"`     // Дата в формате YYYY-MM-DD
+	Note          string        `json:"note"`                                             // Комментарий
+}
+
+// TransferListQuery описывает query-параметры запроса списка переводов.
+type TransferListQuery struct {
+	AccountID int    `form:"account_id"`                              // Фильтр по аккаунту списания или зачисления
+	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100"` // Размер страницы (по умолчанию 50)
+	Cursor    string `form:"cursor"`                                  // Курсор следующей страницы
+}
@@ -0,0 +1,9 @@
+package response
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+
+// BankAccountListResponse описывает страницу списка банковских аккаунтов.
+type BankAccountListResponse struct {
+	Items      []account.BankAccount `json:"items"`
+	NextCursor string                `json:"next_cursor,omitempty"` // Пусто, если страниц больше нет
+}
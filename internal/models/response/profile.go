@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// ProfileResponse описывает профиль текущего пользователя.
+type ProfileResponse struct {
+	ID              int        `json:"id"`
+	Email           string     `json:"email"`
+	HomeCurrency    string     `json:"home_currency"`     // Домашняя валюта: в ней по умолчанию считаются итоги по аккаунтам
+	Language        string     `json:"language"`          // Язык сообщений API; пусто — выбирается по Accept-Language
+	EmailVerifiedAt *time.Time `json:"email_verified_at"` // Время подтверждения email; null — email не подтверждён
+	CreatedAt       time.Time  `json:"created_at"`
+}
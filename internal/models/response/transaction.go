@@ -0,0 +1,9 @@
+package response
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+
+// TransactionListResponse описывает страницу списка операций.
+type TransactionListResponse struct {
+	Items      []transaction.Transaction `json:"items"`
+	NextCursor string                    `json:"next_cursor,omitempty"` // Пусто, если страниц больше нет
+}
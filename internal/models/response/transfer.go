@@ -0,0 +1,9 @@
+package response
+
+import "github.com/stepanpotapov/moneyflow-go-backend/internal/models/transfer"
+
+// TransferListResponse описывает страницу списка переводов.
+type TransferListResponse struct {
+	Items      []transfer.Transfer `json:"items"`
+	NextCursor string              `json:"next_cursor,omitempty"` // Пусто, если страниц больше нет
+}
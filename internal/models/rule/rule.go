@@ -0,0 +1,52 @@
+package rule
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Rule описывает правило автоматической категоризации операций.
+// Условия объединяются через «и», пустое условие не проверяется. Правила применяются по порядку Position:
+// категорию и нового контрагента задаёт первое подходящее правило, в котором они указаны, теги добавляются
+// из всех подходящих правил.
+type Rule struct {
+	ID           int            // Уникальный идентификатор правила
+	UserID       int            // ID пользователя
+	Position     int            // Порядковый номер (меньше — выше приоритет)
+	Name         string         // Название
+	Enabled      bool           // Правило включено
+	PayeePattern string         // Регулярное выражение для контрагента (без учёта регистра)
+	NotePattern  string         // Регулярное выражение для комментария (без учёта регистра)
+	MinAmount    *money.Decimal `swaggertype:"string"` // Минимальная сумма операции по модулю включительно
+	MaxAmount    *money.Decimal `swaggertype:"string"` // Максимальная сумма операции по модулю включительно
+	AccountID    *int           // Только операции этого аккаунта
+	Type         string         // Только операции этого типа: income или expense (пусто — любые)
+	CategoryID   *int           // Действие: установить категорию
+	Tags         []string       // Действие: добавить теги
+	RenamePayee  string         // Действие: заменить контрагента
+	CreatedAt    time.Time      // Дата создания
+	UpdatedAt    time.Time      // Дата обновления
+}
+
+// Snapshot — поля операции, которые изменяют правила.
+type Snapshot struct {
+	Payee      string   // Контрагент
+	CategoryID *int     // ID категории
+	Tags       []string // Теги
+}
+
+// Change описывает изменение одной операции при повторном применении правил.
+type Change struct {
+	TransactionID int      // ID операции
+	Before        Snapshot // Значения до применения правил
+	After         Snapshot // Значения после применения правил
+	RuleIDs       []int    // Сработавшие правила
+}
+
+// ApplyResult — результат повторного применения правил к истории операций.
+type ApplyResult struct {
+	DryRun  bool     // Изменения только рассчитаны и не сохранены
+	Checked int      // Количество проверенных операций
+	Changes []Change // Изменённые операции
+}
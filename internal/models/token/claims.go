@@ -0,0 +1,30 @@
+package token
+
+import "github.com/golang-jwt/jwt/v5"
+
+const (
+	TypeAccess  = "access"  // Тип access токена
+	TypeRefresh = "refresh" // Тип refresh токена
+
+	ScopeRead  = "read"  // Разрешение на чтение данных
+	ScopeWrite = "write" // Разрешение на изменение данных
+)
+
+// JWTConfig содержит параметры выпуска и проверки JWT токенов.
+type JWTConfig struct {
+	Secret   string // Секрет для подписи HS256
+	Issuer   string // Значение claim iss
+	Audience string // Значение claim aud
+}
+
+// Claims описывает содержимое JWT токенов, выпускаемых сервисом.
+type Claims struct {
+	UserID     int    `json:"user_id"`              // ID пользователя
+	Email      string `json:"email"`                // Email пользователя
+	SessionID  string `json:"sid,omitempty"`        // Идентификатор сессии
+	Scope      string `json:"scope,omitempty"`      // Разрешения через пробел
+	TokenType  string `json:"typ"`                  // Тип токена: access или refresh
+	Language   string `json:"lang,omitempty"`       // Язык из профиля пользователя (только access токен)
+	Unverified bool   `json:"unverified,omitempty"` // Email пользователя не подтверждён (только access токен)
+	jwt.RegisteredClaims
+}
@@ -4,9 +4,15 @@ import "time"
 
 // RefreshToken описывает refresh токен пользователя.
 type RefreshToken struct {
-	ID        int       // Уникальный идентификатор токена
-	UserID    int       // ID пользователя
-	Token     string    // Строка токена
-	ExpiresAt time.Time // Время истечения токена
-	CreatedAt time.Time // Время создания токена
+	ID          int        // Уникальный идентификатор токена
+	UserID      int        // ID пользователя
+	Token       string     // Строка токена
+	FamilyID    string     // Идентификатор семейства токенов (цепочки ротаций одного логина)
+	UserAgent   string     // User-Agent клиента, предъявившего токен
+	IP          string     // IP-адрес клиента, предъявившего токен
+	DeviceLabel string     // Название устройства, заданное пользователем при логине
+	ExpiresAt   time.Time  // Время истечения токена
+	CreatedAt   time.Time  // Время создания токена
+	LastUsedAt  time.Time  // Время последнего использования сессии
+	RotatedAt   *time.Time // Время ротации токена (nil, если токен ещё активен)
 }
@@ -0,0 +1,27 @@
+package token
+
+import "time"
+
+// Ограничения длины данных клиента, совпадающие с размерами колонок refresh_tokens.
+const (
+	MaxUserAgentLength = 512 // Длина User-Agent в символах
+	MaxIPLength        = 64  // Длина IP-адреса в символах
+)
+
+// ClientInfo описывает данные клиента, от имени которого выполняется вход или обновление токенов.
+type ClientInfo struct {
+	UserAgent   string // User-Agent клиента
+	IP          string // IP-адрес клиента
+	DeviceLabel string // Название устройства (задаётся клиентом при логине)
+}
+
+// Session описывает активную сессию пользователя (семейство refresh токенов одного логина).
+type Session struct {
+	ID          string    // Идентификатор сессии (совпадает с FamilyID refresh токенов)
+	UserAgent   string    // User-Agent последнего обращения
+	IP          string    // IP-адрес последнего обращения
+	DeviceLabel string    // Название устройства
+	CreatedAt   time.Time // Время входа
+	LastUsedAt  time.Time // Время последнего использования
+	ExpiresAt   time.Time // Время истечения текущего refresh токена
+}
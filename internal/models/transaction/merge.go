@@ -0,0 +1,19 @@
+package transaction
+
+import "time"
+
+// Merge — запись журнала объединения дубликатов: операция Merged удалена, а её данные перенесены в TransactionID.
+type Merge struct {
+	ID            int         // Уникальный идентификатор записи
+	UserID        int         // ID пользователя
+	TransactionID *int        // ID оставленной операции (nil, если она позже удалена)
+	Merged        Transaction // Удалённая операция в том виде, в каком она была до объединения
+	CreatedAt     time.Time   // Дата объединения
+}
+
+// Duplicate описывает пару операций, похожих на одну и ту же операцию банка.
+type Duplicate struct {
+	Transaction Transaction // Более ранняя по id операция
+	Duplicate   Transaction // Вероятный дубликат
+	Score       float64     // Оценка сходства от 0 до 1
+}
@@ -0,0 +1,51 @@
+package transaction
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Типы операций.
+const (
+	TypeIncome      = "income"       // Поступление
+	TypeExpense     = "expense"      // Расход
+	TypeAdjustment  = "adjustment"   // Корректировка баланса (начальный баланс, ручное изменение баланса аккаунта)
+	TypeTransferIn  = "transfer_in"  // Зачисление по переводу между своими аккаунтами
+	TypeTransferOut = "transfer_out" // Списание по переводу между своими аккаунтами
+)
+
+// MaxTags — максимальное количество тегов у операции.
+const MaxTags = 20
+
+// Transaction описывает операцию по банковскому аккаунту.
+type Transaction struct {
+	ID         int           // Уникальный идентификатор операции
+	UserID     int           // ID пользователя
+	AccountID  int           // ID банковского аккаунта
+	Type       string        // Тип операции
+	Amount     money.Decimal `swaggertype:"string"` // Сумма со знаком: положительная — поступление, отрицательная — списание
+	Currency   string        // Валюта (валюта аккаунта)
+	Date       time.Time     // Дата операции
+	Payee      string        // Контрагент
+	Note       string        // Комментарий
+	CategoryID *int          // ID категории (nil — без категории)
+	TransferID *int          // ID перевода, если операция является его частью
+	ExternalID *string       // Идентификатор операции в банке (FITID), если операция импортирована из выписки
+	Tags       []string      // Теги
+	CreatedAt  time.Time     // Дата создания
+	UpdatedAt  time.Time     // Дата обновления
+}
+
+// ListFilter описывает параметры выборки операций пользователя.
+// Операции возвращаются от новых к старым (по дате и id).
+type ListFilter struct {
+	AccountID  int       // Фильтр по аккаунту (0 — все аккаунты)
+	Type       string    // Фильтр по типу операции
+	CategoryID int       // Фильтр по категории, включая её подкатегории (0 — без фильтра)
+	Tag        string    // Фильтр по тегу (пусто — без фильтра)
+	From       time.Time // Начало периода включительно (нулевое значение — без ограничения)
+	To         time.Time // Конец периода включительно (нулевое значение — без ограничения)
+	Limit      int       // Размер страницы
+	Cursor     string    // Курсор, полученный с предыдущей страницы
+}
@@ -0,0 +1,32 @@
+package transfer
+
+import (
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// Transfer описывает перевод между двумя аккаунтами одного пользователя.
+// Для перевода в другой валюте хранятся обе суммы и курс.
+type Transfer struct {
+	ID            int           // Уникальный идентификатор перевода
+	UserID        int           // ID пользователя
+	FromAccountID int           // ID аккаунта списания
+	ToAccountID   int           // ID аккаунта зачисления
+	FromAmount    money.Decimal `swaggertype:"string"` // Сумма списания в валюте аккаунта списания
+	FromCurrency  string        // Валюта аккаунта списания
+	ToAmount      money.Decimal `swaggertype:"string"` // Сумма зачисления в валюте аккаунта зачисления
+	ToCurrency    string        // Валюта аккаунта зачисления
+	Rate          money.Decimal `swaggertype:"string"` // Курс: единиц валюты зачисления за единицу валюты списания
+	Date          time.Time     // Дата перевода
+	Note          string        // Комментарий
+	CreatedAt     time.Time     // Дата создания
+}
+
+// ListFilter описывает параметры выборки переводов пользователя.
+// Переводы возвращаются от новых к старым (по дате и id).
+type ListFilter struct {
+	AccountID int    // Фильтр по аккаунту (списания или зачисления); 0 — все аккаунты
+	Limit     int    // Размер страницы
+	Cursor    string // Курсор, полученный с предыдущей страницы
+}
@@ -0,0 +1,17 @@
+package user
+
+// Ограничения для пользователей с неподтверждённым email.
+const (
+	UnverifiedAccessFull     = "full"      // Без ограничений
+	UnverifiedAccessReadOnly = "read_only" // Только чтение: access токен выдаётся без разрешения write
+	UnverifiedAccessNone     = "none"      // Вход запрещён до подтверждения email
+)
+
+// ValidUnverifiedAccess сообщает, является ли a известным режимом доступа для неподтверждённых пользователей.
+func ValidUnverifiedAccess(a string) bool {
+	switch a {
+	case UnverifiedAccessFull, UnverifiedAccessReadOnly, UnverifiedAccessNone:
+		return true
+	}
+	return false
+}
@@ -4,8 +4,16 @@ import "time"
 
 // User представляет пользователя системы.
 type User struct {
-	ID           int       // Уникальный идентификатор пользователя
-	Email        string    // Email пользователя
-	PasswordHash string    // Хеш пароля пользователя
-	CreatedAt    time.Time // Дата и время создания пользователя
+	ID              int        // Уникальный идентификатор пользователя
+	Email           string     // Email пользователя
+	PasswordHash    string     // Хеш пароля пользователя
+	HomeCurrency    string     // Домашняя валюта: в ней по умолчанию считаются итоги по аккаунтам
+	Language        string     // Язык сообщений API (ru, en); пусто — язык выбирается по Accept-Language
+	EmailVerifiedAt *time.Time // Время подтверждения email; nil — email не подтверждён
+	CreatedAt       time.Time  // Дата и время создания пользователя
+}
+
+// EmailVerified сообщает, подтвердил ли пользователь свой email.
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
 }
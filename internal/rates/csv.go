@@ -0,0 +1,96 @@
+package rates
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rate"
+)
+
+// CSVProvider загружает курсы из CSV-файла с колонками date,base,quote,rate (дата в формате YYYY-MM-DD).
+// Первая строка — заголовок. Файл читается при каждом вызове Fetch, поэтому его можно обновлять без перезапуска.
+type CSVProvider struct {
+	Path string // Путь к файлу
+}
+
+// NewCSVProvider создает провайдер, читающий курсы из файла path.
+func NewCSVProvider(path string) *CSVProvider {
+	return &CSVProvider{Path: path}
+}
+
+// Name возвращает название источника.
+func (p *CSVProvider) Name() string {
+	return "csv"
+}
+
+// Fetch читает файл и возвращает курсы за даты с from по to включительно.
+func (p *CSVProvider) Fetch(ctx context.Context, from, to time.Time) ([]rate.Rate, error) {
+	f, err := os.Open(p.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	all, err := ParseCSV(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.Path, err)
+	}
+	result := []rate.Rate{}
+	for _, r := range all {
+		if r.Date.Before(from) || r.Date.After(to) {
+			continue
+		}
+		r.Source = p.Name()
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// ParseCSV разбирает файл курсов. В отличие от выписок, ошибка в любой строке прерывает разбор:
+// частично загруженные курсы дали бы неверный пересчёт.
+func ParseCSV(r io.Reader) ([]rate.Rate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("header: %w", err)
+	}
+	result := []rate.Rate{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		date, err := time.Parse(time.DateOnly, strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, record[0])
+		}
+		base, quote := currency.Normalize(record[1]), currency.Normalize(record[2])
+		if !currency.Valid(base) || !currency.Valid(quote) || base == quote {
+			return nil, fmt.Errorf("line %d: invalid currency pair %s/%s", line, record[1], record[2])
+		}
+		// Положительным должен быть курс после округления до rate.Scale: именно он сохраняется и используется в пересчёте.
+		value, err := money.Parse(strings.TrimSpace(record[3]))
+		if err == nil {
+			value = value.Round(rate.Scale)
+		}
+		if err != nil || !value.IsPositive() {
+			return nil, fmt.Errorf("line %d: invalid rate %q", line, record[3])
+		}
+		result = append(result, rate.Rate{Base: base, Quote: quote, Date: date, Rate: value})
+	}
+	return result, nil
+}
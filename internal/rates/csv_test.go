@@ -0,0 +1,29 @@
+package rates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCSV(t *testing.T) {
+	body := "date,base,quote,rate\n2024-03-01,usd,RUB,90.5\n2024-03-01,EUR,USD,0.000000000001\n"
+	rates, err := ParseCSV(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("ParseCSV error: %v", err)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("got %d rates, want 2", len(rates))
+	}
+	if r := rates[0]; r.Base != "USD" || r.Quote != "RUB" || r.Rate.String() != "90.500000000000" {
+		t.Errorf("rate = %s/%s %s, want USD/RUB 90.500000000000", r.Base, r.Quote, r.Rate)
+	}
+}
+
+func TestParseCSVInvalidRate(t *testing.T) {
+	for _, value := range []string{"0", "-1", "0.0000000000001", "0.0000000000000", "1e100000000", "abc"} {
+		body := "date,base,quote,rate\n2024-03-01,USD,RUB," + value + "\n"
+		if rates, err := ParseCSV(strings.NewReader(body)); err == nil {
+			t.Errorf("ParseCSV(rate %s) = %+v, want error", value, rates)
+		}
+	}
+}
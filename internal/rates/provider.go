@@ -0,0 +1,18 @@
+// Package rates загружает курсы валют из внешних источников.
+// Источник подключается реализацией Provider; для работы без сети есть загрузчик CSV-файла.
+package rates
+
+import (
+	"context"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rate"
+)
+
+// Provider — источник исторических курсов валют.
+type Provider interface {
+	// Name возвращает название источника; оно сохраняется в rate.Rate.Source.
+	Name() string
+	// Fetch возвращает курсы за даты с from по to включительно. Нулевой from означает всю доступную историю.
+	Fetch(ctx context.Context, from, to time.Time) ([]rate.Rate, error)
+}
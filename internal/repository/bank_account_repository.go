@@ -2,9 +2,36 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+// bankAccountColumns — список колонок, из которых собирается account.BankAccount.
+const bankAccountColumns = `id, user_id, name, balance, currency, iban, bic, type, credit_limit, statement_day, interest_rate, archived_at, deleted_at, version, created_at, updated_at`
+
+// bankAccountSortColumns сопоставляет поля сортировки колонкам и SQL-типам значений курсора.
+var bankAccountSortColumns = map[string]struct{ column, cast string }{
+	account.SortByCreatedAt: {"created_at", "timestamp"},
+	account.SortByName:      {"name", "text"},
+	account.SortByBalance:   {"balance", "numeric"},
+}
+
+var (
+	// ErrCurrencyChangeWithTransactions возвращается при попытке сменить валюту аккаунта, по которому уже есть операции.
+	ErrCurrencyChangeWithTransactions = errors.New("cannot change currency of account with transactions")
+	// ErrIBANExists возвращается, если у пользователя уже есть аккаунт с таким IBAN.
+	ErrIBANExists = errors.New("bank account with this IBAN already exists")
+	// ErrVersionMismatch возвращается, если аккаунт изменён после чтения клиентом.
+	ErrVersionMismatch = errors.New("bank account version mismatch")
 )
 
 // BankAccountRepository предоставляет методы для работы с банковскими аккаунтами в БД.
@@ -18,29 +45,347 @@ func NewBankAccountRepository(db *pgxpool.Pool) *BankAccountRepository {
 }
 
 // Create создает новый банковский аккаунт для пользователя.
-func (r *BankAccountRepository) Create(ctx context.Context, userID int, name string, balance float64, currency string) (*account.BankAccount, error) {
-	row := r.db.QueryRow(ctx, `INSERT INTO bank_accounts (user_id, name, balance, currency) VALUES ($1, $2, $3, $4) RETURNING id, user_id, name, balance, currency, created_at, updated_at`, userID, name, balance, currency)
-	var acc account.BankAccount
-	err := row.Scan(&acc.ID, &acc.UserID, &acc.Name, &acc.Balance, &acc.Currency, &acc.CreatedAt, &acc.UpdatedAt)
+// Ненулевой начальный баланс записывается в историю операций корректировкой.
+func (r *BankAccountRepository) Create(ctx context.Context, a *account.BankAccount) (*account.BankAccount, error) {
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return &acc, nil
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `INSERT INTO bank_accounts (user_id, name, balance, currency, iban, bic, type, credit_limit, statement_day, interest_rate)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7, $8, $9, $10) RETURNING `+bankAccountColumns,
+		a.UserID, a.Name, a.Balance, a.Currency, a.IBAN, a.BIC, a.Type, a.CreditLimit, a.StatementDay, a.InterestRate)
+	acc, err := scanBankAccount(row)
+	if isUniqueViolation(err) {
+		return nil, ErrIBANExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !a.Balance.IsZero() {
+		if err := insertAdjustment(ctx, tx, acc.ID, a.UserID, a.Balance, "Начальный баланс"); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return acc, nil
 }
 
-// Update обновляет банковский аккаунт по id и user_id.
-func (r *BankAccountRepository) Update(ctx context.Context, id, userID int, name string, balance float64, currency string) (*account.BankAccount, error) {
-	row := r.db.QueryRow(ctx, `UPDATE bank_accounts SET name=$1, balance=$2, currency=$3, updated_at=NOW() WHERE id=$4 AND user_id=$5 RETURNING id, user_id, name, balance, currency, created_at, updated_at`, name, balance, currency, id, userID)
-	var acc account.BankAccount
-	err := row.Scan(&acc.ID, &acc.UserID, &acc.Name, &acc.Balance, &acc.Currency, &acc.CreatedAt, &acc.UpdatedAt)
+// GetByID возвращает банковский аккаунт по id и user_id. Удалённые аккаунты не возвращаются.
+func (r *BankAccountRepository) GetByID(ctx context.Context, id, userID int) (*account.BankAccount, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+bankAccountColumns+` FROM bank_accounts WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL`, id, userID)
+	return scanBankAccount(row)
+}
+
+// GetByIBAN возвращает банковский аккаунт пользователя по нормализованному IBAN.
+func (r *BankAccountRepository) GetByIBAN(ctx context.Context, userID int, iban string) (*account.BankAccount, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+bankAccountColumns+` FROM bank_accounts WHERE user_id=$1 AND iban=$2 AND deleted_at IS NULL`, userID, iban)
+	return scanBankAccount(row)
+}
+
+// BalanceAt возвращает баланс аккаунта на конец дня date — сумму его операций по эту дату включительно.
+// Возвращает pgx.ErrNoRows, если аккаунт не принадлежит пользователю.
+func (r *BankAccountRepository) BalanceAt(ctx context.Context, id, userID int, date time.Time) (money.Decimal, error) {
+	var balance money.Decimal
+	err := r.db.QueryRow(ctx, `
+		SELECT COALESCE((SELECT SUM(t.amount) FROM transactions t WHERE t.account_id = a.id AND t.date <= $3), 0)
+		FROM bank_accounts a WHERE a.id = $1 AND a.user_id = $2 AND a.deleted_at IS NULL`, id, userID, date).Scan(&balance)
+	return balance, err
+}
+
+// List возвращает страницу банковских аккаунтов пользователя с фильтрацией и сортировкой.
+// Пагинация keyset по паре (поле сортировки, id); второй результат — курсор следующей страницы или пустая строка.
+func (r *BankAccountRepository) List(ctx context.Context, userID int, filter account.ListFilter) ([]account.BankAccount, string, error) {
+	sortCol, ok := bankAccountSortColumns[filter.SortBy]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown sort field %q", filter.SortBy)
+	}
+
+	conditions := []string{"user_id = $1", "deleted_at IS NULL"}
+	args := []any{userID}
+	if !filter.IncludeArchived {
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+	if filter.Currency != "" {
+		args = append(args, filter.Currency)
+		conditions = append(conditions, fmt.Sprintf("currency = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.InvalidCurrency {
+		args = append(args, currency.Codes())
+		conditions = append(conditions, fmt.Sprintf("currency <> ALL($%d)", len(args)))
+	}
+	if filter.Name != "" {
+		args = append(args, "%"+escapeLike(filter.Name)+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	order, cmp := "ASC", ">"
+	if filter.SortDesc {
+		order, cmp = "DESC", "<"
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor, filter.SortBy)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, cursor.Value, cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d::%s, $%d)", sortCol.column, cmp, len(args)-1, sortCol.cast, len(args)))
+	}
+	args = append(args, filter.Limit+1)
+	query := fmt.Sprintf(`SELECT %s FROM bank_accounts WHERE %s ORDER BY %s %s, id %s LIMIT $%d`,
+		bankAccountColumns, strings.Join(conditions, " AND "), sortCol.column, order, order, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	accounts := []account.BankAccount{}
+	for rows.Next() {
+		acc, err := scanBankAccount(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		accounts = append(accounts, *acc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(accounts) > filter.Limit {
+		accounts = accounts[:filter.Limit]
+		last := accounts[len(accounts)-1]
+		nextCursor = encodeCursor(pageCursor{Sort: filter.SortBy, Value: bankAccountSortValue(last, filter.SortBy), ID: last.ID})
+	}
+	return accounts, nextCursor, nil
+}
+
+// ListAll возвращает все неудалённые банковские аккаунты пользователя, включая архивные, по порядку создания.
+func (r *BankAccountRepository) ListAll(ctx context.Context, userID int) ([]account.BankAccount, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+bankAccountColumns+` FROM bank_accounts WHERE user_id=$1 AND deleted_at IS NULL ORDER BY created_at, id`, userID)
 	if err != nil {
 		return nil, err
 	}
-	return &acc, nil
+	defer rows.Close()
+
+	accounts := []account.BankAccount{}
+	for rows.Next() {
+		acc, err := scanBankAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *acc)
+	}
+	return accounts, rows.Err()
+}
+
+// Update обновляет банковский аккаунт по a.ID и a.UserID.
+// Если balance не nil и отличается от текущего, разница записывается в историю операций корректировкой.
+// Смена валюты допускается только для аккаунта без операций (ErrCurrencyChangeWithTransactions).
+// Если version не 0, обновление выполняется только при совпадении с текущей версией аккаунта (ErrVersionMismatch).
+func (r *BankAccountRepository) Update(ctx context.Context, a *account.BankAccount, balance *money.Decimal, version int) (*account.BankAccount, error) {
+	id, userID := a.ID, a.UserID
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var current money.Decimal
+	var currentCurrency string
+	var currentVersion int
+	err = tx.QueryRow(ctx, `SELECT balance, currency, version FROM bank_accounts WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL FOR UPDATE`, id, userID).
+		Scan(&current, &currentCurrency, &currentVersion)
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && version != currentVersion {
+		return nil, ErrVersionMismatch
+	}
+	// Код вне справочника (аккаунт создан до проверки кодов) можно исправить и при наличии операций,
+	// если баланс представим в новой валюте: это исправление записи, а не смена валюты.
+	relabel := !currency.Valid(currentCurrency) && money.FitsCurrency(current, a.Currency)
+	if a.Currency != currentCurrency && !relabel {
+		var hasTransactions bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM transactions WHERE account_id=$1)`, id).Scan(&hasTransactions); err != nil {
+			return nil, err
+		}
+		if hasTransactions {
+			return nil, ErrCurrencyChangeWithTransactions
+		}
+	}
+	newBalance := current
+	if balance != nil && !balance.Equal(current) {
+		newBalance = *balance
+		if err := insertAdjustment(ctx, tx, id, userID, balance.Sub(current), "Корректировка баланса"); err != nil {
+			return nil, err
+		}
+	}
+	row := tx.QueryRow(ctx, `UPDATE bank_accounts SET name=$1, balance=$2, currency=$3, iban=NULLIF($4, ''), bic=NULLIF($5, ''),
+		type=$6, credit_limit=$7, statement_day=$8, interest_rate=$9, version=version+1, updated_at=NOW()
+		WHERE id=$10 AND user_id=$11 RETURNING `+bankAccountColumns,
+		a.Name, newBalance, a.Currency, a.IBAN, a.BIC, a.Type, a.CreditLimit, a.StatementDay, a.InterestRate, id, userID)
+	acc, err := scanBankAccount(row)
+	if isUniqueViolation(err) {
+		return nil, ErrIBANExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return acc, nil
 }
 
-// Delete удаляет банковский аккаунт по id и user_id.
+// Delete помечает банковский аккаунт удалённым. Аккаунт и его операции сохраняются до окончательного удаления
+// через PurgeDeleted. Возвращает pgx.ErrNoRows, если аккаунт не найден или уже удалён.
 func (r *BankAccountRepository) Delete(ctx context.Context, id, userID int) error {
-	_, err := r.db.Exec(ctx, `DELETE FROM bank_accounts WHERE id=$1 AND user_id=$2`, id, userID)
+	tag, err := r.db.Exec(ctx, `UPDATE bank_accounts SET deleted_at=NOW(), version=version+1, updated_at=NOW() WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ListDeleted возвращает аккаунты пользователя, удалённые не раньше since, от недавно удалённых к старым.
+func (r *BankAccountRepository) ListDeleted(ctx context.Context, userID int, since time.Time) ([]account.BankAccount, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+bankAccountColumns+` FROM bank_accounts
+		WHERE user_id=$1 AND deleted_at >= $2 ORDER BY deleted_at DESC, id DESC`, userID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []account.BankAccount{}
+	for rows.Next() {
+		acc, err := scanBankAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, *acc)
+	}
+	return accounts, rows.Err()
+}
+
+// Restore снимает пометку удаления с аккаунта, удалённого не раньше since.
+// Возвращает pgx.ErrNoRows, если такого удалённого аккаунта нет, и ErrIBANExists, если его IBAN уже занят.
+func (r *BankAccountRepository) Restore(ctx context.Context, id, userID int, since time.Time) (*account.BankAccount, error) {
+	row := r.db.QueryRow(ctx, `UPDATE bank_accounts SET deleted_at=NULL, version=version+1, updated_at=NOW()
+		WHERE id=$1 AND user_id=$2 AND deleted_at >= $3 RETURNING `+bankAccountColumns, id, userID, since)
+	acc, err := scanBankAccount(row)
+	if isUniqueViolation(err) {
+		return nil, ErrIBANExists
+	}
+	return acc, err
+}
+
+// SetArchived переносит аккаунт в архив или возвращает из архива.
+// Возвращает pgx.ErrNoRows, если аккаунт не найден или удалён.
+func (r *BankAccountRepository) SetArchived(ctx context.Context, id, userID int, archived bool) (*account.BankAccount, error) {
+	row := r.db.QueryRow(ctx, `UPDATE bank_accounts
+		SET archived_at = CASE WHEN $3 THEN COALESCE(archived_at, NOW()) END, version=version+1, updated_at=NOW()
+		WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL RETURNING `+bankAccountColumns, id, userID, archived)
+	return scanBankAccount(row)
+}
+
+// PurgeDeleted окончательно удаляет аккаунты, помеченные удалёнными раньше before, вместе с их операциями.
+// Переводы с удаляемых аккаунтов удаляются, а их операции на оставшихся аккаунтах в той же транзакции
+// становятся корректировками: баланс оставшегося аккаунта по-прежнему равен сумме его операций.
+// Возвращает количество удалённых аккаунтов.
+func (r *BankAccountRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var ids []int
+	err = tx.QueryRow(ctx, `SELECT COALESCE(array_agg(id), '{}') FROM (
+		SELECT id FROM bank_accounts WHERE deleted_at < $1 FOR UPDATE) purged`, before).Scan(&ids)
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	_, err = tx.Exec(ctx, `UPDATE transactions SET type=$2, transfer_id=NULL, updated_at=NOW()
+		WHERE account_id <> ALL($1) AND transfer_id IN (
+			SELECT id FROM transfers WHERE from_account_id = ANY($1) OR to_account_id = ANY($1))`, ids, transaction.TypeAdjustment)
+	if err != nil {
+		return 0, err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM bank_accounts WHERE id = ANY($1)`, ids)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), tx.Commit(ctx)
+}
+
+// insertAdjustment записывает в историю корректировку баланса аккаунта на сегодняшнюю дату.
+func insertAdjustment(ctx context.Context, q querier, accountID, userID int, amount money.Decimal, note string) error {
+	_, err := insertTransaction(ctx, q, &transaction.Transaction{
+		UserID:    userID,
+		AccountID: accountID,
+		Type:      transaction.TypeAdjustment,
+		Amount:    amount,
+		Date:      time.Now(),
+		Note:      note,
+	})
 	return err
 }
+
+// scanBankAccount читает банковский аккаунт из строки результата (колонки bankAccountColumns).
+// Баланс приводится к точности валюты аккаунта.
+func scanBankAccount(row pgx.Row) (*account.BankAccount, error) {
+	var acc account.BankAccount
+	var iban, bic *string
+	err := row.Scan(&acc.ID, &acc.UserID, &acc.Name, &acc.Balance, &acc.Currency, &iban, &bic,
+		&acc.Type, &acc.CreditLimit, &acc.StatementDay, &acc.InterestRate, &acc.ArchivedAt, &acc.DeletedAt, &acc.Version, &acc.CreatedAt, &acc.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if iban != nil {
+		acc.IBAN = *iban
+	}
+	if bic != nil {
+		acc.BIC = *bic
+	}
+	acc.Balance = acc.Balance.Round(money.MinorUnits(acc.Currency))
+	acc.InvalidCurrency = !currency.Valid(acc.Currency)
+	acc.Liability = account.IsLiability(acc.Type)
+	if acc.CreditLimit != nil {
+		limit := acc.CreditLimit.Round(money.MinorUnits(acc.Currency))
+		available := limit.Add(acc.Balance)
+		acc.CreditLimit, acc.AvailableCredit = &limit, &available
+	}
+	return &acc, nil
+}
+
+// bankAccountSortValue возвращает значение поля сортировки аккаунта в виде строки для курсора.
+func bankAccountSortValue(acc account.BankAccount, sortBy string) string {
+	switch sortBy {
+	case account.SortByName:
+		return acc.Name
+	case account.SortByBalance:
+		return acc.Balance.String()
+	default:
+		return acc.CreatedAt.Format(time.RFC3339Nano)
+	}
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
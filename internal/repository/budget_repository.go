@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/budget"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+)
+
+// budgetColumns — список колонок, из которых собирается budget.Budget (без лимитов).
+const budgetColumns = `id, user_id, name, period, currency, start_date, rollover, created_at, updated_at`
+
+// BudgetRepository предоставляет методы для работы с бюджетами и их лимитами в БД.
+type BudgetRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewBudgetRepository создает новый экземпляр BudgetRepository.
+func NewBudgetRepository(db *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// Create сохраняет бюджет вместе с лимитами в одной транзакции.
+func (r *BudgetRepository) Create(ctx context.Context, b *budget.Budget) (*budget.Budget, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `INSERT INTO budgets (user_id, name, period, currency, start_date, rollover) VALUES ($1, $2, $3, $4, $5, $6) RETURNING `+budgetColumns,
+		b.UserID, b.Name, b.Period, b.Currency, b.StartDate, b.Rollover)
+	created, err := scanBudget(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := insertBudgetLimits(ctx, tx, created.ID, b.Limits); err != nil {
+		return nil, err
+	}
+	created.Limits = b.Limits
+	return created, tx.Commit(ctx)
+}
+
+// GetByID возвращает бюджет пользователя с лимитами по id.
+func (r *BudgetRepository) GetByID(ctx context.Context, id, userID int) (*budget.Budget, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE id=$1 AND user_id=$2`, id, userID)
+	b, err := scanBudget(row)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := r.listLimits(ctx, []int{b.ID})
+	if err != nil {
+		return nil, err
+	}
+	b.Limits = limits[b.ID]
+	return b, nil
+}
+
+// List возвращает все бюджеты пользователя с лимитами, отсортированные по названию.
+func (r *BudgetRepository) List(ctx context.Context, userID int) ([]budget.Budget, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+budgetColumns+` FROM budgets WHERE user_id=$1 ORDER BY name, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	budgets := []budget.Budget{}
+	ids := []int{}
+	for rows.Next() {
+		b, err := scanBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		budgets = append(budgets, *b)
+		ids = append(ids, b.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	limits, err := r.listLimits(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range budgets {
+		budgets[i].Limits = limits[budgets[i].ID]
+	}
+	return budgets, nil
+}
+
+// Update изменяет параметры бюджета и полностью заменяет его лимиты в одной транзакции.
+func (r *BudgetRepository) Update(ctx context.Context, b *budget.Budget) (*budget.Budget, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `UPDATE budgets SET name=$1, period=$2, currency=$3, start_date=$4, rollover=$5, updated_at=NOW() WHERE id=$6 AND user_id=$7 RETURNING `+budgetColumns,
+		b.Name, b.Period, b.Currency, b.StartDate, b.Rollover, b.ID, b.UserID)
+	updated, err := scanBudget(row)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM budget_limits WHERE budget_id=$1`, updated.ID); err != nil {
+		return nil, err
+	}
+	if err := insertBudgetLimits(ctx, tx, updated.ID, b.Limits); err != nil {
+		return nil, err
+	}
+	updated.Limits = b.Limits
+	return updated, tx.Commit(ctx)
+}
+
+// Delete удаляет бюджет пользователя. Возвращает pgx.ErrNoRows, если бюджет не найден.
+func (r *BudgetRepository) Delete(ctx context.Context, id, userID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM budgets WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// SpendingByDay возвращает расходы по категориям лимитов бюджета за каждый день в диапазоне [from, to].
+// В лимит категории попадают расходы по ней и всем её подкатегориям; учитываются только аккаунты в валюте бюджета.
+func (r *BudgetRepository) SpendingByDay(ctx context.Context, b *budget.Budget, from, to time.Time) ([]budget.Spending, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT category_id AS root_id, category_id AS id FROM budget_limits WHERE budget_id = $1
+			UNION ALL
+			SELECT t.root_id, c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT tree.root_id, tr.date, -SUM(tr.amount)
+		FROM tree
+		JOIN transactions tr ON tr.category_id = tree.id
+		JOIN bank_accounts a ON a.id = tr.account_id
+		WHERE tr.user_id = $2 AND tr.type = 'expense' AND a.currency = $3 AND a.deleted_at IS NULL AND tr.date >= $4 AND tr.date <= $5
+		GROUP BY tree.root_id, tr.date
+		ORDER BY tr.date`, b.ID, b.UserID, b.Currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	spending := []budget.Spending{}
+	for rows.Next() {
+		var s budget.Spending
+		if err := rows.Scan(&s.CategoryID, &s.Date, &s.Amount); err != nil {
+			return nil, err
+		}
+		spending = append(spending, s)
+	}
+	return spending, rows.Err()
+}
+
+// listLimits возвращает лимиты указанных бюджетов, сгруппированные по id бюджета.
+func (r *BudgetRepository) listLimits(ctx context.Context, budgetIDs []int) (map[int][]budget.Limit, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT bl.budget_id, bl.category_id, bl.amount, b.currency
+		FROM budget_limits bl JOIN budgets b ON b.id = bl.budget_id
+		WHERE bl.budget_id = ANY($1)
+		ORDER BY bl.budget_id, bl.category_id`, budgetIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	limits := map[int][]budget.Limit{}
+	for rows.Next() {
+		var budgetID int
+		var currency string
+		var l budget.Limit
+		if err := rows.Scan(&budgetID, &l.CategoryID, &l.Amount, &currency); err != nil {
+			return nil, err
+		}
+		l.Amount = l.Amount.Round(money.MinorUnits(currency))
+		limits[budgetID] = append(limits[budgetID], l)
+	}
+	return limits, rows.Err()
+}
+
+// insertBudgetLimits сохраняет лимиты бюджета в рамках q.
+func insertBudgetLimits(ctx context.Context, q querier, budgetID int, limits []budget.Limit) error {
+	for _, l := range limits {
+		if _, err := q.Exec(ctx, `INSERT INTO budget_limits (budget_id, category_id, amount) VALUES ($1, $2, $3)`, budgetID, l.CategoryID, l.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scanBudget читает бюджет из строки результата (колонки budgetColumns).
+func scanBudget(row pgx.Row) (*budget.Budget, error) {
+	var b budget.Budget
+	err := row.Scan(&b.ID, &b.UserID, &b.Name, &b.Period, &b.Currency, &b.StartDate, &b.Rollover, &b.CreatedAt, &b.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
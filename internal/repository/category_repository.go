@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+)
+
+// ErrCategoryExists возвращается, если у родителя уже есть категория с таким названием.
+var ErrCategoryExists = errors.New("category with this name already exists")
+
+// categoryColumns — список колонок, из которых собирается category.Category.
+const categoryColumns = `id, user_id, parent_id, name, kind, created_at, updated_at`
+
+// CategoryRepository предоставляет методы для работы с категориями в БД.
+type CategoryRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewCategoryRepository создает новый экземпляр CategoryRepository.
+func NewCategoryRepository(db *pgxpool.Pool) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// Create создает категорию пользователя.
+func (r *CategoryRepository) Create(ctx context.Context, c *category.Category) (*category.Category, error) {
+	row := r.db.QueryRow(ctx, `INSERT INTO categories (user_id, parent_id, name, kind) VALUES ($1, $2, $3, $4) RETURNING `+categoryColumns,
+		c.UserID, c.ParentID, c.Name, c.Kind)
+	created, err := scanCategory(row)
+	if isUniqueViolation(err) {
+		return nil, ErrCategoryExists
+	}
+	return created, err
+}
+
+// GetByID возвращает категорию по id и user_id.
+func (r *CategoryRepository) GetByID(ctx context.Context, id, userID int) (*category.Category, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+categoryColumns+` FROM categories WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanCategory(row)
+}
+
+// List возвращает категории пользователя, отсортированные по виду и названию. Пустой kind — все виды.
+func (r *CategoryRepository) List(ctx context.Context, userID int, kind string) ([]category.Category, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+categoryColumns+` FROM categories WHERE user_id=$1 AND ($2 = '' OR kind = $2) ORDER BY kind, name, id`, userID, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := []category.Category{}
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, *c)
+	}
+	return categories, rows.Err()
+}
+
+// Update изменяет название и родителя категории.
+func (r *CategoryRepository) Update(ctx context.Context, c *category.Category) (*category.Category, error) {
+	row := r.db.QueryRow(ctx, `UPDATE categories SET name=$1, parent_id=$2, updated_at=NOW() WHERE id=$3 AND user_id=$4 RETURNING `+categoryColumns,
+		c.Name, c.ParentID, c.ID, c.UserID)
+	updated, err := scanCategory(row)
+	if isUniqueViolation(err) {
+		return nil, ErrCategoryExists
+	}
+	return updated, err
+}
+
+// DescendantIDs возвращает id категории и всех её потомков.
+func (r *CategoryRepository) DescendantIDs(ctx context.Context, userID, id int) ([]int, error) {
+	return descendantCategoryIDs(ctx, r.db, userID, id)
+}
+
+// Delete удаляет категорию: дочерние категории переходят к её родителю, у операций категория сбрасывается.
+// Возвращает pgx.ErrNoRows, если категория не найдена у пользователя.
+func (r *CategoryRepository) Delete(ctx context.Context, id, userID int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var parentID *int
+	if err := tx.QueryRow(ctx, `SELECT parent_id FROM categories WHERE id=$1 AND user_id=$2 FOR UPDATE`, id, userID).Scan(&parentID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE categories SET parent_id=$1, updated_at=NOW() WHERE parent_id=$2 AND user_id=$3`, parentID, id, userID); err != nil {
+		if isUniqueViolation(err) {
+			return ErrCategoryExists
+		}
+		return err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM categories WHERE id=$1 AND user_id=$2`, id, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Merge переносит операции, правила, регулярные операции, лимиты бюджетов и дочерние категории из source в target и удаляет source
+// в одной транзакции.
+func (r *CategoryRepository) Merge(ctx context.Context, userID, sourceID, targetID int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE transactions SET category_id=$1, updated_at=NOW() WHERE category_id=$2 AND user_id=$3`, targetID, sourceID, userID); err != nil {
+		return err
+	}
+	// Правила, назначающие source, назначают target: иначе после удаления source они перестали бы категоризировать.
+	if _, err := tx.Exec(ctx, `UPDATE rules SET category_id=$1, updated_at=NOW() WHERE category_id=$2 AND user_id=$3`, targetID, sourceID, userID); err != nil {
+		return err
+	}
+	// Регулярные операции тоже переходят к target, чтобы будущие проведения не остались без категории.
+	if _, err := tx.Exec(ctx, `UPDATE recurrences SET category_id=$1, updated_at=NOW() WHERE category_id=$2 AND user_id=$3`, targetID, sourceID, userID); err != nil {
+		return err
+	}
+	// Лимиты бюджетов переходят к target; если у target в том же бюджете уже есть лимит, лимиты складываются.
+	if _, err := tx.Exec(ctx, `
+		UPDATE budget_limits t SET amount = t.amount + s.amount
+		FROM budget_limits s
+		WHERE t.category_id = $1 AND s.category_id = $2 AND s.budget_id = t.budget_id`, targetID, sourceID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE budget_limits s SET category_id = $1
+		WHERE s.category_id = $2
+		  AND NOT EXISTS (SELECT 1 FROM budget_limits t WHERE t.budget_id = s.budget_id AND t.category_id = $1)`, targetID, sourceID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE categories SET parent_id=$1, updated_at=NOW() WHERE parent_id=$2 AND user_id=$3`, targetID, sourceID, userID); err != nil {
+		if isUniqueViolation(err) {
+			return ErrCategoryExists
+		}
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM categories WHERE id=$1 AND user_id=$2`, sourceID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return tx.Commit(ctx)
+}
+
+// insertDefaultCategories создает категории по умолчанию вместе с дочерними в рамках q.
+func insertDefaultCategories(ctx context.Context, q querier, userID int, defaults []category.Default) error {
+	for _, d := range defaults {
+		var parentID int
+		err := q.QueryRow(ctx, `INSERT INTO categories (user_id, name, kind) VALUES ($1, $2, $3) RETURNING id`, userID, d.Name, d.Kind).Scan(&parentID)
+		if err != nil {
+			return err
+		}
+		for _, child := range d.Children {
+			_, err := q.Exec(ctx, `INSERT INTO categories (user_id, parent_id, name, kind) VALUES ($1, $2, $3, $4)`, userID, parentID, child, d.Kind)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// descendantCategoryIDs возвращает id категории и всех её потомков рекурсивным запросом.
+func descendantCategoryIDs(ctx context.Context, q querier, userID, id int) ([]int, error) {
+	rows, err := q.Query(ctx, `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE id = $1 AND user_id = $2
+			UNION ALL
+			SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree`, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var childID int
+		if err := rows.Scan(&childID); err != nil {
+			return nil, err
+		}
+		ids = append(ids, childID)
+	}
+	return ids, rows.Err()
+}
+
+// scanCategory читает категорию из строки результата (колонки categoryColumns).
+func scanCategory(row pgx.Row) (*category.Category, error) {
+	var c category.Category
+	err := row.Scan(&c.ID, &c.UserID, &c.ParentID, &c.Name, &c.Kind, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
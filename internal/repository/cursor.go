@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// ErrInvalidCursor возвращается, если курсор пагинации повреждён или не соответствует сортировке.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// pageCursor описывает позицию в выборке с keyset-пагинацией: значение поля сортировки и id последней записи.
+type pageCursor struct {
+	Sort  string `json:"s"` // Поле сортировки, для которого выдан курсор
+	Value string `json:"v"` // Значение поля сортировки последней записи
+	ID    int    `json:"id"`
+}
+
+// encodeCursor кодирует позицию в непрозрачную для клиента строку.
+func encodeCursor(c pageCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor декодирует курсор и проверяет, что он выдан для той же сортировки.
+func decodeCursor(s, sort string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != sort {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
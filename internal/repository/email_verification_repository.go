@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrEmailVerificationTokenInvalid возвращается, если токен подтверждения email не найден, уже использован, заменён или истёк.
+var ErrEmailVerificationTokenInvalid = errors.New("email verification token is invalid")
+
+// EmailVerificationRepository предоставляет методы для работы с токенами подтверждения email в БД.
+// Сами токены не хранятся — только их SHA-256 хеши.
+type EmailVerificationRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewEmailVerificationRepository создает новый экземпляр EmailVerificationRepository.
+func NewEmailVerificationRepository(db *pgxpool.Pool) *EmailVerificationRepository {
+	return &EmailVerificationRepository{db: db}
+}
+
+// Create сохраняет хеш нового токена подтверждения email. Неиспользованные токены пользователя,
+// выданные ранее, в той же транзакции помечаются недействительными: действует только ссылка из последнего письма.
+// Помеченные токены не удаляются до истечения срока, чтобы CountSince учитывал все отправленные письма.
+func (r *EmailVerificationRepository) Create(ctx context.Context, userID int, tokenHash string, expiresAt, createdAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE email_verification_tokens SET invalidated_at = $2
+		WHERE user_id = $1 AND used_at IS NULL AND invalidated_at IS NULL`, userID, createdAt); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO email_verification_tokens (user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4)`,
+		userID, tokenHash, expiresAt, createdAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// CountSince возвращает количество токенов подтверждения email, выданных пользователю начиная с since,
+// включая использованные и недействительные.
+func (r *EmailVerificationRepository) CountSince(ctx context.Context, userID int, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM email_verification_tokens WHERE user_id = $1 AND created_at >= $2`, userID, since).Scan(&count)
+	return count, err
+}
+
+// Verify в одной транзакции погашает токен с хешем tokenHash и отмечает email его владельца подтверждённым
+// на момент now (если email уже был подтверждён, время подтверждения не меняется).
+// Возвращает ID пользователя или ErrEmailVerificationTokenInvalid, если токен не найден, использован,
+// заменён более новым или истёк.
+func (r *EmailVerificationRepository) Verify(ctx context.Context, tokenHash string, now time.Time) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int
+	err = tx.QueryRow(ctx, `UPDATE email_verification_tokens SET used_at = $1
+		WHERE token_hash = $2 AND used_at IS NULL AND invalidated_at IS NULL AND expires_at > $1 RETURNING user_id`, now, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrEmailVerificationTokenInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET email_verified_at = $1 WHERE id = $2 AND email_verified_at IS NULL`, now, userID); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit(ctx)
+}
+
+// DeleteExpired удаляет токены подтверждения email, срок действия которых истёк до before.
+// Срок действия не короче суток, поэтому удаляются только токены вне окна суточного лимита.
+// Возвращает количество удалённых токенов.
+func (r *EmailVerificationRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM email_verification_tokens WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolationCode — код ошибки PostgreSQL при нарушении ограничения уникальности.
+const uniqueViolationCode = "23505"
+
+// isUniqueViolation сообщает, вызвана ли ошибка нарушением ограничения уникальности.
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,403 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+var (
+	// ErrImportProfileExists возвращается, если у пользователя уже есть профиль импорта с таким названием.
+	ErrImportProfileExists = errors.New("import profile with this name already exists")
+	// ErrBatchCommitted возвращается при попытке повторно подтвердить или удалить подтверждённый пакет импорта.
+	ErrBatchCommitted = errors.New("import batch already committed")
+)
+
+const (
+	// importProfileColumns — список колонок, из которых собирается imports.Profile.
+	importProfileColumns = `id, user_id, name, delimiter, encoding, skip_rows, date_format, decimal_separator, sign_convention, columns, created_at, updated_at`
+	// importBatchColumns — список колонок, из которых собирается imports.Batch (без строк).
+	importBatchColumns = `id, user_id, account_id, format, file_name, status, opening_balance, opening_balance_date, closing_balance, closing_balance_date, created_at, committed_at`
+	// importRowColumns — список колонок, из которых собирается imports.Row.
+	importRowColumns = `line, date, amount, payee, note, external_id, already_imported, duplicate_of, duplicate_score, error, transaction_id`
+)
+
+// ImportRepository предоставляет методы для работы с профилями и пакетами импорта выписок в БД.
+type ImportRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewImportRepository создает новый экземпляр ImportRepository.
+func NewImportRepository(db *pgxpool.Pool) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// CreateProfile сохраняет профиль сопоставления колонок CSV.
+func (r *ImportRepository) CreateProfile(ctx context.Context, p *imports.Profile) (*imports.Profile, error) {
+	row := r.db.QueryRow(ctx, `INSERT INTO import_profiles (user_id, name, delimiter, encoding, skip_rows, date_format, decimal_separator, sign_convention, columns)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING `+importProfileColumns,
+		p.UserID, p.Name, p.Delimiter, p.Encoding, p.SkipRows, p.DateFormat, p.DecimalSeparator, p.SignConvention, p.Columns)
+	created, err := scanImportProfile(row)
+	if isUniqueViolation(err) {
+		return nil, ErrImportProfileExists
+	}
+	return created, err
+}
+
+// GetProfile возвращает профиль импорта по id и user_id.
+func (r *ImportRepository) GetProfile(ctx context.Context, id, userID int) (*imports.Profile, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+importProfileColumns+` FROM import_profiles WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanImportProfile(row)
+}
+
+// ListProfiles возвращает профили импорта пользователя, отсортированные по названию.
+func (r *ImportRepository) ListProfiles(ctx context.Context, userID int) ([]imports.Profile, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+importProfileColumns+` FROM import_profiles WHERE user_id=$1 ORDER BY name, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	profiles := []imports.Profile{}
+	for rows.Next() {
+		p, err := scanImportProfile(rows)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, *p)
+	}
+	return profiles, rows.Err()
+}
+
+// UpdateProfile изменяет профиль импорта.
+func (r *ImportRepository) UpdateProfile(ctx context.Context, p *imports.Profile) (*imports.Profile, error) {
+	row := r.db.QueryRow(ctx, `UPDATE import_profiles SET name=$1, delimiter=$2, encoding=$3, skip_rows=$4, date_format=$5,
+		decimal_separator=$6, sign_convention=$7, columns=$8, updated_at=NOW() WHERE id=$9 AND user_id=$10 RETURNING `+importProfileColumns,
+		p.Name, p.Delimiter, p.Encoding, p.SkipRows, p.DateFormat, p.DecimalSeparator, p.SignConvention, p.Columns, p.ID, p.UserID)
+	updated, err := scanImportProfile(row)
+	if isUniqueViolation(err) {
+		return nil, ErrImportProfileExists
+	}
+	return updated, err
+}
+
+// DeleteProfile удаляет профиль импорта. Возвращает pgx.ErrNoRows, если профиль не найден.
+func (r *ImportRepository) DeleteProfile(ctx context.Context, id, userID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM import_profiles WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// CreateBatch сохраняет пакет импорта в статусе preview вместе со строками выписки.
+func (r *ImportRepository) CreateBatch(ctx context.Context, b *imports.Batch) (*imports.Batch, error) {
+	created, err := r.CreateBatches(ctx, []*imports.Batch{b})
+	if err != nil {
+		return nil, err
+	}
+	return &created[0], nil
+}
+
+// CreateBatches сохраняет несколько пакетов импорта одного файла в одной транзакции БД:
+// выписка с несколькими счетами загружается целиком или не загружается вовсе.
+func (r *ImportRepository) CreateBatches(ctx context.Context, batches []*imports.Batch) ([]imports.Batch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	created := make([]imports.Batch, 0, len(batches))
+	for _, b := range batches {
+		c, err := insertImportBatch(ctx, tx, b)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, *c)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetBatch возвращает пакет импорта пользователя со строками.
+func (r *ImportRepository) GetBatch(ctx context.Context, id, userID int) (*imports.Batch, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+importBatchColumns+` FROM import_batches WHERE id=$1 AND user_id=$2`, id, userID)
+	b, err := scanImportBatch(row)
+	if err != nil {
+		return nil, err
+	}
+	b.Rows, err = listImportRows(ctx, r.db, b.ID, b.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// ListBatches возвращает пакеты импорта пользователя (без строк) от новых к старым.
+func (r *ImportRepository) ListBatches(ctx context.Context, userID int) ([]imports.Batch, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+importBatchColumns+` FROM import_batches WHERE user_id=$1 ORDER BY created_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batches := []imports.Batch{}
+	for rows.Next() {
+		b, err := scanImportBatch(rows)
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, *b)
+	}
+	return batches, rows.Err()
+}
+
+// DeleteBatch удаляет неподтверждённый пакет импорта.
+// Возвращает pgx.ErrNoRows, если пакет не найден, и ErrBatchCommitted, если он уже подтверждён.
+func (r *ImportRepository) DeleteBatch(ctx context.Context, id, userID int) error {
+	var status string
+	if err := r.db.QueryRow(ctx, `SELECT status FROM import_batches WHERE id=$1 AND user_id=$2`, id, userID).Scan(&status); err != nil {
+		return err
+	}
+	if status != imports.StatusPreview {
+		return ErrBatchCommitted
+	}
+	tag, err := r.db.Exec(ctx, `DELETE FROM import_batches WHERE id=$1 AND user_id=$2 AND status=$3`, id, userID, imports.StatusPreview)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBatchCommitted
+	}
+	return nil
+}
+
+// ExistingExternalIDs возвращает те из externalIDs, для которых на аккаунте уже есть операции.
+func (r *ImportRepository) ExistingExternalIDs(ctx context.Context, accountID int, externalIDs []string) (map[string]bool, error) {
+	return existingExternalIDs(ctx, r.db, accountID, externalIDs)
+}
+
+// CommitBatch создает операции из корректных строк пакета, изменяет баланс аккаунта и переводит пакет
+// в статус committed — всё в одной транзакции БД. Пакет и аккаунт блокируются, поэтому повторное
+// подтверждение невозможно, а строки, чей ExternalID уже есть на аккаунте, пропускаются даже при
+// параллельном импорте той же выписки.
+// Функция prepare, если задана, вызывается для каждой операции перед сохранением (применение правил категоризации).
+// Возвращает pgx.ErrNoRows, если пакет или его аккаунт не принадлежат пользователю.
+func (r *ImportRepository) CommitBatch(ctx context.Context, id, userID int, prepare func(*transaction.Transaction)) (*imports.Batch, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `SELECT `+importBatchColumns+` FROM import_batches WHERE id=$1 AND user_id=$2 FOR UPDATE`, id, userID)
+	b, err := scanImportBatch(row)
+	if err != nil {
+		return nil, err
+	}
+	if b.Status != imports.StatusPreview {
+		return nil, ErrBatchCommitted
+	}
+	var accountID int
+	if err := tx.QueryRow(ctx, `SELECT id FROM bank_accounts WHERE id=$1 AND user_id=$2 AND deleted_at IS NULL FOR UPDATE`, b.AccountID, userID).Scan(&accountID); err != nil {
+		return nil, err
+	}
+	rows, err := listImportRows(ctx, tx, b.ID, b.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	externalIDs := []string{}
+	for _, row := range rows {
+		if row.Importable() && row.ExternalID != "" {
+			externalIDs = append(externalIDs, row.ExternalID)
+		}
+	}
+	existing, err := existingExternalIDs(ctx, tx, b.AccountID, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	total := money.Zero
+	for i, row := range rows {
+		if !row.Importable() {
+			continue
+		}
+		if existing[row.ExternalID] {
+			if _, err := tx.Exec(ctx, `UPDATE import_rows SET already_imported=TRUE WHERE batch_id=$1 AND line=$2`, b.ID, row.Line); err != nil {
+				return nil, err
+			}
+			rows[i].AlreadyImported = true
+			continue
+		}
+		t := &transaction.Transaction{
+			UserID:    userID,
+			AccountID: b.AccountID,
+			Type:      transaction.TypeIncome,
+			Amount:    row.Amount,
+			Date:      row.Date,
+			Payee:     row.Payee,
+			Note:      row.Note,
+		}
+		if row.Amount.IsNegative() {
+			t.Type = transaction.TypeExpense
+		}
+		if row.ExternalID != "" {
+			t.ExternalID = &rows[i].ExternalID
+		}
+		if prepare != nil {
+			prepare(t)
+		}
+		created, err := insertTransaction(ctx, tx, t)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.Exec(ctx, `UPDATE import_rows SET transaction_id=$1 WHERE batch_id=$2 AND line=$3`, created.ID, b.ID, row.Line); err != nil {
+			return nil, err
+		}
+		rows[i].TransactionID = &created.ID
+		total = total.Add(row.Amount)
+	}
+	if err := adjustAccountBalance(ctx, tx, b.AccountID, userID, total); err != nil {
+		return nil, err
+	}
+	row = tx.QueryRow(ctx, `UPDATE import_batches SET status=$1, committed_at=NOW() WHERE id=$2 RETURNING `+importBatchColumns, imports.StatusCommitted, b.ID)
+	committed, err := scanImportBatch(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	committed.Rows = rows
+	return committed, nil
+}
+
+// insertImportBatch сохраняет пакет импорта в статусе preview и его строки.
+func insertImportBatch(ctx context.Context, tx pgx.Tx, b *imports.Batch) (*imports.Batch, error) {
+	openingAmount, openingDate := balanceColumns(b.OpeningBalance)
+	closingAmount, closingDate := balanceColumns(b.ClosingBalance)
+	row := tx.QueryRow(ctx, `INSERT INTO import_batches (user_id, account_id, format, file_name, status, opening_balance, opening_balance_date, closing_balance, closing_balance_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING `+importBatchColumns,
+		b.UserID, b.AccountID, b.Format, b.FileName, imports.StatusPreview, openingAmount, openingDate, closingAmount, closingDate)
+	created, err := scanImportBatch(row)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"import_rows"},
+		[]string{"batch_id", "line", "date", "amount", "payee", "note", "external_id", "already_imported", "duplicate_of", "duplicate_score", "error"},
+		pgx.CopyFromSlice(len(b.Rows), func(i int) ([]any, error) {
+			row := b.Rows[i]
+			var date *time.Time
+			var amount *money.Decimal
+			if row.Valid() {
+				date, amount = &row.Date, &row.Amount
+			}
+			return []any{created.ID, row.Line, date, amount, row.Payee, row.Note, row.ExternalID, row.AlreadyImported, row.DuplicateOf, row.DuplicateScore, row.Error}, nil
+		}))
+	if err != nil {
+		return nil, err
+	}
+	created.Rows = b.Rows
+	return created, nil
+}
+
+// balanceColumns раскладывает баланс выписки на значения колонок суммы и даты (NULL, если баланса нет).
+func balanceColumns(b *imports.Balance) (*money.Decimal, *time.Time) {
+	if b == nil {
+		return nil, nil
+	}
+	return &b.Amount, &b.Date
+}
+
+// listImportRows возвращает строки пакета по порядку; суммы приводятся к точности валюты аккаунта.
+func listImportRows(ctx context.Context, q querier, batchID, accountID int) ([]imports.Row, error) {
+	var currency string
+	if err := q.QueryRow(ctx, `SELECT currency FROM bank_accounts WHERE id=$1`, accountID).Scan(&currency); err != nil {
+		return nil, err
+	}
+	rows, err := q.Query(ctx, `SELECT `+importRowColumns+` FROM import_rows WHERE batch_id=$1 ORDER BY line`, batchID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []imports.Row{}
+	for rows.Next() {
+		var row imports.Row
+		var date *time.Time
+		var amount *money.Decimal
+		if err := rows.Scan(&row.Line, &date, &amount, &row.Payee, &row.Note, &row.ExternalID, &row.AlreadyImported, &row.DuplicateOf, &row.DuplicateScore, &row.Error, &row.TransactionID); err != nil {
+			return nil, err
+		}
+		if date != nil {
+			row.Date = *date
+		}
+		if amount != nil {
+			row.Amount = amount.Round(money.MinorUnits(currency))
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// existingExternalIDs возвращает те из externalIDs, для которых на аккаунте уже есть операции.
+func existingExternalIDs(ctx context.Context, q querier, accountID int, externalIDs []string) (map[string]bool, error) {
+	existing := map[string]bool{}
+	if len(externalIDs) == 0 {
+		return existing, nil
+	}
+	rows, err := q.Query(ctx, `SELECT external_id FROM transactions WHERE account_id=$1 AND external_id = ANY($2)`, accountID, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		existing[id] = true
+	}
+	return existing, rows.Err()
+}
+
+// scanImportProfile читает профиль импорта из строки результата (колонки importProfileColumns).
+func scanImportProfile(row pgx.Row) (*imports.Profile, error) {
+	var p imports.Profile
+	err := row.Scan(&p.ID, &p.UserID, &p.Name, &p.Delimiter, &p.Encoding, &p.SkipRows, &p.DateFormat, &p.DecimalSeparator, &p.SignConvention, &p.Columns, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// scanImportBatch читает пакет импорта из строки результата (колонки importBatchColumns).
+func scanImportBatch(row pgx.Row) (*imports.Batch, error) {
+	var b imports.Batch
+	var openingAmount, closingAmount *money.Decimal
+	var openingDate, closingDate *time.Time
+	err := row.Scan(&b.ID, &b.UserID, &b.AccountID, &b.Format, &b.FileName, &b.Status,
+		&openingAmount, &openingDate, &closingAmount, &closingDate, &b.CreatedAt, &b.CommittedAt)
+	if err != nil {
+		return nil, err
+	}
+	if openingAmount != nil && openingDate != nil {
+		b.OpeningBalance = &imports.Balance{Amount: *openingAmount, Date: *openingDate}
+	}
+	if closingAmount != nil && closingDate != nil {
+		b.ClosingBalance = &imports.Balance{Amount: *closingAmount, Date: *closingDate}
+	}
+	return &b, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrPasswordResetTokenInvalid возвращается, если токен сброса пароля не найден, уже использован или истёк.
+var ErrPasswordResetTokenInvalid = errors.New("password reset token is invalid")
+
+// PasswordResetRepository предоставляет методы для работы с токенами сброса пароля в БД.
+// Сами токены не хранятся — только их SHA-256 хеши.
+type PasswordResetRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewPasswordResetRepository создает новый экземпляр PasswordResetRepository.
+func NewPasswordResetRepository(db *pgxpool.Pool) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// Create сохраняет хеш нового токена сброса пароля. Неиспользованные токены пользователя,
+// выданные ранее, удаляются в той же транзакции: действует только ссылка из последнего письма.
+func (r *PasswordResetRepository) Create(ctx context.Context, userID int, tokenHash string, expiresAt, createdAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM password_reset_tokens WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4)`,
+		userID, tokenHash, expiresAt, createdAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// CountSince возвращает количество токенов сброса пароля, выданных пользователю начиная с since.
+func (r *PasswordResetRepository) CountSince(ctx context.Context, userID int, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM password_reset_tokens WHERE user_id = $1 AND created_at >= $2`, userID, since).Scan(&count)
+	return count, err
+}
+
+// ResetPassword в одной транзакции погашает токен с хешем tokenHash, меняет хеш пароля его владельца
+// и удаляет все refresh токены пользователя (выход на всех устройствах). Переход по ссылке из письма
+// подтверждает владение адресом, поэтому неподтверждённый email отмечается подтверждённым.
+// Возвращает ID пользователя или ErrPasswordResetTokenInvalid, если токен не найден, использован или истёк к моменту now.
+func (r *PasswordResetRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int
+	err = tx.QueryRow(ctx, `UPDATE password_reset_tokens SET used_at = $1
+		WHERE token_hash = $2 AND used_at IS NULL AND expires_at > $1 RETURNING user_id`, now, tokenHash).Scan(&userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrPasswordResetTokenInvalid
+	}
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE users SET password_hash = $1, email_verified_at = COALESCE(email_verified_at, $2) WHERE id = $3`,
+		passwordHash, now, userID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID); err != nil {
+		return 0, err
+	}
+	return userID, tx.Commit(ctx)
+}
+
+// DeleteExpired удаляет токены сброса пароля, срок действия которых истёк до before.
+// Возвращает количество удалённых токенов.
+func (r *PasswordResetRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM password_reset_tokens WHERE expires_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rate"
+)
+
+// rateColumns — список колонок, из которых собирается rate.Rate.
+const rateColumns = `base, quote, date, rate, source, updated_at`
+
+// RateRepository предоставляет методы для работы с курсами валют в БД.
+type RateRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewRateRepository создает новый экземпляр RateRepository.
+func NewRateRepository(db *pgxpool.Pool) *RateRepository {
+	return &RateRepository{db: db}
+}
+
+// Save сохраняет курсы в одной транзакции БД; курс той же пары за ту же дату заменяется.
+func (r *RateRepository) Save(ctx context.Context, rates []rate.Rate) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	for _, rt := range rates {
+		batch.Queue(`INSERT INTO exchange_rates (base, quote, date, rate, source) VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (base, quote, date) DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source, updated_at = NOW()`,
+			rt.Base, rt.Quote, rt.Date, rt.Rate, rt.Source)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Find возвращает последний курс пары base/quote на дату не позже date и не раньше notBefore.
+// Возвращает pgx.ErrNoRows, если такого курса нет.
+func (r *RateRepository) Find(ctx context.Context, base, quote string, date, notBefore time.Time) (*rate.Rate, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+rateColumns+` FROM exchange_rates
+		WHERE base=$1 AND quote=$2 AND date <= $3 AND date >= $4 ORDER BY date DESC LIMIT 1`, base, quote, date, notBefore)
+	return scanRate(row)
+}
+
+// List возвращает курсы пары base/quote за период по возрастанию даты.
+func (r *RateRepository) List(ctx context.Context, base, quote string, from, to time.Time) ([]rate.Rate, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+rateColumns+` FROM exchange_rates
+		WHERE base=$1 AND quote=$2 AND date BETWEEN $3 AND $4 ORDER BY date`, base, quote, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := []rate.Rate{}
+	for rows.Next() {
+		rt, err := scanRate(rows)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, *rt)
+	}
+	return rates, rows.Err()
+}
+
+// LatestDate возвращает дату последнего курса, загруженного источником source (nil — курсов нет).
+func (r *RateRepository) LatestDate(ctx context.Context, source string) (*time.Time, error) {
+	var date *time.Time
+	err := r.db.QueryRow(ctx, `SELECT MAX(date) FROM exchange_rates WHERE source=$1`, source).Scan(&date)
+	return date, err
+}
+
+// scanRate читает курс из строки результата (колонки rateColumns).
+func scanRate(row pgx.Row) (*rate.Rate, error) {
+	var rt rate.Rate
+	if err := row.Scan(&rt.Base, &rt.Quote, &rt.Date, &rt.Rate, &rt.Source, &rt.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &rt, nil
+}
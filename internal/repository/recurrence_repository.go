@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/recurrence"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+// recurrenceColumns — список колонок, из которых собирается recurrence.Recurrence.
+// Валюта берётся из аккаунта.
+const recurrenceColumns = `id, user_id, account_id, type, amount,
+	(SELECT a.currency FROM bank_accounts a WHERE a.id = recurrences.account_id),
+	payee, note, category_id, tags, frequency, repeat_interval, month_day, business_day, start_date, end_date,
+	mode, active, next_date, next_due, created_at, updated_at`
+
+// occurrenceColumns — список колонок, из которых собирается recurrence.Occurrence.
+const occurrenceColumns = `id, recurrence_id, user_id, date, due_date, status, transaction_id, created_at, updated_at`
+
+// ErrOccurrenceProcessed возвращается при попытке подтвердить или пропустить уже обработанный платёж.
+var ErrOccurrenceProcessed = errors.New("occurrence is not pending")
+
+// RecurrenceRepository предоставляет методы для работы с регулярными операциями и их платежами в БД.
+type RecurrenceRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewRecurrenceRepository создает новый экземпляр RecurrenceRepository.
+func NewRecurrenceRepository(db *pgxpool.Pool) *RecurrenceRepository {
+	return &RecurrenceRepository{db: db}
+}
+
+// Create сохраняет регулярную операцию вместе с датой первого платежа.
+func (r *RecurrenceRepository) Create(ctx context.Context, rec *recurrence.Recurrence) (*recurrence.Recurrence, error) {
+	rec.Schedule(nil)
+	row := r.db.QueryRow(ctx, `INSERT INTO recurrences (user_id, account_id, type, amount, payee, note, category_id, tags,
+			frequency, repeat_interval, month_day, business_day, start_date, end_date, mode, active, next_date, next_due)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING `+recurrenceColumns,
+		rec.UserID, rec.AccountID, rec.Type, rec.Amount, rec.Payee, rec.Note, rec.CategoryID, tagsValue(rec.Tags),
+		rec.Frequency, rec.Interval, rec.MonthDay, rec.BusinessDay, rec.StartDate, rec.EndDate, rec.Mode, rec.Active, rec.NextDate, rec.NextDue)
+	return scanRecurrence(row)
+}
+
+// GetByID возвращает регулярную операцию по id и user_id.
+func (r *RecurrenceRepository) GetByID(ctx context.Context, id, userID int) (*recurrence.Recurrence, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+recurrenceColumns+` FROM recurrences WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanRecurrence(row)
+}
+
+// List возвращает регулярные операции пользователя, отсортированные по дате следующего платежа.
+func (r *RecurrenceRepository) List(ctx context.Context, userID int) ([]recurrence.Recurrence, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+recurrenceColumns+` FROM recurrences WHERE user_id=$1 ORDER BY next_due NULLS LAST, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	recurrences := []recurrence.Recurrence{}
+	for rows.Next() {
+		rec, err := scanRecurrence(rows)
+		if err != nil {
+			return nil, err
+		}
+		recurrences = append(recurrences, *rec)
+	}
+	return recurrences, rows.Err()
+}
+
+// Update изменяет регулярную операцию и пересчитывает дату следующего платежа: она идёт после последнего
+// уже созданного платежа. При включении приостановленного расписания платежи до today не создаются.
+// Возвращает pgx.ErrNoRows, если регулярная операция не найдена.
+func (r *RecurrenceRepository) Update(ctx context.Context, rec *recurrence.Recurrence, today time.Time) (*recurrence.Recurrence, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	var wasActive bool
+	if err := tx.QueryRow(ctx, `SELECT active FROM recurrences WHERE id=$1 AND user_id=$2 FOR UPDATE`, rec.ID, rec.UserID).Scan(&wasActive); err != nil {
+		return nil, err
+	}
+	var last *time.Time
+	if err := tx.QueryRow(ctx, `SELECT MAX(date) FROM recurrence_occurrences WHERE recurrence_id=$1`, rec.ID).Scan(&last); err != nil {
+		return nil, err
+	}
+	if !wasActive && rec.Active {
+		if yesterday := today.AddDate(0, 0, -1); last == nil || last.Before(yesterday) {
+			last = &yesterday
+		}
+	}
+	rec.Schedule(last)
+	row := tx.QueryRow(ctx, `UPDATE recurrences SET account_id=$1, type=$2, amount=$3, payee=$4, note=$5, category_id=$6, tags=$7,
+			frequency=$8, repeat_interval=$9, month_day=$10, business_day=$11, start_date=$12, end_date=$13, mode=$14, active=$15,
+			next_date=$16, next_due=$17, updated_at=NOW()
+		WHERE id=$18 RETURNING `+recurrenceColumns,
+		rec.AccountID, rec.Type, rec.Amount, rec.Payee, rec.Note, rec.CategoryID, tagsValue(rec.Tags),
+		rec.Frequency, rec.Interval, rec.MonthDay, rec.BusinessDay, rec.StartDate, rec.EndDate, rec.Mode, rec.Active,
+		rec.NextDate, rec.NextDue, rec.ID)
+	updated, err := scanRecurrence(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete удаляет регулярную операцию вместе с неподтверждёнными платежами; созданные операции остаются.
+// Возвращает pgx.ErrNoRows, если регулярная операция не найдена.
+func (r *RecurrenceRepository) Delete(ctx context.Context, id, userID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM recurrences WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// ListDue возвращает id включённых регулярных операций, у которых дата следующего платежа не позже today.
+func (r *RecurrenceRepository) ListDue(ctx context.Context, today time.Time, limit int) ([]int, error) {
+	rows, err := r.db.Query(ctx, `SELECT id FROM recurrences r WHERE active AND next_due <= $1
+		AND EXISTS (SELECT 1 FROM bank_accounts a WHERE a.id = r.account_id AND a.deleted_at IS NULL)
+		ORDER BY next_due, id LIMIT $2`, today, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PostDue создает все наступившие к today платежи регулярной операции, но не больше limit за вызов:
+// в режиме auto — операции с изменением баланса, в режиме suggest — предложения. Платежи и сдвиг даты
+// следующего платежа сохраняются в одной транзакции БД. Строка регулярной операции блокируется с SKIP LOCKED,
+// поэтому при нескольких экземплярах приложения каждый платёж создаётся один раз.
+// Возвращает количество созданных платежей.
+func (r *RecurrenceRepository) PostDue(ctx context.Context, id int, today time.Time, limit int) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	row := tx.QueryRow(ctx, `SELECT `+recurrenceColumns+` FROM recurrences WHERE id=$1 AND active AND next_due <= $2 FOR UPDATE SKIP LOCKED`, id, today)
+	rec, err := scanRecurrence(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Платежи уже созданы или создаются другим экземпляром приложения
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for rec.NextDate != nil && !rec.NextDue.After(today) && posted < limit {
+		o := recurrence.Occurrence{RecurrenceID: rec.ID, UserID: rec.UserID, Date: *rec.NextDate, DueDate: *rec.NextDue, Status: recurrence.StatusPending}
+		if rec.Mode == recurrence.ModeAuto {
+			t := rec.Transaction(o.DueDate)
+			if err := adjustAccountBalance(ctx, tx, t.AccountID, t.UserID, t.Amount); err != nil {
+				return 0, err
+			}
+			created, err := insertTransaction(ctx, tx, &t)
+			if err != nil {
+				return 0, err
+			}
+			o.Status, o.TransactionID = recurrence.StatusPosted, &created.ID
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO recurrence_occurrences (recurrence_id, user_id, date, due_date, status, transaction_id)
+			VALUES ($1, $2, $3, $4, $5, $6)`, o.RecurrenceID, o.UserID, o.Date, o.DueDate, o.Status, o.TransactionID); err != nil {
+			return 0, err
+		}
+		rec.Schedule(rec.NextDate)
+		posted++
+	}
+	if _, err := tx.Exec(ctx, `UPDATE recurrences SET next_date=$1, next_due=$2 WHERE id=$3`, rec.NextDate, rec.NextDue, rec.ID); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return posted, nil
+}
+
+// GetOccurrence возвращает платёж по id и user_id.
+func (r *RecurrenceRepository) GetOccurrence(ctx context.Context, id, userID int) (*recurrence.Occurrence, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+occurrenceColumns+` FROM recurrence_occurrences WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanOccurrence(row)
+}
+
+// ListOccurrences возвращает платежи пользователя по дате платежа. Пустой status — все статусы,
+// recurrenceID = 0 — платежи всех регулярных операций.
+func (r *RecurrenceRepository) ListOccurrences(ctx context.Context, userID, recurrenceID int, status string) ([]recurrence.Occurrence, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+occurrenceColumns+` FROM recurrence_occurrences
+		WHERE user_id=$1 AND ($2 = 0 OR recurrence_id = $2) AND ($3 = '' OR status = $3)
+		ORDER BY due_date, id`, userID, recurrenceID, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	occurrences := []recurrence.Occurrence{}
+	for rows.Next() {
+		o, err := scanOccurrence(rows)
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, *o)
+	}
+	return occurrences, rows.Err()
+}
+
+// ConfirmOccurrence создает операцию по ожидающему платежу и изменяет баланс аккаунта в одной транзакции БД.
+// Возвращает ErrOccurrenceProcessed, если платёж уже подтверждён или пропущен.
+func (r *RecurrenceRepository) ConfirmOccurrence(ctx context.Context, id, userID int, t *transaction.Transaction) (*transaction.Transaction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := adjustAccountBalance(ctx, tx, t.AccountID, userID, t.Amount); err != nil {
+		return nil, err
+	}
+	created, err := insertTransaction(ctx, tx, t)
+	if err != nil {
+		return nil, err
+	}
+	tag, err := tx.Exec(ctx, `UPDATE recurrence_occurrences SET status=$1, transaction_id=$2, updated_at=NOW()
+		WHERE id=$3 AND user_id=$4 AND status=$5`, recurrence.StatusPosted, created.ID, id, userID, recurrence.StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, ErrOccurrenceProcessed
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// SkipOccurrence помечает ожидающий платёж пропущенным.
+// Возвращает ErrOccurrenceProcessed, если платёж уже подтверждён или пропущен.
+func (r *RecurrenceRepository) SkipOccurrence(ctx context.Context, id, userID int) (*recurrence.Occurrence, error) {
+	row := r.db.QueryRow(ctx, `UPDATE recurrence_occurrences SET status=$1, updated_at=NOW()
+		WHERE id=$2 AND user_id=$3 AND status=$4 RETURNING `+occurrenceColumns, recurrence.StatusSkipped, id, userID, recurrence.StatusPending)
+	o, err := scanOccurrence(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOccurrenceProcessed
+	}
+	return o, err
+}
+
+// scanRecurrence читает регулярную операцию из строки результата (колонки recurrenceColumns).
+// Сумма приводится к точности валюты аккаунта.
+func scanRecurrence(row pgx.Row) (*recurrence.Recurrence, error) {
+	var rec recurrence.Recurrence
+	err := row.Scan(&rec.ID, &rec.UserID, &rec.AccountID, &rec.Type, &rec.Amount, &rec.Currency, &rec.Payee, &rec.Note, &rec.CategoryID, &rec.Tags,
+		&rec.Frequency, &rec.Interval, &rec.MonthDay, &rec.BusinessDay, &rec.StartDate, &rec.EndDate,
+		&rec.Mode, &rec.Active, &rec.NextDate, &rec.NextDue, &rec.CreatedAt, &rec.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	rec.Amount = rec.Amount.Round(money.MinorUnits(rec.Currency))
+	return &rec, nil
+}
+
+// scanOccurrence читает платёж из строки результата (колонки occurrenceColumns).
+func scanOccurrence(row pgx.Row) (*recurrence.Occurrence, error) {
+	var o recurrence.Occurrence
+	err := row.Scan(&o.ID, &o.RecurrenceID, &o.UserID, &o.Date, &o.DueDate, &o.Status, &o.TransactionID, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
@@ -2,12 +2,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
 )
 
+// ErrRefreshTokenRotated возвращается, если refresh токен уже был ротирован ранее.
+var ErrRefreshTokenRotated = errors.New("refresh token already rotated")
+
 // RefreshTokenRepository предоставляет методы для работы с refresh токенами в БД.
 type RefreshTokenRepository struct {
 	db *pgxpool.Pool // Пул соединений с БД
@@ -18,23 +22,96 @@ func NewRefreshTokenRepository(db *pgxpool.Pool) *RefreshTokenRepository {
 	return &RefreshTokenRepository{db: db}
 }
 
-// Save сохраняет refresh токен в базе данных.
-func (r *RefreshTokenRepository) Save(ctx context.Context, userID int, tokenStr string, expiresAt, createdAt time.Time) error {
-	_, err := r.db.Exec(ctx, `INSERT INTO refresh_tokens (user_id, token, expires_at, created_at) VALUES ($1, $2, $3, $4)`, userID, tokenStr, expiresAt, createdAt)
+// Save сохраняет refresh токен в базе данных вместе с данными клиента.
+func (r *RefreshTokenRepository) Save(ctx context.Context, userID int, tokenStr, familyID string, client token.ClientInfo, expiresAt, createdAt time.Time) error {
+	_, err := r.db.Exec(ctx, `INSERT INTO refresh_tokens (user_id, token, family_id, user_agent, ip, device_label, expires_at, created_at, last_used_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`,
+		userID, tokenStr, familyID, client.UserAgent, client.IP, client.DeviceLabel, expiresAt, createdAt)
 	return err
 }
 
+// Rotate помечает старый refresh токен как ротированный и сохраняет новый токен того же семейства в одной транзакции.
+// Название устройства наследуется от старого токена, User-Agent и IP обновляются по текущему клиенту.
+// Если старый токен уже был ротирован (параллельный запрос), возвращает ErrRefreshTokenRotated.
+func (r *RefreshTokenRepository) Rotate(ctx context.Context, old *token.RefreshToken, newToken string, client token.ClientInfo, expiresAt, createdAt time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE refresh_tokens SET rotated_at = $1 WHERE id = $2 AND rotated_at IS NULL`, createdAt, old.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRefreshTokenRotated
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO refresh_tokens (user_id, token, family_id, user_agent, ip, device_label, expires_at, created_at, last_used_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)`,
+		old.UserID, newToken, old.FamilyID, client.UserAgent, client.IP, old.DeviceLabel, expiresAt, createdAt)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
 // Delete удаляет refresh токен из базы данных по значению токена.
 func (r *RefreshTokenRepository) Delete(ctx context.Context, tokenStr string) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE token = $1`, tokenStr)
 	return err
 }
 
+// DeleteFamily удаляет все refresh токены семейства (отзыв всей цепочки ротаций).
+func (r *RefreshTokenRepository) DeleteFamily(ctx context.Context, familyID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE family_id = $1`, familyID)
+	return err
+}
+
+// DeleteUserFamily удаляет все refresh токены семейства, принадлежащего пользователю.
+// Возвращает false, если у пользователя нет такого семейства.
+func (r *RefreshTokenRepository) DeleteUserFamily(ctx context.Context, userID int, familyID string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE family_id = $1 AND user_id = $2`, familyID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// DeleteAllForUser удаляет все refresh токены пользователя (выход на всех устройствах).
+func (r *RefreshTokenRepository) DeleteAllForUser(ctx context.Context, userID int) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM refresh_tokens WHERE user_id = $1`, userID)
+	return err
+}
+
+// ListActiveSessions возвращает активные сессии пользователя: по одной на каждое семейство с действующим токеном.
+func (r *RefreshTokenRepository) ListActiveSessions(ctx context.Context, userID int) ([]token.Session, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT t.family_id, t.user_agent, t.ip, t.device_label,
+		       (SELECT MIN(f.created_at) FROM refresh_tokens f WHERE f.family_id = t.family_id),
+		       t.last_used_at, t.expires_at
+		FROM refresh_tokens t
+		WHERE t.user_id = $1 AND t.rotated_at IS NULL AND t.expires_at > NOW()
+		ORDER BY t.last_used_at DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []token.Session{}
+	for rows.Next() {
+		var s token.Session
+		if err := rows.Scan(&s.ID, &s.UserAgent, &s.IP, &s.DeviceLabel, &s.CreatedAt, &s.LastUsedAt, &s.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
+
 // FindByToken ищет refresh токен по значению токена.
 func (r *RefreshTokenRepository) FindByToken(ctx context.Context, tokenStr string) (*token.RefreshToken, error) {
-	row := r.db.QueryRow(ctx, `SELECT id, user_id, token, expires_at, created_at FROM refresh_tokens WHERE token = $1`, tokenStr)
+	row := r.db.QueryRow(ctx, `SELECT id, user_id, token, family_id, user_agent, ip, device_label, expires_at, created_at, last_used_at, rotated_at FROM refresh_tokens WHERE token = $1`, tokenStr)
 	var rt token.RefreshToken
-	err := row.Scan(&rt.ID, &rt.UserID, &rt.Token, &rt.ExpiresAt, &rt.CreatedAt)
+	err := row.Scan(&rt.ID, &rt.UserID, &rt.Token, &rt.FamilyID, &rt.UserAgent, &rt.IP, &rt.DeviceLabel, &rt.ExpiresAt, &rt.CreatedAt, &rt.LastUsedAt, &rt.RotatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rule"
+)
+
+// ruleColumns — список колонок, из которых собирается rule.Rule.
+const ruleColumns = `id, user_id, position, name, enabled, payee_pattern, note_pattern, min_amount, max_amount,
+	account_id, type, category_id, tags, rename_payee, created_at, updated_at`
+
+// ErrRuleOrderMismatch возвращается, если новый порядок правил содержит не все правила пользователя или лишние id.
+var ErrRuleOrderMismatch = errors.New("rule order must list every rule exactly once")
+
+// RuleRepository предоставляет методы для работы с правилами категоризации в БД.
+type RuleRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewRuleRepository создает новый экземпляр RuleRepository.
+func NewRuleRepository(db *pgxpool.Pool) *RuleRepository {
+	return &RuleRepository{db: db}
+}
+
+// Create сохраняет правило в конец списка правил пользователя.
+func (r *RuleRepository) Create(ctx context.Context, rl *rule.Rule) (*rule.Rule, error) {
+	row := r.db.QueryRow(ctx, `INSERT INTO rules (user_id, position, name, enabled, payee_pattern, note_pattern, min_amount, max_amount,
+			account_id, type, category_id, tags, rename_payee)
+		VALUES ($1, (SELECT COALESCE(MAX(position), 0) + 1 FROM rules WHERE user_id = $1), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING `+ruleColumns,
+		rl.UserID, rl.Name, rl.Enabled, rl.PayeePattern, rl.NotePattern, rl.MinAmount, rl.MaxAmount,
+		rl.AccountID, rl.Type, rl.CategoryID, tagsValue(rl.Tags), rl.RenamePayee)
+	return scanRule(row)
+}
+
+// GetByID возвращает правило по id и user_id.
+func (r *RuleRepository) GetByID(ctx context.Context, id, userID int) (*rule.Rule, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+ruleColumns+` FROM rules WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanRule(row)
+}
+
+// List возвращает правила пользователя в порядке применения.
+func (r *RuleRepository) List(ctx context.Context, userID int) ([]rule.Rule, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+ruleColumns+` FROM rules WHERE user_id=$1 ORDER BY position, id`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []rule.Rule{}
+	for rows.Next() {
+		rl, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rl)
+	}
+	return rules, rows.Err()
+}
+
+// Update изменяет условия и действия правила; позиция не меняется.
+func (r *RuleRepository) Update(ctx context.Context, rl *rule.Rule) (*rule.Rule, error) {
+	row := r.db.QueryRow(ctx, `UPDATE rules SET name=$1, enabled=$2, payee_pattern=$3, note_pattern=$4, min_amount=$5, max_amount=$6,
+			account_id=$7, type=$8, category_id=$9, tags=$10, rename_payee=$11, updated_at=NOW()
+		WHERE id=$12 AND user_id=$13 RETURNING `+ruleColumns,
+		rl.Name, rl.Enabled, rl.PayeePattern, rl.NotePattern, rl.MinAmount, rl.MaxAmount,
+		rl.AccountID, rl.Type, rl.CategoryID, tagsValue(rl.Tags), rl.RenamePayee, rl.ID, rl.UserID)
+	return scanRule(row)
+}
+
+// Reorder задаёт порядок применения правил: ids должны перечислять все правила пользователя ровно по одному разу.
+func (r *RuleRepository) Reorder(ctx context.Context, userID int, ids []int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id FROM rules WHERE user_id=$1 FOR UPDATE`, userID)
+	if err != nil {
+		return err
+	}
+	existing := map[int]bool{}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if len(ids) != len(existing) {
+		return ErrRuleOrderMismatch
+	}
+	for i, id := range ids {
+		if !existing[id] {
+			return ErrRuleOrderMismatch
+		}
+		delete(existing, id)
+		if _, err := tx.Exec(ctx, `UPDATE rules SET position=$1, updated_at=NOW() WHERE id=$2`, i+1, id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// Delete удаляет правило. Возвращает pgx.ErrNoRows, если правило не найдено.
+func (r *RuleRepository) Delete(ctx context.Context, id, userID int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM rules WHERE id=$1 AND user_id=$2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// scanRule читает правило из строки результата (колонки ruleColumns).
+func scanRule(row pgx.Row) (*rule.Rule, error) {
+	var rl rule.Rule
+	err := row.Scan(&rl.ID, &rl.UserID, &rl.Position, &rl.Name, &rl.Enabled, &rl.PayeePattern, &rl.NotePattern, &rl.MinAmount, &rl.MaxAmount,
+		&rl.AccountID, &rl.Type, &rl.CategoryID, &rl.Tags, &rl.RenamePayee, &rl.CreatedAt, &rl.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &rl, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/networth"
+)
+
+// snapshotColumns — список колонок, из которых собирается networth.Snapshot.
+// Признак обязательства берётся по текущему типу аккаунта, поэтому выборки соединяются с bank_accounts.
+const snapshotColumns = `s.account_id, s.user_id, s.date, s.balance, s.currency, a.type`
+
+// SnapshotRepository предоставляет методы для работы со снимками балансов аккаунтов в БД.
+type SnapshotRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewSnapshotRepository создает новый экземпляр SnapshotRepository.
+func NewSnapshotRepository(db *pgxpool.Pool) *SnapshotRepository {
+	return &SnapshotRepository{db: db}
+}
+
+// Capture сохраняет текущие балансы всех аккаунтов как снимки на дату date.
+// Снимок за ту же дату заменяется, поэтому в течение дня в нём остаётся последний баланс.
+// Возвращает количество сохранённых снимков.
+func (r *SnapshotRepository) Capture(ctx context.Context, date time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `INSERT INTO balance_snapshots (account_id, user_id, date, balance, currency)
+		SELECT id, user_id, $1, balance, currency FROM bank_accounts WHERE deleted_at IS NULL
+		ON CONFLICT (account_id, date) DO UPDATE SET balance = EXCLUDED.balance, currency = EXCLUDED.currency`, date)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// History возвращает снимки аккаунтов пользователя, нужные для расчёта капитала за период [from, to]:
+// последний снимок каждого аккаунта не позже from и все снимки после from до to включительно.
+// Снимки упорядочены по дате и ID аккаунта.
+func (r *SnapshotRepository) History(ctx context.Context, userID int, from, to time.Time) ([]networth.Snapshot, error) {
+	rows, err := r.db.Query(ctx, `SELECT * FROM (
+			SELECT DISTINCT ON (s.account_id) `+snapshotColumns+`
+			FROM balance_snapshots s JOIN bank_accounts a ON a.id = s.account_id
+			WHERE s.user_id=$1 AND s.date <= $2 AND a.deleted_at IS NULL ORDER BY s.account_id, s.date DESC
+		) initial
+		UNION ALL
+		SELECT `+snapshotColumns+` FROM balance_snapshots s JOIN bank_accounts a ON a.id = s.account_id
+		WHERE s.user_id=$1 AND s.date > $2 AND s.date <= $3 AND a.deleted_at IS NULL
+		ORDER BY date, account_id`, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []networth.Snapshot
+	for rows.Next() {
+		s, err := scanSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, *s)
+	}
+	return snapshots, rows.Err()
+}
+
+// scanSnapshot читает снимок баланса из строки результата (колонки snapshotColumns).
+func scanSnapshot(row pgx.Row) (*networth.Snapshot, error) {
+	var s networth.Snapshot
+	var accountType string
+	if err := row.Scan(&s.AccountID, &s.UserID, &s.Date, &s.Balance, &s.Currency, &accountType); err != nil {
+		return nil, err
+	}
+	s.Liability = account.IsLiability(accountType)
+	return &s, nil
+}
@@ -0,0 +1,381 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+// transactionColumns — список колонок, из которых собирается transaction.Transaction.
+// Валюта берётся из аккаунта операции.
+const transactionColumns = `id, user_id, account_id, type, amount,
+	(SELECT a.currency FROM bank_accounts a WHERE a.id = transactions.account_id),
+	date, payee, note, category_id, transfer_id, external_id, tags, created_at, updated_at`
+
+// transactionCursorSort — идентификатор сортировки в курсоре списка операций.
+const transactionCursorSort = "date"
+
+// transactionMergeColumns — список колонок, из которых собирается transaction.Merge.
+const transactionMergeColumns = `id, user_id, transaction_id, merged, created_at`
+
+var (
+	// ErrMergeNotAllowed возвращается при попытке объединить часть перевода или корректировку баланса.
+	ErrMergeNotAllowed = errors.New("only income and expense transactions can be merged")
+	// ErrMergeMismatch возвращается, если объединяемые операции относятся к разным аккаунтам или различаются суммой.
+	ErrMergeMismatch = errors.New("merged transactions must have the same account and amount")
+)
+
+// TransactionRepository предоставляет методы для работы с операциями в БД.
+// Все изменения операций выполняются в одной транзакции БД с изменением баланса аккаунта.
+type TransactionRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewTransactionRepository создает новый экземпляр TransactionRepository.
+func NewTransactionRepository(db *pgxpool.Pool) *TransactionRepository {
+	return &TransactionRepository{db: db}
+}
+
+// Create сохраняет операцию и изменяет баланс аккаунта на её сумму.
+// Возвращает pgx.ErrNoRows, если аккаунт не принадлежит пользователю.
+func (r *TransactionRepository) Create(ctx context.Context, t *transaction.Transaction) (*transaction.Transaction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := adjustAccountBalance(ctx, tx, t.AccountID, t.UserID, t.Amount); err != nil {
+		return nil, err
+	}
+	created, err := insertTransaction(ctx, tx, t)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetByID возвращает операцию по id и user_id.
+func (r *TransactionRepository) GetByID(ctx context.Context, id, userID int) (*transaction.Transaction, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+transactionColumns+` FROM transactions WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanTransaction(row)
+}
+
+// List возвращает страницу операций пользователя от новых к старым и курсор следующей страницы.
+func (r *TransactionRepository) List(ctx context.Context, userID int, filter transaction.ListFilter) ([]transaction.Transaction, string, error) {
+	// Операции удалённых аккаунтов скрыты, пока аккаунт можно восстановить
+	conditions := []string{"user_id = $1", "account_id NOT IN (SELECT id FROM bank_accounts WHERE user_id = $1 AND deleted_at IS NOT NULL)"}
+	args := []any{userID}
+	if filter.AccountID != 0 {
+		args = append(args, filter.AccountID)
+		conditions = append(conditions, fmt.Sprintf("account_id = $%d", len(args)))
+	}
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+	}
+	if filter.CategoryID != 0 {
+		categoryIDs, err := descendantCategoryIDs(ctx, r.db, userID, filter.CategoryID)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, categoryIDs)
+		conditions = append(conditions, fmt.Sprintf("category_id = ANY($%d)", len(args)))
+	}
+	if filter.Tag != "" {
+		args = append(args, filter.Tag)
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(tags)", len(args)))
+	}
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		conditions = append(conditions, fmt.Sprintf("date >= $%d", len(args)))
+	}
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		conditions = append(conditions, fmt.Sprintf("date <= $%d", len(args)))
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor, transactionCursorSort)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, cursor.Value, cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(date, id) < ($%d::date, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit+1)
+	query := fmt.Sprintf(`SELECT %s FROM transactions WHERE %s ORDER BY date DESC, id DESC LIMIT $%d`,
+		transactionColumns, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	transactions := []transaction.Transaction{}
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		transactions = append(transactions, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(transactions) > filter.Limit {
+		transactions = transactions[:filter.Limit]
+		last := transactions[len(transactions)-1]
+		nextCursor = encodeCursor(pageCursor{Sort: transactionCursorSort, Value: last.Date.Format(time.DateOnly), ID: last.ID})
+	}
+	return transactions, nextCursor, nil
+}
+
+// Update изменяет операцию: сумма старой версии возвращается на баланс её аккаунта,
+// сумма новой версии применяется к балансу (возможно другого) аккаунта.
+func (r *TransactionRepository) Update(ctx context.Context, t *transaction.Transaction) (*transaction.Transaction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	old, err := scanTransaction(tx.QueryRow(ctx, `SELECT `+transactionColumns+` FROM transactions WHERE id=$1 AND user_id=$2 FOR UPDATE`, t.ID, t.UserID))
+	if err != nil {
+		return nil, err
+	}
+	if err := adjustAccountBalance(ctx, tx, old.AccountID, old.UserID, old.Amount.Neg()); err != nil {
+		return nil, err
+	}
+	if err := adjustAccountBalance(ctx, tx, t.AccountID, t.UserID, t.Amount); err != nil {
+		return nil, err
+	}
+	row := tx.QueryRow(ctx, `UPDATE transactions SET account_id=$1, type=$2, amount=$3, date=$4, payee=$5, note=$6, category_id=$7, tags=$8, updated_at=NOW()
+		WHERE id=$9 AND user_id=$10 RETURNING `+transactionColumns,
+		t.AccountID, t.Type, t.Amount, t.Date, t.Payee, t.Note, t.CategoryID, tagsValue(t.Tags), t.ID, t.UserID)
+	updated, err := scanTransaction(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// Delete удаляет операцию и возвращает её сумму с баланса аккаунта.
+// Возвращает pgx.ErrNoRows, если операция не найдена у пользователя.
+func (r *TransactionRepository) Delete(ctx context.Context, id, userID int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var accountID int
+	var amount money.Decimal
+	err = tx.QueryRow(ctx, `DELETE FROM transactions WHERE id=$1 AND user_id=$2 RETURNING account_id, amount`, id, userID).Scan(&accountID, &amount)
+	if err != nil {
+		return err
+	}
+	if err := adjustAccountBalance(ctx, tx, accountID, userID, amount.Neg()); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ListInRange возвращает доходы и расходы пользователя за период для поиска дубликатов, не больше limit операций.
+// accountID 0 означает все аккаунты.
+func (r *TransactionRepository) ListInRange(ctx context.Context, userID, accountID int, from, to time.Time, limit int) ([]transaction.Transaction, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+transactionColumns+` FROM transactions
+		WHERE user_id=$1 AND ($2 = 0 OR account_id=$2) AND date BETWEEN $3 AND $4 AND type IN ($5, $6)
+		ORDER BY date, id LIMIT $7`,
+		userID, accountID, from, to, transaction.TypeIncome, transaction.TypeExpense, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	transactions := []transaction.Transaction{}
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, *t)
+	}
+	return transactions, rows.Err()
+}
+
+// Merge объединяет операцию sourceID с дубликатом targetID: пустые контрагент, комментарий, категория и
+// идентификатор банка у targetID заполняются из sourceID, строки импорта переносятся на targetID,
+// sourceID удаляется, а её сумма возвращается с баланса аккаунта. Копия удалённой операции
+// сохраняется в журнале объединений. Всё выполняется в одной транзакции БД.
+// Возвращает pgx.ErrNoRows, если одной из операций нет у пользователя.
+func (r *TransactionRepository) Merge(ctx context.Context, userID, sourceID, targetID int) (*transaction.Transaction, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT `+transactionColumns+` FROM transactions WHERE id = ANY($1) AND user_id=$2 ORDER BY id FOR UPDATE`, []int{sourceID, targetID}, userID)
+	if err != nil {
+		return nil, err
+	}
+	locked := map[int]*transaction.Transaction{}
+	for rows.Next() {
+		t, err := scanTransaction(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		locked[t.ID] = t
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	source, target := locked[sourceID], locked[targetID]
+	if source == nil || target == nil {
+		return nil, pgx.ErrNoRows
+	}
+	for _, t := range []*transaction.Transaction{source, target} {
+		if t.TransferID != nil || (t.Type != transaction.TypeIncome && t.Type != transaction.TypeExpense) {
+			return nil, ErrMergeNotAllowed
+		}
+	}
+	if source.AccountID != target.AccountID || !source.Amount.Equal(target.Amount) {
+		return nil, ErrMergeMismatch
+	}
+
+	if _, err := tx.Exec(ctx, `INSERT INTO transaction_merges (user_id, transaction_id, merged) VALUES ($1, $2, $3)`, userID, targetID, source); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE import_rows SET transaction_id=$1 WHERE transaction_id=$2`, targetID, sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `UPDATE import_rows SET duplicate_of=$1 WHERE duplicate_of=$2`, targetID, sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM transactions WHERE id=$1`, sourceID); err != nil {
+		return nil, err
+	}
+	if err := adjustAccountBalance(ctx, tx, source.AccountID, userID, source.Amount.Neg()); err != nil {
+		return nil, err
+	}
+	row := tx.QueryRow(ctx, `UPDATE transactions SET
+		payee = CASE WHEN payee = '' THEN $1 ELSE payee END,
+		note = CASE WHEN note = '' THEN $2 ELSE note END,
+		category_id = COALESCE(category_id, $3),
+		external_id = COALESCE(external_id, $4),
+		updated_at = NOW()
+		WHERE id=$5 RETURNING `+transactionColumns,
+		source.Payee, source.Note, source.CategoryID, source.ExternalID, targetID)
+	merged, err := scanTransaction(row)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// ListMerges возвращает журнал объединений дубликатов пользователя от новых записей к старым.
+func (r *TransactionRepository) ListMerges(ctx context.Context, userID int) ([]transaction.Merge, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+transactionMergeColumns+` FROM transaction_merges WHERE user_id=$1 ORDER BY created_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	merges := []transaction.Merge{}
+	for rows.Next() {
+		var m transaction.Merge
+		if err := rows.Scan(&m.ID, &m.UserID, &m.TransactionID, &m.Merged, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		merges = append(merges, m)
+	}
+	return merges, rows.Err()
+}
+
+// UpdateCategorization сохраняет контрагента, категорию и теги операций, изменённых правилами, в одной транзакции БД.
+// Суммы и балансы не меняются.
+func (r *TransactionRepository) UpdateCategorization(ctx context.Context, userID int, transactions []transaction.Transaction) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, t := range transactions {
+		_, err := tx.Exec(ctx, `UPDATE transactions SET payee=$1, category_id=$2, tags=$3, updated_at=NOW() WHERE id=$4 AND user_id=$5`,
+			t.Payee, t.CategoryID, tagsValue(t.Tags), t.ID, userID)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CountByAccount возвращает количество операций по аккаунту.
+func (r *TransactionRepository) CountByAccount(ctx context.Context, accountID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM transactions WHERE account_id=$1`, accountID).Scan(&count)
+	return count, err
+}
+
+// insertTransaction вставляет операцию, не изменяя баланс аккаунта.
+func insertTransaction(ctx context.Context, q querier, t *transaction.Transaction) (*transaction.Transaction, error) {
+	row := q.QueryRow(ctx, `INSERT INTO transactions (user_id, account_id, type, amount, date, payee, note, category_id, transfer_id, external_id, tags)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING `+transactionColumns,
+		t.UserID, t.AccountID, t.Type, t.Amount, t.Date, t.Payee, t.Note, t.CategoryID, t.TransferID, t.ExternalID, tagsValue(t.Tags))
+	return scanTransaction(row)
+}
+
+// tagsValue возвращает теги для записи в колонку TEXT[] NOT NULL: nil заменяется пустым массивом.
+func tagsValue(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// adjustAccountBalance изменяет баланс аккаунта на delta, блокируя строку аккаунта до конца транзакции.
+// Возвращает pgx.ErrNoRows, если аккаунт не принадлежит пользователю или удалён.
+func adjustAccountBalance(ctx context.Context, q querier, accountID, userID int, delta money.Decimal) error {
+	tag, err := q.Exec(ctx, `UPDATE bank_accounts SET balance = balance + $1, version = version + 1, updated_at = NOW() WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL`, delta, accountID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// scanTransaction читает операцию из строки результата (колонки transactionColumns).
+// Сумма приводится к точности валюты аккаунта.
+func scanTransaction(row pgx.Row) (*transaction.Transaction, error) {
+	var t transaction.Transaction
+	err := row.Scan(&t.ID, &t.UserID, &t.AccountID, &t.Type, &t.Amount, &t.Currency, &t.Date, &t.Payee, &t.Note, &t.CategoryID, &t.TransferID, &t.ExternalID, &t.Tags, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	t.Amount = t.Amount.Round(money.MinorUnits(t.Currency))
+	return &t, nil
+}
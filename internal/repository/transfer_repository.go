@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transfer"
+)
+
+// ErrForeignAccount возвращается, если один из аккаунтов перевода принадлежит другому пользователю.
+var ErrForeignAccount = errors.New("account belongs to another user")
+
+// transferColumns — список колонок, из которых собирается transfer.Transfer.
+const transferColumns = `id, user_id, from_account_id, from_amount,
+	(SELECT a.currency FROM bank_accounts a WHERE a.id = transfers.from_account_id),
+	to_account_id, to_amount,
+	(SELECT a.currency FROM bank_accounts a WHERE a.id = transfers.to_account_id),
+	rate, date, note, created_at`
+
+// transferCursorSort — идентификатор сортировки в курсоре списка переводов.
+const transferCursorSort = "date"
+
+// TransferRepository предоставляет методы для работы с переводами между аккаунтами в БД.
+type TransferRepository struct {
+	db *pgxpool.Pool // Пул соединений с БД
+}
+
+// NewTransferRepository создает новый экземпляр TransferRepository.
+func NewTransferRepository(db *pgxpool.Pool) *TransferRepository {
+	return &TransferRepository{db: db}
+}
+
+// Create выполняет перевод в одной транзакции БД: блокирует оба аккаунта (в порядке id, чтобы избежать
+// взаимоблокировок) и проверяет их владельца. Затем заполняет валюты перевода и вызывает resolve,
+// который вычисляет суммы, после чего сохраняет перевод, две операции и изменяет балансы.
+// Возвращает pgx.ErrNoRows, если аккаунт не существует, и ErrForeignAccount, если аккаунт чужой.
+func (r *TransferRepository) Create(ctx context.Context, t *transfer.Transfer, resolve func(*transfer.Transfer) error) (*transfer.Transfer, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `SELECT id, user_id, currency FROM bank_accounts WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE`, []int{t.FromAccountID, t.ToAccountID})
+	if err != nil {
+		return nil, err
+	}
+	currencies := map[int]string{}
+	for rows.Next() {
+		var id, ownerID int
+		var currency string
+		if err := rows.Scan(&id, &ownerID, &currency); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if ownerID != t.UserID {
+			rows.Close()
+			return nil, ErrForeignAccount
+		}
+		currencies[id] = currency
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(currencies) != 2 {
+		return nil, pgx.ErrNoRows
+	}
+	t.FromCurrency, t.ToCurrency = currencies[t.FromAccountID], currencies[t.ToAccountID]
+	if err := resolve(t); err != nil {
+		return nil, err
+	}
+
+	var transferID int
+	err = tx.QueryRow(ctx, `INSERT INTO transfers (user_id, from_account_id, to_account_id, from_amount, to_amount, rate, date, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`,
+		t.UserID, t.FromAccountID, t.ToAccountID, t.FromAmount, t.ToAmount, t.Rate, t.Date, t.Note).Scan(&transferID)
+	if err != nil {
+		return nil, err
+	}
+	legs := []transaction.Transaction{
+		{UserID: t.UserID, AccountID: t.FromAccountID, Type: transaction.TypeTransferOut, Amount: t.FromAmount.Neg(), Date: t.Date, Note: t.Note, TransferID: &transferID},
+		{UserID: t.UserID, AccountID: t.ToAccountID, Type: transaction.TypeTransferIn, Amount: t.ToAmount, Date: t.Date, Note: t.Note, TransferID: &transferID},
+	}
+	for i := range legs {
+		if err := adjustAccountBalance(ctx, tx, legs[i].AccountID, t.UserID, legs[i].Amount); err != nil {
+			return nil, err
+		}
+		if _, err := insertTransaction(ctx, tx, &legs[i]); err != nil {
+			return nil, err
+		}
+	}
+	created, err := scanTransfer(tx.QueryRow(ctx, `SELECT `+transferColumns+` FROM transfers WHERE id=$1`, transferID))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetByID возвращает перевод по id и user_id.
+func (r *TransferRepository) GetByID(ctx context.Context, id, userID int) (*transfer.Transfer, error) {
+	row := r.db.QueryRow(ctx, `SELECT `+transferColumns+` FROM transfers WHERE id=$1 AND user_id=$2`, id, userID)
+	return scanTransfer(row)
+}
+
+// List возвращает страницу переводов пользователя от новых к старым и курсор следующей страницы.
+func (r *TransferRepository) List(ctx context.Context, userID int, filter transfer.ListFilter) ([]transfer.Transfer, string, error) {
+	conditions := []string{"user_id = $1"}
+	args := []any{userID}
+	if filter.AccountID != 0 {
+		args = append(args, filter.AccountID)
+		conditions = append(conditions, fmt.Sprintf("(from_account_id = $%d OR to_account_id = $%d)", len(args), len(args)))
+	}
+	if filter.Cursor != "" {
+		cursor, err := decodeCursor(filter.Cursor, transferCursorSort)
+		if err != nil {
+			return nil, "", err
+		}
+		args = append(args, cursor.Value, cursor.ID)
+		conditions = append(conditions, fmt.Sprintf("(date, id) < ($%d::date, $%d)", len(args)-1, len(args)))
+	}
+	args = append(args, filter.Limit+1)
+	query := fmt.Sprintf(`SELECT %s FROM transfers WHERE %s ORDER BY date DESC, id DESC LIMIT $%d`,
+		transferColumns, strings.Join(conditions, " AND "), len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	transfers := []transfer.Transfer{}
+	for rows.Next() {
+		t, err := scanTransfer(rows)
+		if err != nil {
+			return nil, "", err
+		}
+		transfers = append(transfers, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(transfers) > filter.Limit {
+		transfers = transfers[:filter.Limit]
+		last := transfers[len(transfers)-1]
+		nextCursor = encodeCursor(pageCursor{Sort: transferCursorSort, Value: last.Date.Format(time.DateOnly), ID: last.ID})
+	}
+	return transfers, nextCursor, nil
+}
+
+// Delete удаляет перевод вместе с его операциями и возвращает суммы на балансы аккаунтов.
+// Возвращает pgx.ErrNoRows, если перевод не найден у пользователя.
+func (r *TransferRepository) Delete(ctx context.Context, id, userID int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `DELETE FROM transactions WHERE transfer_id = $1 AND user_id = $2 RETURNING account_id, amount`, id, userID)
+	if err != nil {
+		return err
+	}
+	type leg struct {
+		accountID int
+		amount    money.Decimal
+	}
+	var legs []leg
+	for rows.Next() {
+		var l leg
+		if err := rows.Scan(&l.accountID, &l.amount); err != nil {
+			rows.Close()
+			return err
+		}
+		legs = append(legs, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, l := range legs {
+		if err := adjustAccountBalance(ctx, tx, l.accountID, userID, l.amount.Neg()); err != nil {
+			return err
+		}
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM transfers WHERE id = $1 AND user_id = $2`, id, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return tx.Commit(ctx)
+}
+
+// scanTransfer читает перевод из строки результата (колонки transferColumns).
+// Суммы приводятся к точности валют аккаунтов, у курса отбрасываются незначащие нули.
+func scanTransfer(row pgx.Row) (*transfer.Transfer, error) {
+	var t transfer.Transfer
+	err := row.Scan(&t.ID, &t.UserID, &t.FromAccountID, &t.FromAmount, &t.FromCurrency, &t.ToAccountID, &t.ToAmount, &t.ToCurrency, &t.Rate, &t.Date, &t.Note, &t.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	t.FromAmount = t.FromAmount.Round(money.MinorUnits(t.FromCurrency))
+	t.ToAmount = t.ToAmount.Round(money.MinorUnits(t.ToCurrency))
+	t.Rate = t.Rate.Round(t.Rate.DecimalPlaces())
+	return &t, nil
+}
@@ -2,11 +2,17 @@ package repository
 
 import (
 	"context"
+	"errors"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
 )
 
+// ErrEmailExists возвращается, если пользователь с таким email уже зарегистрирован.
+var ErrEmailExists = errors.New("user with this email already exists")
+
 // UserRepository предоставляет методы для работы с пользователями в базе данных.
 type UserRepository struct {
 	db *pgxpool.Pool // Пул соединений с базой данных
@@ -17,18 +23,52 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-// Create добавляет нового пользователя в базу данных.
-func (r *UserRepository) Create(ctx context.Context, email, passwordHash string) error {
-	_, err := r.db.Exec(ctx, `INSERT INTO users (email, password_hash) VALUES ($1, $2)`, email, passwordHash)
-	return err
+// userColumns — список колонок, из которых собирается user.User.
+const userColumns = `id, email, password_hash, home_currency, language, email_verified_at, created_at`
+
+// Create добавляет нового пользователя в базу данных вместе с его категориями по умолчанию
+// в одной транзакции. Возвращает id созданного пользователя или ErrEmailExists, если email уже занят.
+func (r *UserRepository) Create(ctx context.Context, email, passwordHash, homeCurrency string, categories []category.Default) (int, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var id int
+	err = tx.QueryRow(ctx, `INSERT INTO users (email, password_hash, home_currency) VALUES ($1, $2, $3) RETURNING id`, email, passwordHash, homeCurrency).Scan(&id)
+	if isUniqueViolation(err) {
+		return 0, ErrEmailExists
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := insertDefaultCategories(ctx, tx, id, categories); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit(ctx)
 }
 
 // FindByEmail ищет пользователя по email. Возвращает пользователя или ошибку, если не найден.
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
-	row := r.db.QueryRow(ctx, `SELECT id, email, password_hash, created_at FROM users WHERE email = $1`, email)
+	return scanUser(r.db.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE email = $1`, email))
+}
+
+// FindByID ищет пользователя по id. Возвращает пользователя или ошибку, если не найден.
+func (r *UserRepository) FindByID(ctx context.Context, id int) (*user.User, error) {
+	return scanUser(r.db.QueryRow(ctx, `SELECT `+userColumns+` FROM users WHERE id = $1`, id))
+}
+
+// UpdateProfile меняет домашнюю валюту и язык пользователя и возвращает обновлённого пользователя.
+func (r *UserRepository) UpdateProfile(ctx context.Context, id int, homeCurrency, language string) (*user.User, error) {
+	return scanUser(r.db.QueryRow(ctx,
+		`UPDATE users SET home_currency = $1, language = $2 WHERE id = $3 RETURNING `+userColumns, homeCurrency, language, id))
+}
+
+// scanUser читает пользователя из строки результата (колонки userColumns).
+func scanUser(row pgx.Row) (*user.User, error) {
 	var u user.User
-	err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.CreatedAt)
-	if err != nil {
+	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.HomeCurrency, &u.Language, &u.EmailVerifiedAt, &u.CreatedAt); err != nil {
 		return nil, err
 	}
 	return &u, nil
@@ -0,0 +1,125 @@
+// Package rules применяет правила автоматической категоризации (rule.Rule) к операциям.
+// Пакет не обращается к БД: правила и виды категорий загружает вызывающий сервис.
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rule"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+// Engine — скомпилированный упорядоченный набор включённых правил пользователя.
+type Engine struct {
+	rules []compiled
+	kinds map[int]string // Виды категорий пользователя по ID
+}
+
+// compiled — правило со скомпилированными регулярными выражениями.
+type compiled struct {
+	rule.Rule
+	payee *regexp.Regexp
+	note  *regexp.Regexp
+}
+
+// Compile проверяет регулярное выражение условия правила; сравнение выполняется без учёта регистра.
+// Пустой шаблон означает отсутствие условия, для него возвращается nil.
+func Compile(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// New компилирует правила в порядке следования; выключенные правила пропускаются.
+// categories нужны, чтобы не назначать категорию расходов доходу и наоборот.
+func New(rules []rule.Rule, categories []category.Category) (*Engine, error) {
+	e := &Engine{kinds: make(map[int]string, len(categories))}
+	for _, c := range categories {
+		e.kinds[c.ID] = c.Kind
+	}
+	for _, r := range rules {
+		if !r.Enabled {
+			continue
+		}
+		payee, err := Compile(r.PayeePattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", r.ID, err)
+		}
+		note, err := Compile(r.NotePattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", r.ID, err)
+		}
+		e.rules = append(e.rules, compiled{Rule: r, payee: payee, note: note})
+	}
+	return e, nil
+}
+
+// Apply применяет правила к операции и возвращает ID сработавших правил.
+// Условия проверяются по исходным значениям операции. Категория устанавливается, только если у операции
+// её нет (или overwrite) и вид категории соответствует типу операции; контрагент заменяется первым
+// подходящим правилом с RenamePayee; теги всех подходящих правил добавляются к тегам операции,
+// пока их не станет transaction.MaxTags — остальные теги правил пропускаются.
+func (e *Engine) Apply(t *transaction.Transaction, overwrite bool) []int {
+	original := *t
+	t.Tags = slices.Clone(t.Tags)
+	categorySet := t.CategoryID != nil && !overwrite
+	payeeSet := false
+	matched := []int{}
+	for _, r := range e.rules {
+		if !r.matches(&original) {
+			continue
+		}
+		applied := false
+		// Виды категорий совпадают с типами операций: income и expense.
+		if r.CategoryID != nil && !categorySet && e.kinds[*r.CategoryID] == t.Type {
+			id := *r.CategoryID
+			t.CategoryID, categorySet, applied = &id, true, true
+		}
+		if r.RenamePayee != "" && !payeeSet {
+			t.Payee, payeeSet, applied = r.RenamePayee, true, true
+		}
+		for _, tag := range r.Tags {
+			if len(t.Tags) >= transaction.MaxTags {
+				break
+			}
+			if !slices.Contains(t.Tags, tag) {
+				t.Tags, applied = append(t.Tags, tag), true
+			}
+		}
+		if applied {
+			matched = append(matched, r.ID)
+		}
+	}
+	return matched
+}
+
+// matches проверяет условия правила для операции. Правила применяются только к доходам и расходам.
+func (r compiled) matches(t *transaction.Transaction) bool {
+	if t.Type != transaction.TypeIncome && t.Type != transaction.TypeExpense {
+		return false
+	}
+	if r.Type != "" && r.Type != t.Type {
+		return false
+	}
+	if r.AccountID != nil && *r.AccountID != t.AccountID {
+		return false
+	}
+	amount := t.Amount.Abs()
+	if r.MinAmount != nil && amount.Cmp(*r.MinAmount) < 0 {
+		return false
+	}
+	if r.MaxAmount != nil && amount.Cmp(*r.MaxAmount) > 0 {
+		return false
+	}
+	if r.payee != nil && !r.payee.MatchString(t.Payee) {
+		return false
+	}
+	if r.note != nil && !r.note.MatchString(t.Note) {
+		return false
+	}
+	return true
+}
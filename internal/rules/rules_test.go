@@ -0,0 +1,184 @@
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rule"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+)
+
+const (
+	groceries = 1 // Категория расходов
+	salary    = 2 // Категория доходов
+	cafes     = 3 // Категория расходов
+)
+
+var testCategories = []category.Category{
+	{ID: groceries, Kind: category.KindExpense},
+	{ID: salary, Kind: category.KindIncome},
+	{ID: cafes, Kind: category.KindExpense},
+}
+
+func mustEngine(t *testing.T, rs ...rule.Rule) *Engine {
+	t.Helper()
+	for i := range rs {
+		rs[i].Enabled = true
+	}
+	e, err := New(rs, testCategories)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return e
+}
+
+func expense(payee, amount string) transaction.Transaction {
+	return transaction.Transaction{AccountID: 1, Type: transaction.TypeExpense, Amount: money.MustParse(amount), Payee: payee}
+}
+
+func TestApplyOrder(t *testing.T) {
+	e := mustEngine(t,
+		rule.Rule{ID: 1, PayeePattern: "magnit", CategoryID: ptr(groceries), Tags: []string{"food"}},
+		rule.Rule{ID: 2, PayeePattern: "magnit", CategoryID: ptr(cafes), RenamePayee: "Магнит", Tags: []string{"food", "shop"}},
+		rule.Rule{ID: 3, PayeePattern: "^magnit", RenamePayee: "Другой", Tags: []string{"late"}},
+	)
+	tr := expense("MAGNIT 1234", "-100")
+	matched := e.Apply(&tr, false)
+
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(matched, want) {
+		t.Errorf("matched = %v, want %v", matched, want)
+	}
+	if tr.CategoryID == nil || *tr.CategoryID != groceries {
+		t.Errorf("CategoryID = %v, want first rule's category %d", tr.CategoryID, groceries)
+	}
+	if tr.Payee != "Магнит" {
+		t.Errorf("Payee = %q, want first rename", tr.Payee)
+	}
+	if want := []string{"food", "shop", "late"}; !reflect.DeepEqual(tr.Tags, want) {
+		t.Errorf("Tags = %v, want %v", tr.Tags, want)
+	}
+}
+
+// TestApplyUsesOriginalValues проверяет, что условия проверяются по исходному контрагенту, а не переименованному.
+func TestApplyUsesOriginalValues(t *testing.T) {
+	e := mustEngine(t,
+		rule.Rule{ID: 1, PayeePattern: "magnit", RenamePayee: "Supermarket"},
+		rule.Rule{ID: 2, PayeePattern: "supermarket", Tags: []string{"wrong"}},
+	)
+	tr := expense("MAGNIT", "-1")
+	if matched := e.Apply(&tr, false); !reflect.DeepEqual(matched, []int{1}) {
+		t.Errorf("matched = %v, want [1]", matched)
+	}
+}
+
+func TestApplyOverwrite(t *testing.T) {
+	e := mustEngine(t, rule.Rule{ID: 1, PayeePattern: "magnit", CategoryID: ptr(groceries)})
+
+	tr := expense("Magnit", "-100")
+	tr.CategoryID = ptr(cafes)
+	if matched := e.Apply(&tr, false); len(matched) != 0 || *tr.CategoryID != cafes {
+		t.Errorf("without overwrite: matched = %v, category = %d; want user's category kept", matched, *tr.CategoryID)
+	}
+
+	tr.CategoryID = ptr(cafes)
+	if matched := e.Apply(&tr, true); !reflect.DeepEqual(matched, []int{1}) || *tr.CategoryID != groceries {
+		t.Errorf("with overwrite: matched = %v, category = %d; want %d", matched, *tr.CategoryID, groceries)
+	}
+}
+
+func TestApplyKindMismatch(t *testing.T) {
+	e := mustEngine(t,
+		rule.Rule{ID: 1, PayeePattern: "acme", CategoryID: ptr(salary)},
+		rule.Rule{ID: 2, PayeePattern: "acme", CategoryID: ptr(groceries)},
+	)
+	tr := expense("ACME", "-100")
+	matched := e.Apply(&tr, false)
+	if !reflect.DeepEqual(matched, []int{2}) || tr.CategoryID == nil || *tr.CategoryID != groceries {
+		t.Errorf("matched = %v, category = %v; want income category skipped", matched, tr.CategoryID)
+	}
+
+	income := transaction.Transaction{AccountID: 1, Type: transaction.TypeIncome, Amount: money.MustParse("100"), Payee: "ACME"}
+	if matched := e.Apply(&income, false); !reflect.DeepEqual(matched, []int{1}) || *income.CategoryID != salary {
+		t.Errorf("income: matched = %v, category = %v", matched, income.CategoryID)
+	}
+}
+
+func TestApplyConditions(t *testing.T) {
+	e := mustEngine(t, rule.Rule{
+		ID: 1, AccountID: ptr(1), Type: transaction.TypeExpense, NotePattern: "coffee",
+		MinAmount: ptr(money.MustParse("5")), MaxAmount: ptr(money.MustParse("10.00")), Tags: []string{"coffee"},
+	})
+	tests := []struct {
+		name   string
+		modify func(*transaction.Transaction)
+		want   bool
+	}{
+		{name: "all conditions", want: true},
+		{name: "at max", modify: func(tr *transaction.Transaction) { tr.Amount = money.MustParse("-10") }, want: true},
+		{name: "below min", modify: func(tr *transaction.Transaction) { tr.Amount = money.MustParse("-4.99") }},
+		{name: "above max", modify: func(tr *transaction.Transaction) { tr.Amount = money.MustParse("-10.01") }},
+		{name: "other account", modify: func(tr *transaction.Transaction) { tr.AccountID = 2 }},
+		{name: "other note", modify: func(tr *transaction.Transaction) { tr.Note = "tea" }},
+		{name: "transfer", modify: func(tr *transaction.Transaction) { tr.Type = transaction.TypeTransferOut }},
+	}
+	for _, tt := range tests {
+		tr := expense("", "-7")
+		tr.Note = "Morning COFFEE"
+		if tt.modify != nil {
+			tt.modify(&tr)
+		}
+		if got := len(e.Apply(&tr, false)) == 1; got != tt.want {
+			t.Errorf("%s: matched = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestApplyDisabledRule(t *testing.T) {
+	e, err := New([]rule.Rule{{ID: 1, PayeePattern: "x", Tags: []string{"t"}}}, testCategories)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := expense("x", "-1")
+	if matched := e.Apply(&tr, false); len(matched) != 0 {
+		t.Errorf("disabled rule matched: %v", matched)
+	}
+}
+
+func TestApplyTagLimit(t *testing.T) {
+	many := make([]string, transaction.MaxTags)
+	for i := range many {
+		many[i] = fmt.Sprintf("rule-%d", i)
+	}
+	e := mustEngine(t,
+		rule.Rule{ID: 1, PayeePattern: "x", Tags: many},
+		rule.Rule{ID: 2, PayeePattern: "x", Tags: []string{"extra"}},
+	)
+	tr := expense("x", "-1")
+	tr.Tags = []string{"own-1", "own-2"}
+	original := tr.Tags
+	matched := e.Apply(&tr, false)
+
+	if len(tr.Tags) != transaction.MaxTags {
+		t.Fatalf("len(Tags) = %d, want %d", len(tr.Tags), transaction.MaxTags)
+	}
+	if tr.Tags[0] != "own-1" || tr.Tags[1] != "own-2" || tr.Tags[2] != "rule-0" {
+		t.Errorf("Tags = %v, want own tags first", tr.Tags)
+	}
+	if !reflect.DeepEqual(matched, []int{1}) {
+		t.Errorf("matched = %v, want [1]: rule 2 added nothing", matched)
+	}
+	if len(original) != 2 {
+		t.Errorf("Apply modified the caller's tag slice: %v", original)
+	}
+}
+
+func TestNewInvalidPattern(t *testing.T) {
+	if _, err := New([]rule.Rule{{ID: 7, Enabled: true, PayeePattern: "("}}, nil); err == nil {
+		t.Error("New with invalid pattern should fail")
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
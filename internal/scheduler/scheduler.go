@@ -0,0 +1,75 @@
+// Package scheduler выполняет фоновые задачи приложения внутри процесса.
+// Задачи не хранят время последнего запуска: каждая сама определяет по данным в БД, что нужно сделать,
+// поэтому первый запуск после простоя догоняет всё пропущенное.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job описывает периодическую задачу.
+type Job struct {
+	Name     string                                         // Название задачи для журнала
+	Interval time.Duration                                  // Интервал между запусками
+	Run      func(ctx context.Context, now time.Time) error // Выполнение задачи; now — время запуска
+}
+
+// Scheduler запускает задачи сразу после старта и затем с их интервалом.
+// Запуски одной задачи не пересекаются: следующий начинается только после завершения предыдущего.
+type Scheduler struct {
+	jobs []Job          // Зарегистрированные задачи
+	wg   sync.WaitGroup // Ожидание завершения задач при остановке
+}
+
+// New создает планировщик с задачами jobs.
+func New(jobs ...Job) *Scheduler {
+	return &Scheduler{jobs: jobs}
+}
+
+// Start запускает задачи в отдельных горутинах. Задачи останавливаются при отмене ctx.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, job)
+		}()
+	}
+}
+
+// Wait ожидает завершения всех задач после отмены контекста, переданного в Start.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// loop выполняет задачу сразу и затем по таймеру до отмены ctx.
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		s.run(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// run выполняет задачу один раз с таймаутом, равным её интервалу. Ошибки и паники записываются в журнал
+// и не останавливают следующие запуски.
+func (s *Scheduler) run(ctx context.Context, job Job) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("Планировщик: паника в задаче %s: %v", job.Name, p)
+		}
+	}()
+	runCtx, cancel := context.WithTimeout(ctx, job.Interval)
+	defer cancel()
+	if err := job.Run(runCtx, time.Now()); err != nil {
+		log.Printf("Планировщик: ошибка задачи %s: %v", job.Name, err)
+	}
+}
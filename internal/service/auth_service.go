@@ -2,20 +2,58 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"log"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/token"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTokenTTL      = 15 * time.Minute   // Время жизни access токена
+	refreshTokenTTL     = 7 * 24 * time.Hour // Время жизни refresh токена
+	defaultHomeCurrency = "RUB"              // Домашняя валюта, если она не указана при регистрации
+)
+
+var (
+	// ErrWeakPassword возвращается при регистрации со слишком простым паролем.
+	ErrWeakPassword = apperror.New(apperror.KindValidation, "weak_password")
+	// ErrEmailTaken возвращается при регистрации с уже занятым email.
+	ErrEmailTaken = apperror.Conflict("email_taken")
+	// ErrInvalidCredentials возвращается при входе с неизвестным email или неверным паролем.
+	ErrInvalidCredentials = apperror.Unauthorized("invalid_credentials")
+	// ErrInvalidRefreshToken возвращается для неизвестного refresh токена.
+	ErrInvalidRefreshToken = apperror.Unauthorized("invalid_refresh_token")
+	// ErrRefreshTokenReused возвращается при повторном предъявлении ротированного refresh токена.
+	ErrRefreshTokenReused = apperror.Unauthorized("refresh_token_reused")
+	// ErrRefreshTokenExpired возвращается для refresh токена с истёкшим сроком действия.
+	ErrRefreshTokenExpired = apperror.Unauthorized("refresh_token_expired")
+	// ErrInvalidLanguage возвращается для языка профиля, на который API не переведён.
+	ErrInvalidLanguage = apperror.New(apperror.KindValidation, "invalid_language")
+	// ErrSessionNotFound возвращается, если сессия не найдена среди сессий пользователя.
+	ErrSessionNotFound = apperror.NotFound("session_not_found")
+)
+
 // AuthService реализует бизнес-логику аутентификации и регистрации пользователей.
 type AuthService struct {
-	repo        *repository.UserRepository
-	refreshRepo *repository.RefreshTokenRepository
-	jwtSecret   string
+	repo             *repository.UserRepository
+	refreshRepo      *repository.RefreshTokenRepository
+	verification     *EmailVerificationService // Сервис подтверждения email
+	jwtConfig        token.JWTConfig
+	unverifiedAccess string // Ограничения для пользователей с неподтверждённым email (user.UnverifiedAccess*)
 }
 
 // Tokens содержит access и refresh токены для пользователя.
@@ -25,62 +63,249 @@ type Tokens struct {
 }
 
 // NewAuthService создает новый экземпляр AuthService.
-func NewAuthService(repo *repository.UserRepository, refreshRepo *repository.RefreshTokenRepository, jwtSecret string) *AuthService {
-	return &AuthService{repo: repo, refreshRepo: refreshRepo, jwtSecret: jwtSecret}
+func NewAuthService(repo *repository.UserRepository, refreshRepo *repository.RefreshTokenRepository, verification *EmailVerificationService, jwtConfig token.JWTConfig, unverifiedAccess string) *AuthService {
+	return &AuthService{repo: repo, refreshRepo: refreshRepo, verification: verification, jwtConfig: jwtConfig, unverifiedAccess: unverifiedAccess}
 }
 
-// Register регистрирует нового пользователя с проверкой сложности пароля и хешированием.
-func (s *AuthService) Register(ctx context.Context, email, password string) error {
+// Register регистрирует нового пользователя с проверкой сложности пароля и хешированием
+// и отправляет ему письмо со ссылкой для подтверждения email. Категории по умолчанию и письмо — на языке lang.
+// Пустая домашняя валюта заменяется на defaultHomeCurrency. Ошибка отправки письма не отменяет
+// регистрацию: она пишется в лог, а письмо можно запросить повторно.
+func (s *AuthService) Register(ctx context.Context, email, password, homeCurrency, lang string) error {
 	if !isPasswordStrong(password) {
-		return errors.New("Пароль слишком простой")
+		return ErrWeakPassword
+	}
+	homeCurrency = currency.Normalize(homeCurrency)
+	if homeCurrency == "" {
+		homeCurrency = defaultHomeCurrency
+	}
+	if !currency.Valid(homeCurrency) {
+		return ErrInvalidCurrency
 	}
 	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return errors.New("Ошибка при хешировании пароля")
+		return apperror.Internal(err)
+	}
+	id, err := s.repo.Create(ctx, email, string(passwordHash), homeCurrency, defaultCategoriesFor(lang))
+	if errors.Is(err, repository.ErrEmailExists) {
+		return ErrEmailTaken
+	}
+	if err != nil {
+		return err
 	}
-	return s.repo.Create(ctx, email, string(passwordHash))
+	if err := s.verification.Send(ctx, id, email, lang); err != nil {
+		log.Printf("Не удалось выдать токен подтверждения email пользователю %d: %v", id, err)
+	}
+	return nil
+}
+
+// Profile возвращает профиль пользователя.
+func (s *AuthService) Profile(ctx context.Context, userID int) (*user.User, error) {
+	return s.repo.FindByID(ctx, userID)
+}
+
+// UpdateProfile меняет домашнюю валюту и язык пользователя. Пустая валюта и nil язык оставляют
+// текущие значения, пустой язык сбрасывает выбор (язык снова определяется по Accept-Language).
+// Балансы аккаунтов не пересчитываются: домашняя валюта влияет только на валюту итогов по умолчанию.
+// Новый язык попадает в access токен при следующем обновлении токенов.
+func (s *AuthService) UpdateProfile(ctx context.Context, userID int, homeCurrency string, language *string) (*user.User, error) {
+	current, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if homeCurrency = currency.Normalize(homeCurrency); homeCurrency == "" {
+		homeCurrency = current.HomeCurrency
+	} else if !currency.Valid(homeCurrency) {
+		return nil, ErrInvalidCurrency
+	}
+	lang := current.Language
+	if language != nil {
+		lang = strings.ToLower(strings.TrimSpace(*language))
+		if lang != "" && !i18n.Supported(lang) {
+			return nil, ErrInvalidLanguage
+		}
+	}
+	return s.repo.UpdateProfile(ctx, userID, homeCurrency, lang)
 }
 
 // Login выполняет аутентификацию пользователя по email и паролю, возвращает токены и сохраняет refresh токен в БД.
-func (s *AuthService) Login(ctx context.Context, email, password string) (*Tokens, error) {
+// Каждый логин открывает новую сессию, данные клиента сохраняются вместе с refresh токеном.
+func (s *AuthService) Login(ctx context.Context, email, password string, client token.ClientInfo) (*Tokens, error) {
 	userObj, err := s.repo.FindByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInvalidCredentials
+	}
 	if err != nil {
-		return nil, errors.New("Неверный email или пароль")
+		return nil, err
 	}
 	if err := bcrypt.CompareHashAndPassword([]byte(userObj.PasswordHash), []byte(password)); err != nil {
-		return nil, errors.New("Неверный email или пароль")
+		return nil, ErrInvalidCredentials
+	}
+	if err := s.checkEmailVerified(userObj); err != nil {
+		return nil, err
 	}
-	access, err := s.generateToken(userObj.ID, userObj.Email, 15*time.Minute)
+	familyID, err := generateRandomString(16)
 	if err != nil {
 		return nil, err
 	}
-	refresh, err := s.generateToken(userObj.ID, userObj.Email, 7*24*time.Hour)
+	return s.issueTokens(ctx, userObj, familyID, client)
+}
+
+// Refresh обменивает refresh токен на новую пару токенов с ротацией.
+// Повторное предъявление уже ротированного токена считается кражей: всё семейство токенов отзывается.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client token.ClientInfo) (*Tokens, error) {
+	stored, err := s.refreshRepo.FindByToken(ctx, refreshToken)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if stored.RotatedAt != nil {
+		_ = s.refreshRepo.DeleteFamily(ctx, stored.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
+	if time.Now().After(stored.ExpiresAt) {
+		_ = s.refreshRepo.Delete(ctx, refreshToken)
+		return nil, ErrRefreshTokenExpired
+	}
+	userObj, err := s.repo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err := s.checkEmailVerified(userObj); err != nil {
+		return nil, err
+	}
+	access, refresh, err := s.generateTokenPair(userObj, stored.FamilyID)
 	if err != nil {
 		return nil, err
 	}
-	expiresAt := time.Now().Add(7 * 24 * time.Hour)
 	createdAt := time.Now()
-	err = s.refreshRepo.Save(ctx, userObj.ID, refresh, expiresAt, createdAt)
+	err = s.refreshRepo.Rotate(ctx, stored, refresh, client, createdAt.Add(refreshTokenTTL), createdAt)
+	if errors.Is(err, repository.ErrRefreshTokenRotated) {
+		_ = s.refreshRepo.DeleteFamily(ctx, stored.FamilyID)
+		return nil, ErrRefreshTokenReused
+	}
 	if err != nil {
-		return nil, errors.New("Ошибка сохранения refresh токена")
+		return nil, apperror.Internal(err)
 	}
 	return &Tokens{AccessToken: access, RefreshToken: refresh}, nil
 }
 
-// Logout удаляет refresh токен из БД (инвалидация токена).
+// Logout завершает сессию, к которой относится refresh токен (инвалидация всех токенов семейства).
 func (s *AuthService) Logout(ctx context.Context, refreshToken string) error {
-	return s.refreshRepo.Delete(ctx, refreshToken)
+	stored, err := s.refreshRepo.FindByToken(ctx, refreshToken)
+	if err != nil {
+		return s.refreshRepo.Delete(ctx, refreshToken)
+	}
+	return s.refreshRepo.DeleteFamily(ctx, stored.FamilyID)
+}
+
+// ListSessions возвращает активные сессии пользователя.
+func (s *AuthService) ListSessions(ctx context.Context, userID int) ([]token.Session, error) {
+	return s.refreshRepo.ListActiveSessions(ctx, userID)
+}
+
+// RevokeSession завершает одну сессию пользователя по её идентификатору.
+func (s *AuthService) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	found, err := s.refreshRepo.DeleteUserFamily(ctx, userID, sessionID)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// LogoutAll завершает все сессии пользователя на всех устройствах.
+func (s *AuthService) LogoutAll(ctx context.Context, userID int) error {
+	return s.refreshRepo.DeleteAllForUser(ctx, userID)
+}
+
+// checkEmailVerified возвращает ErrEmailNotVerified, если email пользователя не подтверждён,
+// а вход без подтверждения запрещён.
+func (s *AuthService) checkEmailVerified(userObj *user.User) error {
+	if !userObj.EmailVerified() && s.unverifiedAccess == user.UnverifiedAccessNone {
+		return ErrEmailNotVerified
+	}
+	return nil
+}
+
+// issueTokens выпускает пару токенов для пользователя и сохраняет refresh токен в указанном семействе.
+func (s *AuthService) issueTokens(ctx context.Context, userObj *user.User, familyID string, client token.ClientInfo) (*Tokens, error) {
+	access, refresh, err := s.generateTokenPair(userObj, familyID)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := time.Now()
+	err = s.refreshRepo.Save(ctx, userObj.ID, refresh, familyID, client, createdAt.Add(refreshTokenTTL), createdAt)
+	if err != nil {
+		return nil, apperror.Internal(err)
+	}
+	return &Tokens{AccessToken: access, RefreshToken: refresh}, nil
+}
+
+// generateTokenPair создает access и refresh токены для пользователя в рамках сессии.
+// Пользователь с неподтверждённым email в режиме user.UnverifiedAccessReadOnly получает только разрешение read.
+func (s *AuthService) generateTokenPair(userObj *user.User, sessionID string) (string, string, error) {
+	scopes := []string{token.ScopeRead, token.ScopeWrite}
+	if !userObj.EmailVerified() && s.unverifiedAccess == user.UnverifiedAccessReadOnly {
+		scopes = []string{token.ScopeRead}
+	}
+	access, err := s.generateToken(userObj, sessionID, token.TypeAccess, scopes, accessTokenTTL)
+	if err != nil {
+		return "", "", err
+	}
+	refresh, err := s.generateToken(userObj, sessionID, token.TypeRefresh, nil, refreshTokenTTL)
+	if err != nil {
+		return "", "", err
+	}
+	return access, refresh, nil
+}
+
+// generateToken создает подписанный HS256 JWT токен заданного типа с временем жизни ttl.
+// Claim sid содержит идентификатор сессии, scope, lang и unverified — разрешения, язык пользователя
+// и признак неподтверждённого email (только в access токене).
+func (s *AuthService) generateToken(userObj *user.User, sessionID, tokenType string, scopes []string, ttl time.Duration) (string, error) {
+	// jti гарантирует уникальность токенов, выпущенных в одну и ту же секунду
+	jti, err := generateRandomString(16)
+	if err != nil {
+		return "", err
+	}
+	var language string // Язык и признак подтверждения нужны только при проверке access токена
+	var unverified bool
+	if tokenType == token.TypeAccess {
+		language = userObj.Language
+		unverified = !userObj.EmailVerified()
+	}
+	now := time.Now()
+	claims := token.Claims{
+		UserID:     userObj.ID,
+		Email:      userObj.Email,
+		SessionID:  sessionID,
+		Scope:      strings.Join(scopes, " "),
+		TokenType:  tokenType,
+		Language:   language,
+		Unverified: unverified,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.Itoa(userObj.ID),
+			Issuer:    s.jwtConfig.Issuer,
+			Audience:  jwt.ClaimStrings{s.jwtConfig.Audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return jwtToken.SignedString([]byte(s.jwtConfig.Secret))
 }
 
-// generateToken создает JWT токен с заданным временем жизни.
-func (s *AuthService) generateToken(userID int, email string, ttl time.Duration) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": userID,
-		"email":   email,
-		"exp":     time.Now().Add(ttl).Unix(),
+// generateRandomString возвращает криптографически случайную hex-строку из n байт.
+func generateRandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return hex.EncodeToString(b), nil
 }
 
 // isPasswordStrong проверяет сложность пароля: минимум 8 символов, буквы и цифры.
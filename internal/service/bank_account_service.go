@@ -3,38 +3,287 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
 	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
 )
 
+const (
+	defaultAccountPageSize = 20                  // Размер страницы списка аккаунтов по умолчанию
+	maxAccountPageSize     = 100                 // Максимальный размер страницы списка аккаунтов
+	accountRestoreWindow   = 30 * 24 * time.Hour // Срок, в течение которого удалённый аккаунт можно восстановить
+	maxInterestRate        = 100                 // Максимальная годовая процентная ставка, %
+	interestRatePlaces     = 4                   // Точность процентной ставки
+)
+
+var (
+	// ErrAccountNotFound возвращается, если аккаунт не найден среди аккаунтов пользователя.
+	ErrAccountNotFound = apperror.NotFound("account_not_found")
+	// ErrVersionConflict возвращается, если аккаунт изменён после того, как клиент получил его версию.
+	ErrVersionConflict = apperror.PreconditionFailed("version_conflict")
+	// ErrInvalidCursor возвращается для курсора пагинации, который не удалось разобрать.
+	ErrInvalidCursor = apperror.New(apperror.KindValidation, "invalid_cursor")
+	// ErrInvalidCurrency возвращается для кода валюты вне справочника ISO 4217.
+	ErrInvalidCurrency = apperror.New(apperror.KindValidation, "invalid_currency")
+)
+
 // BankAccountService реализует бизнес-логику для банковских аккаунтов.
 type BankAccountService struct {
-	repo *repository.BankAccountRepository // Репозиторий банковских аккаунтов
+	repo     *repository.BankAccountRepository // Репозиторий банковских аккаунтов
+	userRepo *repository.UserRepository        // Репозиторий пользователей (домашняя валюта)
+	rates    *RateService                      // Сервис курсов валют для итогов в одной валюте
 }
 
 // NewBankAccountService создает новый экземпляр BankAccountService.
-func NewBankAccountService(repo *repository.BankAccountRepository) *BankAccountService {
-	return &BankAccountService{repo: repo}
+func NewBankAccountService(repo *repository.BankAccountRepository, userRepo *repository.UserRepository, rates *RateService) *BankAccountService {
+	return &BankAccountService{repo: repo, userRepo: userRepo, rates: rates}
 }
 
 // Create создает новый банковский аккаунт для пользователя.
-func (s *BankAccountService) Create(ctx context.Context, userID int, name string, balance float64, currency string) (*account.BankAccount, error) {
-	if name == "" || currency == "" {
-		return nil, errors.New("Название и валюта обязательны")
+func (s *BankAccountService) Create(ctx context.Context, a account.BankAccount) (*account.BankAccount, error) {
+	if err := validateBankAccount(&a); err != nil {
+		return nil, err
+	}
+	acc, err := s.repo.Create(ctx, &a)
+	return acc, mapBankAccountError(err)
+}
+
+// Get возвращает банковский аккаунт пользователя по id.
+func (s *BankAccountService) Get(ctx context.Context, id, userID int) (*account.BankAccount, error) {
+	acc, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	return acc, err
+}
+
+// List возвращает страницу банковских аккаунтов пользователя и курсор следующей страницы.
+func (s *BankAccountService) List(ctx context.Context, userID int, filter account.ListFilter) ([]account.BankAccount, string, error) {
+	switch filter.SortBy {
+	case "":
+		filter.SortBy = account.SortByCreatedAt
+	case account.SortByCreatedAt, account.SortByName, account.SortByBalance:
+	default:
+		return nil, "", apperror.Validation("account.invalid_sort")
+	}
+	if filter.Currency != "" {
+		filter.Currency = currency.Normalize(filter.Currency)
+	}
+	if filter.Type != "" && !account.ValidType(filter.Type) {
+		return nil, "", apperror.Validation("account.invalid_type_filter")
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultAccountPageSize
+	}
+	if filter.Limit > maxAccountPageSize {
+		filter.Limit = maxAccountPageSize
 	}
-	return s.repo.Create(ctx, userID, name, balance, currency)
+	accounts, nextCursor, err := s.repo.List(ctx, userID, filter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, "", ErrInvalidCursor
+	}
+	return accounts, nextCursor, err
 }
 
-// Update обновляет банковский аккаунт по id и user_id.
-func (s *BankAccountService) Update(ctx context.Context, id, userID int, name string, balance float64, currency string) (*account.BankAccount, error) {
-	if name == "" || currency == "" {
-		return nil, errors.New("Название и валюта обязательны")
+// Update обновляет банковский аккаунт по a.ID и a.UserID.
+// Если баланс не передан, он не меняется; изменение баланса сохраняется в истории как корректировка.
+// Если не передан тип, тип и его параметры (лимит, день выписки, ставка) остаются прежними.
+// Ненулевая version — версия, которую видел клиент: если аккаунт с тех пор изменился, возвращается ErrVersionConflict.
+func (s *BankAccountService) Update(ctx context.Context, a account.BankAccount, version int) (*account.BankAccount, error) {
+	if a.Type == "" {
+		current, err := s.Get(ctx, a.ID, a.UserID)
+		if err != nil {
+			return nil, err
+		}
+		a.Type, a.CreditLimit, a.StatementDay, a.InterestRate = current.Type, current.CreditLimit, current.StatementDay, current.InterestRate
+	}
+	if err := validateBankAccount(&a); err != nil {
+		return nil, err
+	}
+	var newBalance *money.Decimal
+	if a.Balance.IsSet() {
+		newBalance = &a.Balance
+	}
+	acc, err := s.repo.Update(ctx, &a, newBalance, version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
 	}
-	return s.repo.Update(ctx, id, userID, name, balance, currency)
+	return acc, mapBankAccountError(err)
 }
 
 // Delete удаляет банковский аккаунт по id и user_id.
 func (s *BankAccountService) Delete(ctx context.Context, id, userID int) error {
-	return s.repo.Delete(ctx, id, userID)
+	err := s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAccountNotFound
+	}
+	return err
+}
+
+// ListDeleted возвращает удалённые аккаунты пользователя, которые ещё можно восстановить.
+func (s *BankAccountService) ListDeleted(ctx context.Context, userID int) ([]account.BankAccount, error) {
+	return s.repo.ListDeleted(ctx, userID, time.Now().Add(-accountRestoreWindow))
+}
+
+// Restore восстанавливает удалённый аккаунт вместе с его операциями, если срок восстановления не истёк.
+func (s *BankAccountService) Restore(ctx context.Context, id, userID int) (*account.BankAccount, error) {
+	acc, err := s.repo.Restore(ctx, id, userID, time.Now().Add(-accountRestoreWindow))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	return acc, mapBankAccountError(err)
+}
+
+// SetArchived переносит аккаунт в архив (archived = true) или возвращает из архива.
+// Архивный аккаунт скрыт из списка по умолчанию, но его баланс учитывается в итогах.
+func (s *BankAccountService) SetArchived(ctx context.Context, id, userID int, archived bool) (*account.BankAccount, error) {
+	acc, err := s.repo.SetArchived(ctx, id, userID, archived)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	return acc, err
+}
+
+// PurgeDeleted окончательно удаляет аккаунты, срок восстановления которых истёк. Вызывается планировщиком.
+func (s *BankAccountService) PurgeDeleted(ctx context.Context, now time.Time) error {
+	_, err := s.repo.PurgeDeleted(ctx, now.Add(-accountRestoreWindow))
+	return err
+}
+
+// Totals возвращает сумму балансов всех аккаунтов пользователя в валюте code по курсам на сегодня.
+// Пустая валюта означает домашнюю валюту пользователя.
+// Аккаунты в валютах без курса возвращаются без пересчёта и не входят в итог.
+func (s *BankAccountService) Totals(ctx context.Context, userID int, code string) (*account.Totals, error) {
+	code = currency.Normalize(code)
+	if code == "" {
+		u, err := s.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		code = u.HomeCurrency
+	}
+	if !currency.Valid(code) {
+		return nil, ErrInvalidCurrency
+	}
+	accounts, err := s.repo.ListAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	zero := money.Zero.Round(money.MinorUnits(code))
+	totals := &account.Totals{
+		Currency:     code,
+		Date:         today,
+		Total:        zero,
+		Assets:       zero,
+		Liabilities:  zero,
+		Accounts:     make([]account.ConvertedBalance, 0, len(accounts)),
+		MissingRates: []string{},
+	}
+	for _, acc := range accounts {
+		item := account.ConvertedBalance{AccountID: acc.ID, Name: acc.Name, Type: acc.Type, Liability: acc.Liability, Currency: acc.Currency, Balance: acc.Balance}
+		c, err := s.rates.Convert(ctx, acc.Balance, acc.Currency, code, today)
+		switch {
+		case errors.Is(err, ErrRateNotFound), errors.Is(err, ErrInvalidCurrency):
+			if !slices.Contains(totals.MissingRates, acc.Currency) {
+				totals.MissingRates = append(totals.MissingRates, acc.Currency)
+			}
+		case err != nil:
+			return nil, err
+		default:
+			item.Converted, item.Rate = &c.Result, &c.Rate
+			totals.Total = totals.Total.Add(c.Result)
+			if acc.Liability {
+				totals.Liabilities = totals.Liabilities.Sub(c.Result)
+			} else {
+				totals.Assets = totals.Assets.Add(c.Result)
+			}
+		}
+		totals.Accounts = append(totals.Accounts, item)
+	}
+	return totals, nil
+}
+
+// validateBankAccount проверяет обязательные поля аккаунта и точность баланса для валюты,
+// нормализует и проверяет банковские реквизиты.
+func validateBankAccount(a *account.BankAccount) error {
+	if a.Name == "" || a.Currency == "" {
+		return apperror.Validation("account.name_currency_required")
+	}
+	a.Currency = currency.Normalize(a.Currency)
+	if !currency.Valid(a.Currency) {
+		return ErrInvalidCurrency
+	}
+	if !money.FitsCurrency(a.Balance, a.Currency) {
+		return apperror.Validation("money.too_many_decimals")
+	}
+	if err := validateAccountType(a); err != nil {
+		return err
+	}
+	a.IBAN = account.NormalizeIBAN(a.IBAN)
+	if a.IBAN != "" && !account.ValidIBAN(a.IBAN) {
+		return apperror.Validation("account.invalid_iban")
+	}
+	a.BIC = strings.ToUpper(strings.TrimSpace(a.BIC))
+	if a.BIC != "" && !account.ValidBIC(a.BIC) {
+		return apperror.Validation("account.invalid_bic")
+	}
+	return nil
+}
+
+// validateAccountType проверяет тип аккаунта и параметры, допустимые только для отдельных типов.
+// Пустой тип означает текущий счёт.
+func validateAccountType(a *account.BankAccount) error {
+	if a.Type == "" {
+		a.Type = account.TypeChecking
+	}
+	if !account.ValidType(a.Type) {
+		return apperror.Validation("account.invalid_type")
+	}
+	if a.Type != account.TypeCreditCard && (a.CreditLimit != nil || a.StatementDay != nil) {
+		return apperror.Validation("account.credit_params_only_card")
+	}
+	if a.CreditLimit != nil {
+		if a.CreditLimit.IsNegative() {
+			return apperror.Validation("account.credit_limit_negative")
+		}
+		if !money.FitsCurrency(*a.CreditLimit, a.Currency) {
+			return apperror.Validation("money.too_many_decimals")
+		}
+	}
+	if a.StatementDay != nil && (*a.StatementDay < 1 || *a.StatementDay > 31) {
+		return apperror.Validation("account.statement_day_range")
+	}
+	if a.InterestRate != nil {
+		if !account.HasInterestRate(a.Type) {
+			return apperror.Validation("account.interest_rate_not_allowed")
+		}
+		if a.InterestRate.IsNegative() || a.InterestRate.Cmp(money.NewFromInt(maxInterestRate)) > 0 {
+			return apperror.Validation("account.interest_rate_range")
+		}
+		if a.InterestRate.DecimalPlaces() > interestRatePlaces {
+			return apperror.Validation("account.interest_rate_precision")
+		}
+	}
+	return nil
+}
+
+// mapBankAccountError переводит ошибки репозитория аккаунтов в ошибки сервиса.
+func mapBankAccountError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrCurrencyChangeWithTransactions):
+		return apperror.Conflict("account_has_transactions")
+	case errors.Is(err, repository.ErrIBANExists):
+		return apperror.Conflict("iban_taken")
+	case errors.Is(err, repository.ErrVersionMismatch):
+		return ErrVersionConflict
+	}
+	return err
 }
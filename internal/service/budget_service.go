@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/budget"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+// percentPlaces — количество знаков после запятой в проценте исполнения бюджета.
+const percentPlaces = 2
+
+// ErrBudgetNotFound возвращается, если бюджет не найден среди бюджетов пользователя.
+var ErrBudgetNotFound = apperror.NotFound("budget_not_found")
+
+// BudgetService реализует бизнес-логику бюджетов и расчёт их исполнения.
+type BudgetService struct {
+	repo         *repository.BudgetRepository   // Репозиторий бюджетов
+	categoryRepo *repository.CategoryRepository // Репозиторий категорий
+}
+
+// NewBudgetService создает новый экземпляр BudgetService.
+func NewBudgetService(repo *repository.BudgetRepository, categoryRepo *repository.CategoryRepository) *BudgetService {
+	return &BudgetService{repo: repo, categoryRepo: categoryRepo}
+}
+
+// Create создает бюджет с лимитами по категориям.
+func (s *BudgetService) Create(ctx context.Context, b budget.Budget) (*budget.Budget, error) {
+	if err := s.prepare(ctx, &b); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, &b)
+}
+
+// Get возвращает бюджет пользователя по id.
+func (s *BudgetService) Get(ctx context.Context, id, userID int) (*budget.Budget, error) {
+	b, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBudgetNotFound
+	}
+	return b, err
+}
+
+// List возвращает все бюджеты пользователя.
+func (s *BudgetService) List(ctx context.Context, userID int) ([]budget.Budget, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// Update изменяет бюджет; переданные лимиты полностью заменяют прежние.
+func (s *BudgetService) Update(ctx context.Context, b budget.Budget) (*budget.Budget, error) {
+	if err := s.prepare(ctx, &b); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, &b)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrBudgetNotFound
+	}
+	return updated, err
+}
+
+// Delete удаляет бюджет пользователя.
+func (s *BudgetService) Delete(ctx context.Context, id, userID int) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrBudgetNotFound
+	}
+	return err
+}
+
+// Progress рассчитывает исполнение бюджета за период, содержащий дату date.
+// Если включён перенос остатков, неизрасходованная часть лимита каждого прошлого периода
+// (начиная с первого) добавляется к следующему; перерасход на следующий период не переносится.
+func (s *BudgetService) Progress(ctx context.Context, id, userID int, date time.Time) (*budget.Progress, error) {
+	b, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if date.Before(b.StartDate) {
+		return nil, apperror.Validation("budget.date_before_start")
+	}
+	periodStart := b.PeriodStart(date)
+	periodEnd := b.NextPeriodStart(periodStart).AddDate(0, 0, -1)
+	from := periodStart
+	if b.Rollover {
+		from = b.StartDate
+	}
+	spending, err := s.repo.SpendingByDay(ctx, b, from, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	carry := map[int]money.Decimal{}
+	var spent map[int]money.Decimal
+	i := 0
+	for start := from; ; start = b.NextPeriodStart(start) {
+		next := b.NextPeriodStart(start)
+		spent = map[int]money.Decimal{}
+		for ; i < len(spending) && spending[i].Date.Before(next); i++ {
+			spent[spending[i].CategoryID] = spent[spending[i].CategoryID].Add(spending[i].Amount)
+		}
+		if !start.Before(periodStart) {
+			break
+		}
+		for _, l := range b.Limits {
+			left := l.Amount.Add(carry[l.CategoryID]).Sub(spent[l.CategoryID])
+			if !left.IsPositive() {
+				left = money.Zero
+			}
+			carry[l.CategoryID] = left
+		}
+	}
+
+	places := money.MinorUnits(b.Currency)
+	progress := &budget.Progress{
+		BudgetID:    b.ID,
+		Currency:    b.Currency,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Categories:  []budget.CategoryProgress{},
+	}
+	for _, l := range b.Limits {
+		available := l.Amount.Add(carry[l.CategoryID])
+		p := budget.CategoryProgress{
+			CategoryID: l.CategoryID,
+			Limit:      l.Amount.Round(places),
+			RolledOver: carry[l.CategoryID].Round(places),
+			Available:  available.Round(places),
+			Spent:      spent[l.CategoryID].Round(places),
+			Remaining:  available.Sub(spent[l.CategoryID]).Round(places),
+		}
+		if available.IsPositive() {
+			percent, err := spent[l.CategoryID].Mul(money.NewFromInt(100)).Quo(available, percentPlaces)
+			if err != nil {
+				return nil, err
+			}
+			p.Percent = &percent
+		}
+		progress.Categories = append(progress.Categories, p)
+	}
+	return progress, nil
+}
+
+// prepare проверяет бюджет и приводит дату начала к началу периода:
+// для месячных, квартальных и годовых бюджетов — к первому числу месяца.
+func (s *BudgetService) prepare(ctx context.Context, b *budget.Budget) error {
+	b.Name = strings.TrimSpace(b.Name)
+	if b.Name == "" || b.Currency == "" {
+		return apperror.Validation("budget.name_currency_required")
+	}
+	b.Currency = currency.Normalize(b.Currency)
+	if !currency.Valid(b.Currency) {
+		return ErrInvalidCurrency
+	}
+	switch b.Period {
+	case budget.PeriodWeek:
+	case budget.PeriodMonth, budget.PeriodQuarter, budget.PeriodYear:
+		b.StartDate = time.Date(b.StartDate.Year(), b.StartDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return apperror.Validation("budget.invalid_period")
+	}
+	if len(b.Limits) == 0 {
+		return apperror.Validation("budget.limits_required")
+	}
+	seen := map[int]bool{}
+	for _, l := range b.Limits {
+		if seen[l.CategoryID] {
+			return apperror.Validation("budget.duplicate_limit")
+		}
+		seen[l.CategoryID] = true
+		if !l.Amount.IsPositive() {
+			return apperror.Validation("budget.limit_not_positive")
+		}
+		if !money.FitsCurrency(l.Amount, b.Currency) {
+			return apperror.Validation("money.too_many_decimals")
+		}
+		c, err := s.categoryRepo.GetByID(ctx, l.CategoryID, b.UserID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if c.Kind != category.KindExpense {
+			return apperror.Validation("budget.expense_categories_only")
+		}
+	}
+	return nil
+}
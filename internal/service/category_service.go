@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+// ErrCategoryNotFound возвращается, если категория не найдена среди категорий пользователя.
+var ErrCategoryNotFound = apperror.NotFound("category_not_found")
+
+// defaultCategories — набор категорий, создаваемый каждому пользователю при регистрации.
+// Названия — ключи каталогов i18n: их переводит defaultCategoriesFor на язык пользователя.
+// Пользователь может переименовать, перенести или удалить любую из них.
+var defaultCategories = []category.Default{
+	{Name: "category.default.groceries", Kind: category.KindExpense},
+	{Name: "category.default.restaurants", Kind: category.KindExpense},
+	{Name: "category.default.transport", Kind: category.KindExpense, Children: []string{"category.default.taxi", "category.default.public_transport", "category.default.fuel"}},
+	{Name: "category.default.housing", Kind: category.KindExpense, Children: []string{"category.default.rent", "category.default.utilities"}},
+	{Name: "category.default.health", Kind: category.KindExpense},
+	{Name: "category.default.entertainment", Kind: category.KindExpense},
+	{Name: "category.default.clothing", Kind: category.KindExpense},
+	{Name: "category.default.telecom", Kind: category.KindExpense},
+	{Name: "category.default.salary", Kind: category.KindIncome},
+	{Name: "category.default.gifts", Kind: category.KindIncome},
+	{Name: "category.default.interest", Kind: category.KindIncome},
+	{Name: "category.default.other_income", Kind: category.KindIncome},
+}
+
+// defaultCategoriesFor возвращает набор категорий по умолчанию с названиями на языке lang.
+func defaultCategoriesFor(lang string) []category.Default {
+	result := make([]category.Default, len(defaultCategories))
+	for i, d := range defaultCategories {
+		children := make([]string, len(d.Children))
+		for j, child := range d.Children {
+			children[j] = i18n.Translate(lang, child)
+		}
+		result[i] = category.Default{Name: i18n.Translate(lang, d.Name), Kind: d.Kind, Children: children}
+	}
+	return result
+}
+
+// CategoryService реализует бизнес-логику для категорий доходов и расходов.
+type CategoryService struct {
+	repo *repository.CategoryRepository // Репозиторий категорий
+}
+
+// NewCategoryService создает новый экземпляр CategoryService.
+func NewCategoryService(repo *repository.CategoryRepository) *CategoryService {
+	return &CategoryService{repo: repo}
+}
+
+// List возвращает категории пользователя. Пустой kind — категории всех видов.
+func (s *CategoryService) List(ctx context.Context, userID int, kind string) ([]category.Category, error) {
+	return s.repo.List(ctx, userID, kind)
+}
+
+// Get возвращает категорию пользователя по id.
+func (s *CategoryService) Get(ctx context.Context, id, userID int) (*category.Category, error) {
+	c, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrCategoryNotFound
+	}
+	return c, err
+}
+
+// Create создает категорию. Дочерняя категория наследует вид родительской.
+func (s *CategoryService) Create(ctx context.Context, c category.Category) (*category.Category, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return nil, apperror.Validation("category.name_required")
+	}
+	if c.ParentID != nil {
+		parent, err := s.Get(ctx, *c.ParentID, c.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Kind != c.Kind {
+			return nil, apperror.Validation("category.kind_mismatch_parent")
+		}
+	}
+	created, err := s.repo.Create(ctx, &c)
+	return created, mapCategoryError(err)
+}
+
+// Update переименовывает категорию и меняет её родителя.
+// Категорию нельзя сделать дочерней для неё самой или для её потомка.
+func (s *CategoryService) Update(ctx context.Context, c category.Category) (*category.Category, error) {
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return nil, apperror.Validation("category.name_required")
+	}
+	current, err := s.Get(ctx, c.ID, c.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if c.ParentID != nil {
+		parent, err := s.Get(ctx, *c.ParentID, c.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.Kind != current.Kind {
+			return nil, apperror.Validation("category.kind_mismatch_parent")
+		}
+		descendants, err := s.repo.DescendantIDs(ctx, c.UserID, c.ID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(descendants, parent.ID) {
+			return nil, apperror.Validation("category.move_into_itself")
+		}
+	}
+	updated, err := s.repo.Update(ctx, &c)
+	return updated, mapCategoryError(err)
+}
+
+// Delete удаляет категорию. Дочерние категории поднимаются на уровень выше,
+// операции удалённой категории остаются без категории.
+func (s *CategoryService) Delete(ctx context.Context, id, userID int) error {
+	return mapCategoryError(s.repo.Delete(ctx, id, userID))
+}
+
+// Merge объединяет категорию sourceID с targetID: операции и дочерние категории
+// переносятся в targetID, а sourceID удаляется.
+func (s *CategoryService) Merge(ctx context.Context, userID, sourceID, targetID int) error {
+	if sourceID == targetID {
+		return apperror.Validation("category.merge_into_itself")
+	}
+	source, err := s.Get(ctx, sourceID, userID)
+	if err != nil {
+		return err
+	}
+	target, err := s.Get(ctx, targetID, userID)
+	if err != nil {
+		return err
+	}
+	if source.Kind != target.Kind {
+		return apperror.Validation("category.merge_kind_mismatch")
+	}
+	descendants, err := s.repo.DescendantIDs(ctx, userID, sourceID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(descendants, targetID) {
+		return apperror.Validation("category.merge_into_child")
+	}
+	return mapCategoryError(s.repo.Merge(ctx, userID, sourceID, targetID))
+}
+
+// mapCategoryError переводит ошибки репозитория категорий в ошибки сервиса.
+func mapCategoryError(err error) error {
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrCategoryNotFound
+	case errors.Is(err, repository.ErrCategoryExists):
+		return apperror.Conflict("category_exists")
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+)
+
+func TestDefaultCategoriesFor(t *testing.T) {
+	ru, en := defaultCategoriesFor(i18n.LangRU), defaultCategoriesFor(i18n.LangEN)
+	if ru[0].Name != "Продукты" || en[0].Name != "Groceries" {
+		t.Errorf("first category = %q / %q, want Продукты / Groceries", ru[0].Name, en[0].Name)
+	}
+	for _, categories := range [][]string{names(ru), names(en)} {
+		for _, name := range categories {
+			if strings.HasPrefix(name, "category.default.") {
+				t.Errorf("category name %q is not translated", name)
+			}
+		}
+	}
+	if defaultCategories[0].Name != "category.default.groceries" {
+		t.Error("defaultCategoriesFor modified defaultCategories")
+	}
+}
+
+func names(categories []category.Default) []string {
+	result := []string{}
+	for _, c := range categories {
+		result = append(result, c.Name)
+		result = append(result, c.Children...)
+	}
+	return result
+}
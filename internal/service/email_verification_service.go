@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/mailer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+const (
+	emailVerificationTTL        = 24 * time.Hour  // Время жизни ссылки для подтверждения email
+	emailVerificationThrottle   = 2 * time.Minute // Минимальный интервал между письмами со ссылкой одному пользователю
+	emailVerificationDailyLimit = 5               // Максимальное количество писем со ссылкой одному пользователю за сутки
+)
+
+var (
+	// ErrInvalidVerificationToken возвращается для неизвестного, уже использованного или истёкшего токена подтверждения email.
+	ErrInvalidVerificationToken = apperror.New(apperror.KindValidation, "invalid_verification_token")
+	// ErrEmailNotVerified возвращается при входе с неподтверждённым email, если такой вход запрещён.
+	ErrEmailNotVerified = apperror.Forbidden("email_not_verified")
+)
+
+// emailVerificationUsers — методы репозитория пользователей, нужные EmailVerificationService.
+type emailVerificationUsers interface {
+	FindByEmail(ctx context.Context, email string) (*user.User, error)
+}
+
+// emailVerificationTokens — хранилище токенов подтверждения email (repository.EmailVerificationRepository).
+type emailVerificationTokens interface {
+	Create(ctx context.Context, userID int, tokenHash string, expiresAt, createdAt time.Time) error
+	CountSince(ctx context.Context, userID int, since time.Time) (int, error)
+	Verify(ctx context.Context, tokenHash string, now time.Time) (int, error)
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EmailVerificationService реализует подтверждение email: отправку ссылки с одноразовым токеном
+// и отметку email подтверждённым по этому токену.
+type EmailVerificationService struct {
+	users     emailVerificationUsers  // Репозиторий пользователей
+	tokens    emailVerificationTokens // Репозиторий токенов подтверждения email
+	mail      *mailDelivery           // Фоновая отправка писем
+	verifyURL string                  // Адрес страницы подтверждения email; токен добавляется параметром token
+}
+
+// NewEmailVerificationService создает новый экземпляр EmailVerificationService.
+func NewEmailVerificationService(users *repository.UserRepository, tokens *repository.EmailVerificationRepository, mailer mailer.Mailer, verifyURL string) *EmailVerificationService {
+	return &EmailVerificationService{users: users, tokens: tokens, mail: newMailDelivery(mailer), verifyURL: verifyURL}
+}
+
+// Send выдаёт пользователю новый токен подтверждения email и отправляет ссылку с ним на email на языке lang.
+// Ссылки из предыдущих писем перестают действовать. Письмо отправляется в фоне, а ошибка его доставки
+// только пишется в лог: иначе по ответу или времени ответа Resend можно было бы узнать, зарегистрирован ли адрес.
+func (s *EmailVerificationService) Send(ctx context.Context, userID int, email, lang string) error {
+	verifyToken, err := generateRandomString(32)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	now := time.Now()
+	if err := s.tokens.Create(ctx, userID, hashToken(verifyToken), now.Add(emailVerificationTTL), now); err != nil {
+		return err
+	}
+	link, err := linkWithToken(s.verifyURL, verifyToken)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	s.mail.send(mailer.Message{
+		To:      email,
+		Subject: i18n.Translate(lang, "mail.email_verification.subject"),
+		Body:    i18n.Translate(lang, "mail.email_verification.body", link, int(emailVerificationTTL.Hours())),
+	}, fmt.Sprintf("письмо для подтверждения email пользователю %d", userID))
+	return nil
+}
+
+// Resend повторно отправляет ссылку для подтверждения email на языке профиля, а если он не выбран — на языке lang.
+// Для неизвестного или уже подтверждённого email ничего не отправляется, но ошибка не возвращается, чтобы
+// по ответу нельзя было узнать, зарегистрирован ли адрес. Так же молча пропускаются запросы чаще
+// emailVerificationThrottle и сверх emailVerificationDailyLimit писем за сутки.
+func (s *EmailVerificationService) Resend(ctx context.Context, email, lang string) error {
+	u, err := s.users.FindByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u.EmailVerified() {
+		return nil
+	}
+	now := time.Now()
+	recent, err := s.tokens.CountSince(ctx, u.ID, now.Add(-emailVerificationThrottle))
+	if err != nil {
+		return err
+	}
+	daily, err := s.tokens.CountSince(ctx, u.ID, now.Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+	if recent > 0 || daily >= emailVerificationDailyLimit {
+		return nil
+	}
+	if i18n.Supported(u.Language) {
+		lang = u.Language
+	}
+	return s.Send(ctx, u.ID, u.Email, lang)
+}
+
+// Verify отмечает email подтверждённым по токену из ссылки. Токен одноразовый.
+// Новые разрешения попадают в access токен при следующем обновлении токенов.
+func (s *EmailVerificationService) Verify(ctx context.Context, verifyToken string) error {
+	_, err := s.tokens.Verify(ctx, hashToken(verifyToken), time.Now())
+	if errors.Is(err, repository.ErrEmailVerificationTokenInvalid) {
+		return ErrInvalidVerificationToken
+	}
+	return err
+}
+
+// PurgeExpired удаляет истёкшие токены подтверждения email. Вызывается планировщиком.
+func (s *EmailVerificationService) PurgeExpired(ctx context.Context, now time.Time) error {
+	_, err := s.tokens.DeleteExpired(ctx, now)
+	return err
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/mailer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/user"
+)
+
+type fakeUsers map[string]*user.User
+
+func (f fakeUsers) FindByEmail(_ context.Context, email string) (*user.User, error) {
+	if u, ok := f[email]; ok {
+		return u, nil
+	}
+	return nil, pgx.ErrNoRows
+}
+
+type fakeToken struct {
+	userID      int
+	createdAt   time.Time
+	invalidated bool
+}
+
+// fakeTokens повторяет поведение EmailVerificationRepository: старые токены помечаются, но не удаляются.
+type fakeTokens struct {
+	tokens []fakeToken
+}
+
+func (f *fakeTokens) Create(_ context.Context, userID int, _ string, _, createdAt time.Time) error {
+	for i := range f.tokens {
+		if f.tokens[i].userID == userID {
+			f.tokens[i].invalidated = true
+		}
+	}
+	f.tokens = append(f.tokens, fakeToken{userID: userID, createdAt: createdAt})
+	return nil
+}
+
+func (f *fakeTokens) CountSince(_ context.Context, userID int, since time.Time) (int, error) {
+	count := 0
+	for _, t := range f.tokens {
+		if t.userID == userID && !t.createdAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeTokens) Verify(context.Context, string, time.Time) (int, error) { return 0, nil }
+
+func (f *fakeTokens) DeleteExpired(context.Context, time.Time) (int64, error) { return 0, nil }
+
+// age сдвигает время выдачи всех токенов в прошлое, имитируя ожидание между запросами.
+func (f *fakeTokens) age(d time.Duration) {
+	for i := range f.tokens {
+		f.tokens[i].createdAt = f.tokens[i].createdAt.Add(-d)
+	}
+}
+
+type fakeMailer struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+	err  error
+}
+
+func (f *fakeMailer) Send(_ context.Context, msg mailer.Message) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, msg)
+	return f.err
+}
+
+// sentCount дожидается фоновых отправок сервиса и возвращает количество отправленных писем.
+func sentCount(s *EmailVerificationService, mail *fakeMailer) int {
+	s.mail.wait()
+	mail.mu.Lock()
+	defer mail.mu.Unlock()
+	return len(mail.sent)
+}
+
+func newTestVerificationService(mail *fakeMailer) (*EmailVerificationService, *fakeTokens) {
+	tokens := &fakeTokens{}
+	users := fakeUsers{"user@example.com": {ID: 1, Email: "user@example.com"}}
+	return &EmailVerificationService{users: users, tokens: tokens, mail: newMailDelivery(mail), verifyURL: "https://app.example.com/verify"}, tokens
+}
+
+func TestResendDailyLimit(t *testing.T) {
+	mail := &fakeMailer{}
+	s, tokens := newTestVerificationService(mail)
+	ctx := context.Background()
+
+	for i := 1; i <= emailVerificationDailyLimit+1; i++ {
+		if err := s.Resend(ctx, "user@example.com", "en"); err != nil {
+			t.Fatalf("Resend %d error: %v", i, err)
+		}
+		tokens.age(emailVerificationThrottle + time.Minute)
+	}
+	if sentCount(s, mail) != emailVerificationDailyLimit {
+		t.Fatalf("sent %d emails, want %d: the last resend within 24h must send nothing", sentCount(s, mail), emailVerificationDailyLimit)
+	}
+
+	tokens.age(24 * time.Hour)
+	if err := s.Resend(ctx, "user@example.com", "en"); err != nil {
+		t.Fatalf("Resend error: %v", err)
+	}
+	if sentCount(s, mail) != emailVerificationDailyLimit+1 {
+		t.Errorf("sent %d emails after 24h, want %d", sentCount(s, mail), emailVerificationDailyLimit+1)
+	}
+}
+
+func TestResendThrottle(t *testing.T) {
+	mail := &fakeMailer{}
+	s, _ := newTestVerificationService(mail)
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if err := s.Resend(ctx, "user@example.com", "en"); err != nil {
+			t.Fatalf("Resend error: %v", err)
+		}
+	}
+	if sentCount(s, mail) != 1 {
+		t.Errorf("sent %d emails, want 1", sentCount(s, mail))
+	}
+}
+
+// TestResendDoesNotRevealEmail проверяет, что ответ не зависит от того, зарегистрирован ли адрес и дошло ли письмо.
+func TestResendDoesNotRevealEmail(t *testing.T) {
+	mail := &fakeMailer{err: errors.New("smtp: connection refused")}
+	s, _ := newTestVerificationService(mail)
+	ctx := context.Background()
+	if err := s.Resend(ctx, "user@example.com", "en"); err != nil {
+		t.Errorf("Resend with mail failure error = %v, want nil", err)
+	}
+	if sentCount(s, mail) != 1 {
+		t.Errorf("sent %d emails, want 1", sentCount(s, mail))
+	}
+	if err := s.Resend(ctx, "unknown@example.com", "en"); err != nil {
+		t.Errorf("Resend for unknown email error = %v, want nil", err)
+	}
+
+	verified := time.Now()
+	s.users.(fakeUsers)["verified@example.com"] = &user.User{ID: 2, Email: "verified@example.com", EmailVerifiedAt: &verified}
+	if err := s.Resend(ctx, "verified@example.com", "en"); err != nil || sentCount(s, mail) != 1 {
+		t.Errorf("Resend for verified email: error = %v, sent = %d; want nothing sent", err, sentCount(s, mail))
+	}
+}
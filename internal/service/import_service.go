@@ -0,0 +1,566 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/dedup"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/importer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/imports"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+var (
+	// ErrImportProfileNotFound возвращается, если профиль импорта не найден среди профилей пользователя.
+	ErrImportProfileNotFound = apperror.NotFound("import_profile_not_found")
+	// ErrImportBatchNotFound возвращается, если пакет импорта не найден среди пакетов пользователя.
+	ErrImportBatchNotFound = apperror.NotFound("import_not_found")
+)
+
+// columnRoles — допустимые роли колонок в профиле импорта.
+var columnRoles = map[string]bool{
+	imports.ColumnDate:   true,
+	imports.ColumnAmount: true,
+	imports.ColumnDebit:  true,
+	imports.ColumnCredit: true,
+	imports.ColumnPayee:  true,
+	imports.ColumnNote:   true,
+}
+
+// ImportService реализует импорт банковских выписок: предпросмотр и подтверждение.
+type ImportService struct {
+	repo            *repository.ImportRepository      // Репозиторий импорта
+	accountRepo     *repository.BankAccountRepository // Репозиторий банковских аккаунтов
+	transactionRepo *repository.TransactionRepository // Репозиторий операций (поиск дубликатов)
+	ruleRepo        *repository.RuleRepository        // Репозиторий правил категоризации
+	categoryRepo    *repository.CategoryRepository    // Репозиторий категорий
+}
+
+// NewImportService создает новый экземпляр ImportService.
+func NewImportService(repo *repository.ImportRepository, accountRepo *repository.BankAccountRepository, transactionRepo *repository.TransactionRepository, ruleRepo *repository.RuleRepository, categoryRepo *repository.CategoryRepository) *ImportService {
+	return &ImportService{repo: repo, accountRepo: accountRepo, transactionRepo: transactionRepo, ruleRepo: ruleRepo, categoryRepo: categoryRepo}
+}
+
+// CreateProfile создает профиль сопоставления колонок CSV.
+func (s *ImportService) CreateProfile(ctx context.Context, p imports.Profile) (*imports.Profile, error) {
+	if err := validateImportProfile(&p); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.CreateProfile(ctx, &p)
+	return created, mapImportError(err)
+}
+
+// GetProfile возвращает профиль импорта пользователя по id.
+func (s *ImportService) GetProfile(ctx context.Context, id, userID int) (*imports.Profile, error) {
+	p, err := s.repo.GetProfile(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrImportProfileNotFound
+	}
+	return p, err
+}
+
+// ListProfiles возвращает профили импорта пользователя.
+func (s *ImportService) ListProfiles(ctx context.Context, userID int) ([]imports.Profile, error) {
+	return s.repo.ListProfiles(ctx, userID)
+}
+
+// UpdateProfile изменяет профиль импорта.
+func (s *ImportService) UpdateProfile(ctx context.Context, p imports.Profile) (*imports.Profile, error) {
+	if err := validateImportProfile(&p); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.UpdateProfile(ctx, &p)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrImportProfileNotFound
+	}
+	return updated, mapImportError(err)
+}
+
+// DeleteProfile удаляет профиль импорта.
+func (s *ImportService) DeleteProfile(ctx context.Context, id, userID int) error {
+	err := s.repo.DeleteProfile(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrImportProfileNotFound
+	}
+	return err
+}
+
+// PreviewCSV разбирает CSV-выписку по сохранённому профилю и сохраняет результат как пакет в статусе preview.
+// Операции не создаются до вызова Commit.
+func (s *ImportService) PreviewCSV(ctx context.Context, userID, accountID, profileID int, fileName string, r io.Reader) (*imports.Batch, error) {
+	profile, err := s.GetProfile(ctx, profileID, userID)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := s.getAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := importer.ParseCSV(r, *profile)
+	if err != nil {
+		return nil, invalidStatement(err)
+	}
+	return s.preview(ctx, acc, imports.FormatCSV, fileName, importer.Statement{Rows: rows})
+}
+
+// PreviewOFX разбирает выписку OFX/QFX и сохраняет результат как пакет в статусе preview.
+// Операции, чей FITID уже импортирован на аккаунт, помечаются и при подтверждении пропускаются.
+func (s *ImportService) PreviewOFX(ctx context.Context, userID, accountID int, fileName string, r io.Reader) (*imports.Batch, error) {
+	acc, err := s.getAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := importer.ParseOFX(r)
+	if err != nil {
+		return nil, invalidStatement(err)
+	}
+	if len(statements) > 1 {
+		return nil, apperror.Validation("import.multiple_accounts")
+	}
+	return s.preview(ctx, acc, imports.FormatOFX, fileName, statements[0])
+}
+
+// PreviewQIF разбирает выписку QIF и сохраняет результат как пакет в статусе preview.
+func (s *ImportService) PreviewQIF(ctx context.Context, userID, accountID int, opts importer.QIFOptions, fileName string, r io.Reader) (*imports.Batch, error) {
+	switch opts.DateOrder {
+	case "", importer.DateOrderMDY, importer.DateOrderDMY, importer.DateOrderYMD:
+	default:
+		return nil, apperror.Validation("import.invalid_date_order")
+	}
+	if opts.DecimalSeparator != "" && opts.DecimalSeparator != "." && opts.DecimalSeparator != "," {
+		return nil, apperror.Validation("import.invalid_decimal_separator")
+	}
+	acc, err := s.getAccount(ctx, accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	statement, err := importer.ParseQIF(r, opts)
+	if err != nil {
+		return nil, invalidStatement(err)
+	}
+	return s.preview(ctx, acc, imports.FormatQIF, fileName, statement)
+}
+
+// PreviewCAMT053 разбирает выписку camt.053 и сохраняет по пакету в статусе preview на каждый счёт из файла.
+// Счета сопоставляются с аккаунтами по IBAN; accountID (может быть 0) используется, если счёт в файле
+// один и его номер не совпадает ни с одним IBAN.
+func (s *ImportService) PreviewCAMT053(ctx context.Context, userID, accountID int, fileName string, r io.Reader) ([]imports.Batch, error) {
+	statements, err := importer.ParseCAMT053(r)
+	if err != nil {
+		return nil, invalidStatement(err)
+	}
+	return s.previewStatements(ctx, userID, accountID, imports.FormatCAMT053, fileName, statements)
+}
+
+// PreviewMT940 разбирает выписку MT940 и сохраняет по пакету в статусе preview на каждый счёт из файла.
+// Сопоставление счетов с аккаунтами — как в PreviewCAMT053.
+func (s *ImportService) PreviewMT940(ctx context.Context, userID, accountID int, fileName string, r io.Reader) ([]imports.Batch, error) {
+	statements, err := importer.ParseMT940(r)
+	if err != nil {
+		return nil, invalidStatement(err)
+	}
+	return s.previewStatements(ctx, userID, accountID, imports.FormatMT940, fileName, statements)
+}
+
+// GetBatch возвращает пакет импорта пользователя со строками и сверкой баланса.
+func (s *ImportService) GetBatch(ctx context.Context, id, userID int) (*imports.Batch, error) {
+	b, err := s.repo.GetBatch(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrImportBatchNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, s.checkBalance(ctx, b)
+}
+
+// ListBatches возвращает пакеты импорта пользователя без строк.
+func (s *ImportService) ListBatches(ctx context.Context, userID int) ([]imports.Batch, error) {
+	return s.repo.ListBatches(ctx, userID)
+}
+
+// Commit создает операции из корректных строк пакета; строки с ошибками пропускаются.
+// К новым операциям применяются правила категоризации пользователя.
+func (s *ImportService) Commit(ctx context.Context, id, userID int) (*imports.Batch, error) {
+	engine, err := loadRuleEngine(ctx, s.ruleRepo, s.categoryRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	b, err := s.repo.CommitBatch(ctx, id, userID, func(t *transaction.Transaction) { engine.Apply(t, false) })
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrImportBatchNotFound
+	}
+	if err != nil {
+		return nil, mapImportError(err)
+	}
+	return b, s.checkBalance(ctx, b)
+}
+
+// DeleteBatch отменяет неподтверждённый импорт.
+func (s *ImportService) DeleteBatch(ctx context.Context, id, userID int) error {
+	err := s.repo.DeleteBatch(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrImportBatchNotFound
+	}
+	return mapImportError(err)
+}
+
+// getAccount возвращает аккаунт пользователя, в который выполняется импорт.
+func (s *ImportService) getAccount(ctx context.Context, accountID, userID int) (*account.BankAccount, error) {
+	acc, err := s.accountRepo.GetByID(ctx, accountID, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	return acc, err
+}
+
+// preview проверяет строки разобранной выписки для аккаунта и сохраняет их как пакет в статусе preview.
+func (s *ImportService) preview(ctx context.Context, acc *account.BankAccount, format, fileName string, statement importer.Statement) (*imports.Batch, error) {
+	if err := checkStatementCurrency(statement, acc); err != nil {
+		return nil, err
+	}
+	b, err := s.prepareBatch(ctx, acc, format, fileName, statement)
+	if err != nil {
+		return nil, err
+	}
+	b, err = s.repo.CreateBatch(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return b, s.checkBalance(ctx, b)
+}
+
+// previewStatements сопоставляет выписки многосчётного файла с аккаунтами и сохраняет пакеты в одной транзакции.
+// Несколько выписок по одному счёту (например, за последовательные дни) объединяются в один пакет:
+// входящий баланс берётся из первой выписки, итоговый — из последней.
+func (s *ImportService) previewStatements(ctx context.Context, userID, accountID int, format, fileName string, statements []importer.Statement) ([]imports.Batch, error) {
+	numbers := map[string]bool{}
+	for _, st := range statements {
+		numbers[account.NormalizeIBAN(st.AccountNumber)] = true
+	}
+	fallbackID := 0
+	if len(numbers) == 1 {
+		fallbackID = accountID
+	}
+
+	accounts := []*account.BankAccount{}
+	merged := map[int]*importer.Statement{}
+	for _, st := range statements {
+		acc, err := s.resolveStatementAccount(ctx, userID, fallbackID, st.AccountNumber)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkStatementCurrency(st, acc); err != nil {
+			return nil, err
+		}
+		m, ok := merged[acc.ID]
+		if !ok {
+			accounts = append(accounts, acc)
+			m = &importer.Statement{AccountNumber: st.AccountNumber, Currency: st.Currency, Rows: []imports.Row{}}
+			merged[acc.ID] = m
+		}
+		if m.OpeningBalance == nil {
+			m.OpeningBalance = st.OpeningBalance
+		}
+		if st.ClosingBalance != nil {
+			m.ClosingBalance = st.ClosingBalance
+		}
+		m.Rows = append(m.Rows, st.Rows...)
+	}
+
+	batches := make([]*imports.Batch, 0, len(accounts))
+	for _, acc := range accounts {
+		b, err := s.prepareBatch(ctx, acc, format, fileName, *merged[acc.ID])
+		if err != nil {
+			return nil, err
+		}
+		batches = append(batches, b)
+	}
+	created, err := s.repo.CreateBatches(ctx, batches)
+	if err != nil {
+		return nil, err
+	}
+	for i := range created {
+		if err := s.checkBalance(ctx, &created[i]); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+// resolveStatementAccount находит аккаунт пользователя по номеру счёта из выписки (IBAN).
+// Если такого IBAN нет, используется fallbackID (0 — не задан), при условии что у этого аккаунта не указан другой IBAN.
+func (s *ImportService) resolveStatementAccount(ctx context.Context, userID, fallbackID int, number string) (*account.BankAccount, error) {
+	iban := account.NormalizeIBAN(number)
+	if iban != "" {
+		acc, err := s.accountRepo.GetByIBAN(ctx, userID, iban)
+		if err == nil {
+			if fallbackID != 0 && acc.ID != fallbackID {
+				return nil, apperror.Validation("import.account_linked_elsewhere", iban)
+			}
+			return acc, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+	}
+	if fallbackID == 0 {
+		if iban == "" {
+			return nil, apperror.Validation("import.account_not_specified")
+		}
+		return nil, apperror.NotFound("statement_account_not_found", iban)
+	}
+	acc, err := s.getAccount(ctx, fallbackID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.IBAN != "" && account.ValidIBAN(iban) && acc.IBAN != iban {
+		return nil, apperror.Validation("import.account_mismatch", iban)
+	}
+	return acc, nil
+}
+
+// flagDuplicates сопоставляет импортируемые строки с уже существующими доходами и расходами аккаунта
+// и отмечает вероятные дубликаты (например, операции, внесённые вручную до импорта).
+func (s *ImportService) flagDuplicates(ctx context.Context, acc *account.BankAccount, rows []imports.Row) error {
+	entries := []dedup.Entry{}
+	var from, to time.Time
+	for i, row := range rows {
+		if !row.Importable() {
+			continue
+		}
+		entries = append(entries, dedup.Entry{ID: i, AccountID: acc.ID, Date: row.Date, Amount: row.Amount, Payee: row.Payee, ExternalID: row.ExternalID})
+		if from.IsZero() || row.Date.Before(from) {
+			from = row.Date
+		}
+		if row.Date.After(to) {
+			to = row.Date
+		}
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	transactions, err := s.transactionRepo.ListInRange(ctx, acc.UserID, acc.ID,
+		from.AddDate(0, 0, -dedup.DateWindowDays), to.AddDate(0, 0, dedup.DateWindowDays), maxDuplicateScan)
+	if err != nil {
+		return err
+	}
+	candidates := make([]dedup.Entry, 0, len(transactions))
+	for _, t := range transactions {
+		candidates = append(candidates, dedupEntry(t))
+	}
+	for _, m := range dedup.Find(entries, candidates, false) {
+		id := m.Candidate
+		rows[m.Item].DuplicateOf = &id
+		rows[m.Item].DuplicateScore = math.Round(m.Score*100) / 100
+	}
+	return nil
+}
+
+// checkStatementCurrency проверяет, что валюта выписки (если она указана) совпадает с валютой аккаунта.
+func checkStatementCurrency(statement importer.Statement, acc *account.BankAccount) error {
+	if statement.Currency != "" && !strings.EqualFold(statement.Currency, acc.Currency) {
+		return apperror.Validation("import.currency_mismatch")
+	}
+	return nil
+}
+
+// prepareBatch проверяет строки разобранной выписки для аккаунта и собирает пакет для сохранения.
+// Строки с лишними знаками после запятой и повторяющимся в файле ExternalID помечаются ошибкой,
+// строки с уже импортированным на аккаунт ExternalID — флагом AlreadyImported, а строки, похожие
+// на существующие операции аккаунта, — ссылкой DuplicateOf.
+func (s *ImportService) prepareBatch(ctx context.Context, acc *account.BankAccount, format, fileName string, statement importer.Statement) (*imports.Batch, error) {
+	rows := statement.Rows
+	seen := map[string]bool{}
+	externalIDs := []string{}
+	for i := range rows {
+		if !rows[i].Valid() {
+			continue
+		}
+		if !money.FitsCurrency(rows[i].Amount, acc.Currency) {
+			rows[i].Error = "import.row_too_many_decimals"
+			continue
+		}
+		if id := rows[i].ExternalID; id != "" {
+			if seen[id] {
+				rows[i].Error = "import.row_duplicate_external_id"
+				continue
+			}
+			seen[id] = true
+			externalIDs = append(externalIDs, id)
+		}
+	}
+	existing, err := s.repo.ExistingExternalIDs(ctx, acc.ID, externalIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if rows[i].Valid() && existing[rows[i].ExternalID] {
+			rows[i].AlreadyImported = true
+		}
+	}
+	if err := s.flagDuplicates(ctx, acc, rows); err != nil {
+		return nil, err
+	}
+	return &imports.Batch{
+		UserID:         acc.UserID,
+		AccountID:      acc.ID,
+		Format:         format,
+		FileName:       fileName,
+		OpeningBalance: statement.OpeningBalance,
+		ClosingBalance: statement.ClosingBalance,
+		Rows:           rows,
+	}, nil
+}
+
+// checkBalance сверяет балансы выписки с балансом аккаунта.
+// Итоговый баланс сравнивается с балансом аккаунта на ту же дату; для неподтверждённого пакета к нему
+// добавляются строки, которые будут импортированы. Входящий баланс сравнивается с балансом аккаунта
+// на конец дня перед первой операцией выписки (или на дату баланса, если операций нет).
+func (s *ImportService) checkBalance(ctx context.Context, b *imports.Batch) error {
+	if b.OpeningBalance == nil && b.ClosingBalance == nil {
+		return nil
+	}
+	acc, err := s.getAccount(ctx, b.AccountID, b.UserID)
+	if err != nil {
+		return err
+	}
+	places := money.MinorUnits(acc.Currency)
+
+	if b.OpeningBalance != nil {
+		date := b.OpeningBalance.Date
+		if first, ok := firstRowDate(b.Rows); ok {
+			date = first.AddDate(0, 0, -1)
+		}
+		expected, err := s.accountRepo.BalanceAt(ctx, b.AccountID, b.UserID, date)
+		if err != nil {
+			return err
+		}
+		b.OpeningBalanceCheck = newBalanceCheck(b.OpeningBalance, expected, places)
+	}
+	if b.ClosingBalance != nil {
+		expected, err := s.accountRepo.BalanceAt(ctx, b.AccountID, b.UserID, b.ClosingBalance.Date)
+		if err != nil {
+			return err
+		}
+		if b.Status == imports.StatusPreview {
+			for _, row := range b.Rows {
+				if row.Importable() && !row.Date.After(b.ClosingBalance.Date) {
+					expected = expected.Add(row.Amount)
+				}
+			}
+		}
+		b.BalanceCheck = newBalanceCheck(b.ClosingBalance, expected, places)
+	}
+	return nil
+}
+
+// newBalanceCheck сравнивает баланс выписки с расчётным балансом аккаунта с точностью валюты.
+func newBalanceCheck(statement *imports.Balance, expected money.Decimal, places int32) *imports.BalanceCheck {
+	statement.Amount = statement.Amount.Round(places)
+	difference := statement.Amount.Sub(expected).Round(places)
+	return &imports.BalanceCheck{
+		Date:       statement.Date,
+		Statement:  statement.Amount,
+		Expected:   expected.Round(places),
+		Difference: difference,
+		Matches:    difference.IsZero(),
+	}
+}
+
+// firstRowDate возвращает самую раннюю дату среди корректных строк выписки.
+func firstRowDate(rows []imports.Row) (time.Time, bool) {
+	var first time.Time
+	found := false
+	for _, row := range rows {
+		if row.Valid() && (!found || row.Date.Before(first)) {
+			first, found = row.Date, true
+		}
+	}
+	return first, found
+}
+
+// validateImportProfile проверяет профиль импорта и подставляет значения по умолчанию.
+func validateImportProfile(p *imports.Profile) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return apperror.Validation("import.profile_name_required")
+	}
+	if p.Delimiter == "" {
+		p.Delimiter = ","
+	}
+	if p.Encoding == "" {
+		p.Encoding = imports.EncodingUTF8
+	}
+	if p.DecimalSeparator == "" {
+		p.DecimalSeparator = "."
+	}
+	if utf8.RuneCountInString(p.Delimiter) != 1 || p.Delimiter == `"` || p.Delimiter == "\n" || p.Delimiter == "\r" {
+		return apperror.Validation("import.invalid_delimiter")
+	}
+	if p.Encoding != imports.EncodingUTF8 && p.Encoding != imports.EncodingWindows1251 {
+		return apperror.Validation("import.unsupported_encoding")
+	}
+	if p.DecimalSeparator != "." && p.DecimalSeparator != "," {
+		return apperror.Validation("import.invalid_decimal_separator")
+	}
+	if p.SkipRows < 0 {
+		return apperror.Validation("import.negative_skip_rows")
+	}
+	if _, err := importer.DateLayout(p.DateFormat); err != nil {
+		return err
+	}
+	for role, index := range p.Columns {
+		if !columnRoles[role] {
+			return apperror.Validation("import.unknown_column_role", role)
+		}
+		if index < 0 {
+			return apperror.Validation("import.negative_column")
+		}
+	}
+	required := []string{imports.ColumnDate, imports.ColumnAmount}
+	switch p.SignConvention {
+	case imports.SignSigned, imports.SignInverted:
+	case imports.SignDebitCredit:
+		required = []string{imports.ColumnDate, imports.ColumnDebit, imports.ColumnCredit}
+	default:
+		return apperror.Validation("import.invalid_sign_convention")
+	}
+	for _, role := range required {
+		if _, ok := p.Columns[role]; !ok {
+			return apperror.Validation("import.missing_column", role)
+		}
+	}
+	return nil
+}
+
+// mapImportError переводит ошибки репозитория импорта в ошибки сервиса.
+func mapImportError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrImportProfileExists):
+		return apperror.Conflict("import_profile_exists")
+	case errors.Is(err, repository.ErrBatchCommitted):
+		return apperror.Conflict("import_committed")
+	}
+	return err
+}
+
+// invalidStatement превращает ошибку разбора файла выписки в ошибку валидации. Ошибки парсеров уже
+// типизированы; прочие (например, ошибки чтения файла) заменяются общей ошибкой invalid_statement.
+func invalidStatement(err error) error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return apperror.New(apperror.KindValidation, "invalid_statement")
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/mailer"
+)
+
+// mailDeliveryTimeout ограничивает фоновую отправку одного письма.
+const mailDeliveryTimeout = time.Minute
+
+// mailDelivery отправляет письма в фоне, вне обработки запроса: время ответа не зависит от того,
+// отправлялось ли письмо и сколько длилась доставка, поэтому по нему нельзя узнать, зарегистрирован ли адрес.
+type mailDelivery struct {
+	mailer mailer.Mailer  // Способ доставки писем
+	wg     sync.WaitGroup // Незавершённые отправки
+}
+
+// newMailDelivery создает новый экземпляр mailDelivery.
+func newMailDelivery(m mailer.Mailer) *mailDelivery {
+	return &mailDelivery{mailer: m}
+}
+
+// send ставит письмо в отправку и сразу возвращает управление. Письмо отправляется с собственным
+// контекстом: завершение запроса его не отменяет. Ошибка доставки пишется в лог с описанием what.
+func (d *mailDelivery) send(msg mailer.Message, what string) {
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), mailDeliveryTimeout)
+		defer cancel()
+		if err := d.mailer.Send(ctx, msg); err != nil {
+			log.Printf("Не удалось отправить %s: %v", what, err)
+		}
+	}()
+}
+
+// wait дожидается завершения всех начатых отправок.
+func (d *mailDelivery) wait() {
+	d.wg.Wait()
+}
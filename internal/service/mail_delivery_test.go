@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/mailer"
+)
+
+// blockingMailer не завершает отправку, пока не закрыт release.
+type blockingMailer struct {
+	release chan struct{}
+	sent    chan mailer.Message
+}
+
+func (m *blockingMailer) Send(ctx context.Context, msg mailer.Message) error {
+	<-m.release
+	m.sent <- msg
+	return nil
+}
+
+// TestMailDeliveryDoesNotBlock проверяет, что send не ждёт доставки письма.
+func TestMailDeliveryDoesNotBlock(t *testing.T) {
+	m := &blockingMailer{release: make(chan struct{}), sent: make(chan mailer.Message, 1)}
+	d := newMailDelivery(m)
+
+	done := make(chan struct{})
+	go func() {
+		d.send(mailer.Message{To: "user@example.com"}, "письмо")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("send ждёт завершения доставки")
+	}
+
+	close(m.release)
+	d.wait()
+	if msg := <-m.sent; msg.To != "user@example.com" {
+		t.Errorf("To = %q", msg.To)
+	}
+}
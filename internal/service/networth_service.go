@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/account"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/networth"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+const (
+	defaultNetWorthPeriodDays = 30   // Период истории капитала по умолчанию
+	maxNetWorthPoints         = 1000 // Максимальное количество точек в ряду истории капитала
+)
+
+// NetWorthService реализует бизнес-логику расчёта капитала пользователя и его истории.
+type NetWorthService struct {
+	snapshots *repository.SnapshotRepository // Репозиторий снимков балансов
+	userRepo  *repository.UserRepository     // Репозиторий пользователей (домашняя валюта)
+	accounts  *BankAccountService            // Сервис аккаунтов для текущего капитала
+	rates     *RateService                   // Сервис курсов валют
+}
+
+// NewNetWorthService создает новый экземпляр NetWorthService.
+func NewNetWorthService(snapshots *repository.SnapshotRepository, userRepo *repository.UserRepository, accounts *BankAccountService, rates *RateService) *NetWorthService {
+	return &NetWorthService{snapshots: snapshots, userRepo: userRepo, accounts: accounts, rates: rates}
+}
+
+// Current возвращает текущий капитал пользователя: сумму балансов всех аккаунтов в домашней валюте.
+func (s *NetWorthService) Current(ctx context.Context, userID int) (*account.Totals, error) {
+	return s.accounts.Totals(ctx, userID, "")
+}
+
+// History возвращает капитал пользователя в домашней валюте за период [from, to] с шагом granularity
+// (по умолчанию — по дням за последние 30 дней). Баланс аккаунта на дату точки берётся из последнего
+// снимка не позже неё и пересчитывается по курсу на ту же дату. Курсы за период загружаются заранее —
+// по одному запросу на пару валют, а не на каждую точку.
+func (s *NetWorthService) History(ctx context.Context, userID int, from, to time.Time, granularity string) (*networth.Series, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	switch granularity {
+	case "":
+		granularity = networth.GranularityDay
+	case networth.GranularityDay, networth.GranularityWeek, networth.GranularityMonth:
+	default:
+		return nil, apperror.Validation("networth.invalid_granularity")
+	}
+	if to.IsZero() || to.After(today) {
+		to = today
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultNetWorthPeriodDays)
+	}
+	if from.After(to) {
+		return nil, apperror.Validation("period.start_after_end")
+	}
+	if networth.PointCount(from, to, granularity) > maxNetWorthPoints {
+		return nil, apperror.Validation("networth.too_many_points")
+	}
+	dates := networth.Dates(from, to, granularity)
+	u, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := s.snapshots.History(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	var currencies []string
+	for _, snapshot := range snapshots {
+		if !slices.Contains(currencies, snapshot.Currency) {
+			currencies = append(currencies, snapshot.Currency)
+		}
+	}
+	history, err := s.rates.history(ctx, currencies, u.HomeCurrency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &networth.Series{Currency: u.HomeCurrency, From: from, To: to, Granularity: granularity, Points: make([]networth.Point, 0, len(dates))}
+	places := money.MinorUnits(u.HomeCurrency)
+	rates := make(map[string]*money.Decimal) // Курсы валют к домашней на дату точки; nil — курса нет
+	balances := make(map[int]networth.Snapshot)
+	next := 0
+	for _, date := range dates {
+		for ; next < len(snapshots) && !snapshots[next].Date.After(date); next++ {
+			balances[snapshots[next].AccountID] = snapshots[next]
+		}
+		zero := money.Zero.Round(places)
+		point := networth.Point{Date: date, Total: zero, Assets: zero, Liabilities: zero, MissingRates: []string{}}
+		for _, b := range balances {
+			r := rateOn(history, rates, b.Currency, u.HomeCurrency, date)
+			if r == nil {
+				if !slices.Contains(point.MissingRates, b.Currency) {
+					point.MissingRates = append(point.MissingRates, b.Currency)
+				}
+				continue
+			}
+			converted := b.Balance.Mul(*r).Round(places)
+			point.Total = point.Total.Add(converted)
+			if b.Liability {
+				point.Liabilities = point.Liabilities.Sub(converted)
+			} else {
+				point.Assets = point.Assets.Add(converted)
+			}
+		}
+		slices.Sort(point.MissingRates)
+		series.Points = append(series.Points, point)
+	}
+	return series, nil
+}
+
+// Capture сохраняет снимки балансов всех аккаунтов на текущую дату. Вызывается планировщиком.
+func (s *NetWorthService) Capture(ctx context.Context, now time.Time) error {
+	_, err := s.snapshots.Capture(ctx, now.UTC().Truncate(24*time.Hour))
+	return err
+}
+
+// rateOn возвращает курс валюты from к валюте to на дату date из history, запоминая его в cache.
+// Возвращает nil, если курса нет или код валюты не из справочника.
+func rateOn(history *rateHistory, cache map[string]*money.Decimal, from, to string, date time.Time) *money.Decimal {
+	key := from + date.Format(time.DateOnly)
+	if r, ok := cache[key]; ok {
+		return r
+	}
+	var r *money.Decimal
+	if value, ok := history.rate(from, to, date); ok {
+		r = &value
+	}
+	cache[key] = r
+	return r
+}
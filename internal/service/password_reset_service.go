@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/i18n"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/mailer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordResetTTL      = time.Hour       // Время жизни ссылки для сброса пароля
+	passwordResetThrottle = 2 * time.Minute // Минимальный интервал между письмами со ссылкой одному пользователю
+)
+
+// ErrInvalidResetToken возвращается для неизвестного, уже использованного или истёкшего токена сброса пароля.
+var ErrInvalidResetToken = apperror.New(apperror.KindValidation, "invalid_reset_token")
+
+// PasswordResetService реализует восстановление доступа к аккаунту: отправку ссылки для сброса пароля
+// и установку нового пароля по одноразовому токену из ссылки.
+type PasswordResetService struct {
+	users    *repository.UserRepository          // Репозиторий пользователей
+	resets   *repository.PasswordResetRepository // Репозиторий токенов сброса пароля
+	mail     *mailDelivery                       // Фоновая отправка писем
+	resetURL string                              // Адрес страницы сброса пароля; токен добавляется параметром token
+}
+
+// NewPasswordResetService создает новый экземпляр PasswordResetService.
+func NewPasswordResetService(users *repository.UserRepository, resets *repository.PasswordResetRepository, mailer mailer.Mailer, resetURL string) *PasswordResetService {
+	return &PasswordResetService{users: users, resets: resets, mail: newMailDelivery(mailer), resetURL: resetURL}
+}
+
+// Forgot отправляет на email ссылку для сброса пароля на языке профиля пользователя, а если он не выбран — на языке lang.
+// Для неизвестного email ничего не отправляется, но ошибка не возвращается, чтобы по ответу нельзя было
+// узнать, зарегистрирован ли адрес. По той же причине письмо отправляется в фоне, не задерживая ответ,
+// а ошибка его доставки только пишется в лог.
+// Повторные запросы чаще passwordResetThrottle тоже молча пропускаются.
+func (s *PasswordResetService) Forgot(ctx context.Context, email, lang string) error {
+	u, err := s.users.FindByEmail(ctx, email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	recent, err := s.resets.CountSince(ctx, u.ID, now.Add(-passwordResetThrottle))
+	if err != nil {
+		return err
+	}
+	if recent > 0 {
+		return nil
+	}
+	resetToken, err := generateRandomString(32)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if err := s.resets.Create(ctx, u.ID, hashToken(resetToken), now.Add(passwordResetTTL), now); err != nil {
+		return err
+	}
+	link, err := linkWithToken(s.resetURL, resetToken)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	if i18n.Supported(u.Language) {
+		lang = u.Language
+	}
+	s.mail.send(mailer.Message{
+		To:      u.Email,
+		Subject: i18n.Translate(lang, "mail.password_reset.subject"),
+		Body:    i18n.Translate(lang, "mail.password_reset.body", link, int(passwordResetTTL.Minutes())),
+	}, fmt.Sprintf("письмо для сброса пароля пользователю %d", u.ID))
+	return nil
+}
+
+// Reset устанавливает новый пароль по токену из ссылки. Токен одноразовый; после смены пароля
+// все сессии пользователя завершаются. Слабый пароль отклоняется до погашения токена.
+func (s *PasswordResetService) Reset(ctx context.Context, resetToken, password string) error {
+	if !isPasswordStrong(password) {
+		return ErrWeakPassword
+	}
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return apperror.Internal(err)
+	}
+	_, err = s.resets.ResetPassword(ctx, hashToken(resetToken), string(passwordHash), time.Now())
+	if errors.Is(err, repository.ErrPasswordResetTokenInvalid) {
+		return ErrInvalidResetToken
+	}
+	return err
+}
+
+// PurgeExpired удаляет истёкшие токены сброса пароля. Вызывается планировщиком.
+func (s *PasswordResetService) PurgeExpired(ctx context.Context, now time.Time) error {
+	_, err := s.resets.DeleteExpired(ctx, now)
+	return err
+}
+
+// hashToken возвращает SHA-256 хеш одноразового токена в hex; в БД хранятся только хеши.
+func hashToken(t string) string {
+	sum := sha256.Sum256([]byte(t))
+	return hex.EncodeToString(sum[:])
+}
+
+// linkWithToken добавляет токен к адресу страницы параметром token.
+func linkWithToken(pageURL, t string) (string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("token", t)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
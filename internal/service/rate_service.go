@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/currency"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rate"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/rates"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+const (
+	rateLookbackDays      = 14  // Насколько старый курс можно использовать, если на дату курса нет (выходные, праздники)
+	defaultRatePeriodDays = 30  // Период истории курсов по умолчанию
+	maxRatePeriodDays     = 366 // Максимальный период истории курсов
+)
+
+// ErrRateNotFound возвращается, если для пересчёта нет ни прямого, ни обратного, ни кросс-курса.
+var ErrRateNotFound = apperror.NotFound("rate_not_found")
+
+// RateService хранит исторические курсы валют и пересчитывает суммы между валютами.
+// Курс пары ищется прямой (From/To), затем обратный (To/From), затем кросс-курс через базовую валюту.
+type RateService struct {
+	repo     *repository.RateRepository // Репозиторий курсов
+	base     string                     // Базовая валюта для кросс-курсов
+	provider rates.Provider             // Источник курсов (nil — курсы не загружаются)
+}
+
+// NewRateService создает новый экземпляр RateService.
+func NewRateService(repo *repository.RateRepository, base string, provider rates.Provider) *RateService {
+	return &RateService{repo: repo, base: currency.Normalize(base), provider: provider}
+}
+
+// List возвращает историю курса пары за период (по умолчанию — последние 30 дней).
+func (s *RateService) List(ctx context.Context, base, quote string, from, to time.Time) ([]rate.Rate, error) {
+	base, quote = currency.Normalize(base), currency.Normalize(quote)
+	if !currency.Valid(base) || !currency.Valid(quote) {
+		return nil, ErrInvalidCurrency
+	}
+	if to.IsZero() {
+		to = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultRatePeriodDays)
+	}
+	if from.After(to) {
+		return nil, apperror.Validation("period.start_after_end")
+	}
+	if to.Sub(from) > maxRatePeriodDays*24*time.Hour {
+		return nil, apperror.Validation("rate.period_too_long")
+	}
+	return s.repo.List(ctx, base, quote, from, to)
+}
+
+// Convert пересчитывает сумму из валюты from в валюту to по курсу на дату date.
+// Результат округляется до точности целевой валюты.
+func (s *RateService) Convert(ctx context.Context, amount money.Decimal, from, to string, date time.Time) (*rate.Conversion, error) {
+	from, to = currency.Normalize(from), currency.Normalize(to)
+	if !currency.Valid(from) || !currency.Valid(to) {
+		return nil, ErrInvalidCurrency
+	}
+	c := &rate.Conversion{From: from, To: to, Date: date, Amount: amount, Rate: money.NewFromInt(1), RateDate: date, Path: []string{from, to}}
+	if from != to {
+		if err := s.quote(ctx, c); err != nil {
+			return nil, err
+		}
+	}
+	c.Result = amount.Mul(c.Rate).Round(money.MinorUnits(to))
+	return c, nil
+}
+
+// Sync загружает курсы из провайдера начиная с даты последнего загруженного им курса.
+// Вызывается планировщиком; первый запуск загружает всю доступную историю.
+func (s *RateService) Sync(ctx context.Context, now time.Time) error {
+	if s.provider == nil {
+		return nil
+	}
+	var from time.Time
+	latest, err := s.repo.LatestDate(ctx, s.provider.Name())
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		from = *latest
+	}
+	fetched, err := s.provider.Fetch(ctx, from, now.UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return err
+	}
+	if len(fetched) == 0 {
+		return nil
+	}
+	return s.repo.Save(ctx, fetched)
+}
+
+// quote находит курс c.From/c.To на дату c.Date и заполняет Rate, RateDate и Path.
+func (s *RateService) quote(ctx context.Context, c *rate.Conversion) error {
+	value, rateDate, err := s.pairRate(ctx, c.From, c.To, c.Date)
+	if err == nil {
+		c.Rate, c.RateDate = value, rateDate
+		return nil
+	}
+	if !errors.Is(err, ErrRateNotFound) || c.From == s.base || c.To == s.base {
+		return err
+	}
+	toBase, toBaseDate, err := s.pairRate(ctx, c.From, s.base, c.Date)
+	if err != nil {
+		return err
+	}
+	fromBase, fromBaseDate, err := s.pairRate(ctx, s.base, c.To, c.Date)
+	if err != nil {
+		return err
+	}
+	c.Rate = toBase.Mul(fromBase).Round(rate.Scale)
+	c.RateDate = toBaseDate
+	if fromBaseDate.Before(toBaseDate) {
+		c.RateDate = fromBaseDate
+	}
+	c.Path = []string{c.From, s.base, c.To}
+	return nil
+}
+
+// pairRate возвращает прямой или обратный курс пары на дату date и дату найденного курса.
+func (s *RateService) pairRate(ctx context.Context, from, to string, date time.Time) (money.Decimal, time.Time, error) {
+	notBefore := date.AddDate(0, 0, -rateLookbackDays)
+	direct, err := s.repo.Find(ctx, from, to, date, notBefore)
+	if err == nil {
+		return direct.Rate, direct.Date, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return money.Zero, time.Time{}, err
+	}
+	inverse, err := s.repo.Find(ctx, to, from, date, notBefore)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return money.Zero, time.Time{}, ErrRateNotFound
+	}
+	if err != nil {
+		return money.Zero, time.Time{}, err
+	}
+	value, err := money.NewFromInt(1).Quo(inverse.Rate, rate.Scale)
+	if err != nil {
+		return money.Zero, time.Time{}, err
+	}
+	return value, inverse.Date, nil
+}
+
+// rateHistory — курсы нескольких пар за период, загруженные одним запросом на пару. Выбор курса
+// повторяет Convert (прямой, обратный, кросс-курс через базовую валюту), но без обращений к БД.
+type rateHistory struct {
+	base  string                    // Базовая валюта для кросс-курсов
+	pairs map[[2]string][]rate.Rate // Курсы пары base/quote по возрастанию даты
+}
+
+// history загружает курсы, нужные для пересчёта валют currencies в валюту to на даты периода [from, until].
+// Коды вне справочника пропускаются: для них, как и в Convert, курса нет.
+func (s *RateService) history(ctx context.Context, currencies []string, to string, from, until time.Time) (*rateHistory, error) {
+	to = currency.Normalize(to)
+	h := &rateHistory{base: s.base, pairs: make(map[[2]string][]rate.Rate)}
+	notBefore := from.AddDate(0, 0, -rateLookbackDays)
+	load := func(base, quote string) error {
+		key := [2]string{base, quote}
+		if _, ok := h.pairs[key]; ok {
+			return nil
+		}
+		list, err := s.repo.List(ctx, base, quote, notBefore, until)
+		if err != nil {
+			return err
+		}
+		h.pairs[key] = list
+		return nil
+	}
+	for _, c := range currencies {
+		c = currency.Normalize(c)
+		if !currency.Valid(c) || !currency.Valid(to) || c == to {
+			continue
+		}
+		legs := [][2]string{{c, to}, {to, c}}
+		if c != s.base && to != s.base {
+			legs = append(legs, [2]string{c, s.base}, [2]string{s.base, c}, [2]string{s.base, to}, [2]string{to, s.base})
+		}
+		for _, leg := range legs {
+			if err := load(leg[0], leg[1]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return h, nil
+}
+
+// rate возвращает курс from/to на дату date; false — курса нет.
+func (h *rateHistory) rate(from, to string, date time.Time) (money.Decimal, bool) {
+	from, to = currency.Normalize(from), currency.Normalize(to)
+	if !currency.Valid(from) || !currency.Valid(to) {
+		return money.Zero, false
+	}
+	if from == to {
+		return money.NewFromInt(1), true
+	}
+	if value, ok := h.pairRate(from, to, date); ok {
+		return value, true
+	}
+	if from == h.base || to == h.base {
+		return money.Zero, false
+	}
+	toBase, ok := h.pairRate(from, h.base, date)
+	if !ok {
+		return money.Zero, false
+	}
+	fromBase, ok := h.pairRate(h.base, to, date)
+	if !ok {
+		return money.Zero, false
+	}
+	return toBase.Mul(fromBase).Round(rate.Scale), true
+}
+
+// pairRate возвращает прямой или обратный курс пары на дату date, как RateService.pairRate.
+func (h *rateHistory) pairRate(from, to string, date time.Time) (money.Decimal, bool) {
+	if direct, ok := h.find(from, to, date); ok {
+		return direct.Rate, true
+	}
+	inverse, ok := h.find(to, from, date)
+	if !ok {
+		return money.Zero, false
+	}
+	value, err := money.NewFromInt(1).Quo(inverse.Rate, rate.Scale)
+	if err != nil {
+		return money.Zero, false
+	}
+	return value, true
+}
+
+// find возвращает последний курс пары не позже date и не старше rateLookbackDays дней.
+func (h *rateHistory) find(base, quote string, date time.Time) (rate.Rate, bool) {
+	list := h.pairs[[2]string{base, quote}]
+	i := sort.Search(len(list), func(i int) bool { return list[i].Date.After(date) }) - 1
+	if i < 0 || list[i].Date.Before(date.AddDate(0, 0, -rateLookbackDays)) {
+		return rate.Rate{}, false
+	}
+	return list[i], true
+}
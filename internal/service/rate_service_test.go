@@ -0,0 +1,51 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rate"
+)
+
+func day(d int) time.Time {
+	return time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestRateHistory(t *testing.T) {
+	h := &rateHistory{base: "USD", pairs: map[[2]string][]rate.Rate{
+		{"USD", "RUB"}: {
+			{Base: "USD", Quote: "RUB", Date: day(1), Rate: money.MustParse("90")},
+			{Base: "USD", Quote: "RUB", Date: day(4), Rate: money.MustParse("91")},
+		},
+		{"EUR", "USD"}: {{Base: "EUR", Quote: "USD", Date: day(2), Rate: money.MustParse("1.1")}},
+	}}
+	tests := []struct {
+		name     string
+		from, to string
+		date     time.Time
+		want     string
+	}{
+		{name: "same currency", from: "RUB", to: "rub", date: day(1), want: "1"},
+		{name: "direct", from: "USD", to: "RUB", date: day(3), want: "90"},
+		{name: "direct on date", from: "USD", to: "RUB", date: day(4), want: "91"},
+		{name: "inverse", from: "RUB", to: "USD", date: day(1), want: "0.011111111111"},
+		{name: "cross", from: "EUR", to: "RUB", date: day(5), want: "100.100000000000"},
+		{name: "before first rate", from: "USD", to: "RUB", date: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{name: "older than lookback", from: "USD", to: "RUB", date: day(4).AddDate(0, 0, rateLookbackDays+1)},
+		{name: "no pair", from: "GBP", to: "RUB", date: day(5)},
+		{name: "unknown code", from: "XXQ", to: "RUB", date: day(5)},
+	}
+	for _, tt := range tests {
+		got, ok := h.rate(tt.from, tt.to, tt.date)
+		if tt.want == "" {
+			if ok {
+				t.Errorf("%s: rate = %s, want none", tt.name, got)
+			}
+			continue
+		}
+		if !ok || got.String() != tt.want {
+			t.Errorf("%s: rate = %s, %v; want %s", tt.name, got, ok, tt.want)
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/recurrence"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+const (
+	maxRecurrenceInterval = 366  // Максимальный шаг повторения
+	maxUpcoming           = 100  // Максимальное количество предстоящих платежей в ответе
+	dueRecurrenceBatch    = 1000 // Количество регулярных операций, обрабатываемых за один запуск задачи
+	maxCatchUpPerRun      = 366  // Максимальное количество платежей одной регулярной операции за один запуск задачи
+)
+
+var (
+	// ErrRecurrenceNotFound возвращается, если регулярная операция не найдена среди операций пользователя.
+	ErrRecurrenceNotFound = apperror.NotFound("recurrence_not_found")
+	// ErrOccurrenceNotFound возвращается, если платёж по расписанию не найден.
+	ErrOccurrenceNotFound = apperror.NotFound("occurrence_not_found")
+	// ErrOccurrenceProcessed возвращается при попытке подтвердить или пропустить уже обработанный платёж.
+	ErrOccurrenceProcessed = apperror.Conflict("occurrence_processed")
+)
+
+// OccurrenceConfirmation описывает изменения, с которыми подтверждается предложенный платёж.
+type OccurrenceConfirmation struct {
+	Amount *money.Decimal // Фактическая положительная сумма (nil — сумма из расписания)
+	Date   *time.Time     // Фактическая дата (nil — дата платежа по расписанию)
+}
+
+// RecurrenceService реализует бизнес-логику регулярных операций и создание платежей по расписанию.
+type RecurrenceService struct {
+	repo         *repository.RecurrenceRepository  // Репозиторий регулярных операций
+	accountRepo  *repository.BankAccountRepository // Репозиторий банковских аккаунтов
+	categoryRepo *repository.CategoryRepository    // Репозиторий категорий
+}
+
+// NewRecurrenceService создает новый экземпляр RecurrenceService.
+func NewRecurrenceService(repo *repository.RecurrenceRepository, accountRepo *repository.BankAccountRepository, categoryRepo *repository.CategoryRepository) *RecurrenceService {
+	return &RecurrenceService{repo: repo, accountRepo: accountRepo, categoryRepo: categoryRepo}
+}
+
+// List возвращает регулярные операции пользователя.
+func (s *RecurrenceService) List(ctx context.Context, userID int) ([]recurrence.Recurrence, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// Get возвращает регулярную операцию пользователя по id.
+func (s *RecurrenceService) Get(ctx context.Context, id, userID int) (*recurrence.Recurrence, error) {
+	rec, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRecurrenceNotFound
+	}
+	return rec, err
+}
+
+// Create создает регулярную операцию. Если дата начала в прошлом, пропущенные платежи будут созданы
+// при ближайшем запуске планировщика.
+func (s *RecurrenceService) Create(ctx context.Context, rec recurrence.Recurrence) (*recurrence.Recurrence, error) {
+	if err := s.prepare(ctx, &rec); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, &rec)
+}
+
+// Update изменяет регулярную операцию. Уже созданные платежи не меняются.
+func (s *RecurrenceService) Update(ctx context.Context, rec recurrence.Recurrence) (*recurrence.Recurrence, error) {
+	if err := s.prepare(ctx, &rec); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, &rec, time.Now().UTC().Truncate(24*time.Hour))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRecurrenceNotFound
+	}
+	return updated, err
+}
+
+// Delete удаляет регулярную операцию; созданные по ней операции остаются.
+func (s *RecurrenceService) Delete(ctx context.Context, id, userID int) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrRecurrenceNotFound
+	}
+	return err
+}
+
+// Upcoming возвращает до n предстоящих платежей регулярной операции.
+func (s *RecurrenceService) Upcoming(ctx context.Context, id, userID, n int) ([]recurrence.Planned, error) {
+	rec, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return rec.Upcoming(min(n, maxUpcoming)), nil
+}
+
+// ListOccurrences возвращает платежи пользователя; recurrenceID = 0 — по всем регулярным операциям.
+func (s *RecurrenceService) ListOccurrences(ctx context.Context, userID, recurrenceID int, status string) ([]recurrence.Occurrence, error) {
+	return s.repo.ListOccurrences(ctx, userID, recurrenceID, status)
+}
+
+// Confirm создает операцию по предложенному платежу; сумму и дату можно уточнить.
+func (s *RecurrenceService) Confirm(ctx context.Context, id, userID int, c OccurrenceConfirmation) (*transaction.Transaction, error) {
+	o, err := s.repo.GetOccurrence(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOccurrenceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.Status != recurrence.StatusPending {
+		return nil, ErrOccurrenceProcessed
+	}
+	rec, err := s.Get(ctx, o.RecurrenceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if c.Amount != nil {
+		if !c.Amount.IsPositive() {
+			return nil, apperror.Validation("transaction.amount_not_positive")
+		}
+		if !money.FitsCurrency(*c.Amount, rec.Currency) {
+			return nil, apperror.Validation("money.too_many_decimals")
+		}
+		rec.Amount = *c.Amount
+	}
+	date := o.DueDate
+	if c.Date != nil {
+		date = *c.Date
+	}
+	t := rec.Transaction(date)
+	created, err := s.repo.ConfirmOccurrence(ctx, id, userID, &t)
+	if errors.Is(err, repository.ErrOccurrenceProcessed) {
+		return nil, ErrOccurrenceProcessed
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	return created, err
+}
+
+// Skip помечает предложенный платёж пропущенным.
+func (s *RecurrenceService) Skip(ctx context.Context, id, userID int) (*recurrence.Occurrence, error) {
+	if _, err := s.repo.GetOccurrence(ctx, id, userID); errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrOccurrenceNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	o, err := s.repo.SkipOccurrence(ctx, id, userID)
+	if errors.Is(err, repository.ErrOccurrenceProcessed) {
+		return nil, ErrOccurrenceProcessed
+	}
+	return o, err
+}
+
+// PostDue создает наступившие платежи всех пользователей. Вызывается планировщиком; платежи,
+// пропущенные за время простоя, создаются при первом запуске после него.
+func (s *RecurrenceService) PostDue(ctx context.Context, now time.Time) error {
+	date := now.UTC().Truncate(24 * time.Hour)
+	ids, err := s.repo.ListDue(ctx, date, dueRecurrenceBatch)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, id := range ids {
+		if _, err := s.repo.PostDue(ctx, id, date, maxCatchUpPerRun); err != nil {
+			errs = append(errs, fmt.Errorf("recurrence %d: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// prepare проверяет регулярную операцию: сумму, аккаунт, категорию и параметры расписания.
+func (s *RecurrenceService) prepare(ctx context.Context, rec *recurrence.Recurrence) error {
+	if !isEditableType(rec.Type) {
+		return apperror.Validation("transaction.invalid_type")
+	}
+	if !rec.Amount.IsPositive() {
+		return apperror.Validation("transaction.amount_not_positive")
+	}
+	switch rec.Frequency {
+	case recurrence.FrequencyDaily, recurrence.FrequencyWeekly, recurrence.FrequencyMonthly, recurrence.FrequencyYearly:
+	default:
+		return apperror.Validation("recurrence.invalid_frequency")
+	}
+	if rec.Interval == 0 {
+		rec.Interval = 1
+	}
+	if rec.Interval < 1 || rec.Interval > maxRecurrenceInterval {
+		return apperror.Validation("recurrence.interval_range")
+	}
+	if rec.MonthDay < recurrence.LastDay || rec.MonthDay > 31 {
+		return apperror.Validation("recurrence.day_of_month_range")
+	}
+	if rec.BusinessDay == "" {
+		rec.BusinessDay = recurrence.BusinessDayNone
+	}
+	switch rec.BusinessDay {
+	case recurrence.BusinessDayNone, recurrence.BusinessDayPrevious, recurrence.BusinessDayNext:
+	default:
+		return apperror.Validation("recurrence.invalid_weekend_shift")
+	}
+	if rec.Mode != recurrence.ModeAuto && rec.Mode != recurrence.ModeSuggest {
+		return apperror.Validation("recurrence.invalid_mode")
+	}
+	if rec.EndDate != nil && rec.EndDate.Before(rec.StartDate) {
+		return apperror.Validation("recurrence.end_before_start")
+	}
+	rec.Payee = strings.TrimSpace(rec.Payee)
+	if utf8.RuneCountInString(rec.Payee) > 255 {
+		return apperror.Validation("recurrence.payee_too_long")
+	}
+	tags, err := normalizeTags(rec.Tags)
+	if err != nil {
+		return err
+	}
+	rec.Tags = tags
+	acc, err := s.accountRepo.GetByID(ctx, rec.AccountID, rec.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAccountNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !money.FitsCurrency(rec.Amount, acc.Currency) {
+		return apperror.Validation("money.too_many_decimals")
+	}
+	if rec.CategoryID != nil {
+		c, err := s.categoryRepo.GetByID(ctx, *rec.CategoryID, rec.UserID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if c.Kind != categoryKindFor(rec.Type) {
+			return apperror.Validation("transaction.category_kind_mismatch")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/rule"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/rules"
+)
+
+const (
+	maxTags         = transaction.MaxTags // Максимальное количество тегов у операции или правила
+	maxTagLength    = 50                  // Максимальная длина тега
+	maxRuleApplyRun = 20000               // Максимальное количество операций, к которым правила применяются за один запуск
+)
+
+// ErrRuleNotFound возвращается, если правило не найдено среди правил пользователя.
+var ErrRuleNotFound = apperror.NotFound("rule_not_found")
+
+// RuleApplyOptions описывает параметры повторного применения правил к истории операций.
+type RuleApplyOptions struct {
+	AccountID int       // Только операции этого аккаунта (0 — все аккаунты)
+	From      time.Time // Начало периода (нулевое значение — с начала истории)
+	To        time.Time // Конец периода (нулевое значение — по сегодня)
+	Overwrite bool      // Заменять уже установленные категории
+	DryRun    bool      // Только рассчитать изменения, не сохраняя их
+}
+
+// RuleService реализует управление правилами категоризации и их повторное применение к истории.
+type RuleService struct {
+	repo            *repository.RuleRepository        // Репозиторий правил
+	categoryRepo    *repository.CategoryRepository    // Репозиторий категорий
+	accountRepo     *repository.BankAccountRepository // Репозиторий банковских аккаунтов
+	transactionRepo *repository.TransactionRepository // Репозиторий операций
+}
+
+// NewRuleService создает новый экземпляр RuleService.
+func NewRuleService(repo *repository.RuleRepository, categoryRepo *repository.CategoryRepository, accountRepo *repository.BankAccountRepository, transactionRepo *repository.TransactionRepository) *RuleService {
+	return &RuleService{repo: repo, categoryRepo: categoryRepo, accountRepo: accountRepo, transactionRepo: transactionRepo}
+}
+
+// List возвращает правила пользователя в порядке применения.
+func (s *RuleService) List(ctx context.Context, userID int) ([]rule.Rule, error) {
+	return s.repo.List(ctx, userID)
+}
+
+// Get возвращает правило пользователя по id.
+func (s *RuleService) Get(ctx context.Context, id, userID int) (*rule.Rule, error) {
+	rl, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRuleNotFound
+	}
+	return rl, err
+}
+
+// Create создает правило в конце списка правил пользователя.
+func (s *RuleService) Create(ctx context.Context, rl rule.Rule) (*rule.Rule, error) {
+	if err := s.prepare(ctx, &rl); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(ctx, &rl)
+}
+
+// Update изменяет условия и действия правила.
+func (s *RuleService) Update(ctx context.Context, rl rule.Rule) (*rule.Rule, error) {
+	if err := s.prepare(ctx, &rl); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, &rl)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRuleNotFound
+	}
+	return updated, err
+}
+
+// Delete удаляет правило пользователя.
+func (s *RuleService) Delete(ctx context.Context, id, userID int) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrRuleNotFound
+	}
+	return err
+}
+
+// Reorder задаёт порядок применения правил списком всех их id.
+func (s *RuleService) Reorder(ctx context.Context, userID int, ids []int) error {
+	err := s.repo.Reorder(ctx, userID, ids)
+	if errors.Is(err, repository.ErrRuleOrderMismatch) {
+		return apperror.Validation("rule.order_mismatch")
+	}
+	return err
+}
+
+// Apply повторно применяет правила к доходам и расходам за период и возвращает изменения.
+// В режиме DryRun изменения только рассчитываются; иначе сохраняются в одной транзакции БД.
+func (s *RuleService) Apply(ctx context.Context, userID int, opts RuleApplyOptions) (*rule.ApplyResult, error) {
+	if opts.To.IsZero() {
+		opts.To = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if opts.From.After(opts.To) {
+		return nil, apperror.Validation("period.start_after_end")
+	}
+	engine, err := loadRuleEngine(ctx, s.repo, s.categoryRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	transactions, err := s.transactionRepo.ListInRange(ctx, userID, opts.AccountID, opts.From, opts.To, maxRuleApplyRun+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(transactions) > maxRuleApplyRun {
+		return nil, apperror.Validation("rule.too_many_transactions")
+	}
+
+	result := &rule.ApplyResult{DryRun: opts.DryRun, Checked: len(transactions), Changes: []rule.Change{}}
+	changed := []transaction.Transaction{}
+	for _, t := range transactions {
+		before := ruleSnapshot(t)
+		ruleIDs := engine.Apply(&t, opts.Overwrite)
+		after := ruleSnapshot(t)
+		if len(ruleIDs) == 0 || snapshotsEqual(before, after) {
+			continue
+		}
+		result.Changes = append(result.Changes, rule.Change{TransactionID: t.ID, Before: before, After: after, RuleIDs: ruleIDs})
+		changed = append(changed, t)
+	}
+	if opts.DryRun || len(changed) == 0 {
+		return result, nil
+	}
+	if err := s.transactionRepo.UpdateCategorization(ctx, userID, changed); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// prepare проверяет правило: название, регулярные выражения, диапазон сумм, принадлежность аккаунта и категории.
+func (s *RuleService) prepare(ctx context.Context, rl *rule.Rule) error {
+	rl.Name = strings.TrimSpace(rl.Name)
+	if rl.Name == "" || utf8.RuneCountInString(rl.Name) > 100 {
+		return apperror.Validation("rule.name_invalid")
+	}
+	if _, err := rules.Compile(rl.PayeePattern); err != nil {
+		return apperror.Validation("rule.invalid_payee_pattern")
+	}
+	if _, err := rules.Compile(rl.NotePattern); err != nil {
+		return apperror.Validation("rule.invalid_note_pattern")
+	}
+	if (rl.MinAmount != nil && rl.MinAmount.IsNegative()) || (rl.MaxAmount != nil && rl.MaxAmount.IsNegative()) {
+		return apperror.Validation("rule.negative_amount_bounds")
+	}
+	if rl.MinAmount != nil && rl.MaxAmount != nil && rl.MinAmount.Cmp(*rl.MaxAmount) > 0 {
+		return apperror.Validation("rule.min_above_max")
+	}
+	if rl.Type != "" && !isEditableType(rl.Type) {
+		return apperror.Validation("transaction.invalid_type")
+	}
+	if rl.PayeePattern == "" && rl.NotePattern == "" && rl.MinAmount == nil && rl.MaxAmount == nil && rl.AccountID == nil && rl.Type == "" {
+		return apperror.Validation("rule.conditions_required")
+	}
+	tags, err := normalizeTags(rl.Tags)
+	if err != nil {
+		return err
+	}
+	rl.Tags = tags
+	rl.RenamePayee = strings.TrimSpace(rl.RenamePayee)
+	if utf8.RuneCountInString(rl.RenamePayee) > 255 {
+		return apperror.Validation("rule.rename_payee_too_long")
+	}
+	if rl.CategoryID == nil && len(rl.Tags) == 0 && rl.RenamePayee == "" {
+		return apperror.Validation("rule.actions_required")
+	}
+	if rl.AccountID != nil {
+		if _, err := s.accountRepo.GetByID(ctx, *rl.AccountID, rl.UserID); errors.Is(err, pgx.ErrNoRows) {
+			return ErrAccountNotFound
+		} else if err != nil {
+			return err
+		}
+	}
+	if rl.CategoryID != nil {
+		c, err := s.categoryRepo.GetByID(ctx, *rl.CategoryID, rl.UserID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if rl.Type != "" && c.Kind != categoryKindFor(rl.Type) {
+			return apperror.Validation("transaction.category_kind_mismatch")
+		}
+	}
+	return nil
+}
+
+// loadRuleEngine загружает и компилирует правила пользователя.
+func loadRuleEngine(ctx context.Context, ruleRepo *repository.RuleRepository, categoryRepo *repository.CategoryRepository, userID int) (*rules.Engine, error) {
+	rs, err := ruleRepo.List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := categoryRepo.List(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	return rules.New(rs, categories)
+}
+
+// normalizeTags обрезает пробелы, убирает пустые и повторяющиеся теги и проверяет ограничения.
+func normalizeTags(tags []string) ([]string, error) {
+	result := []string{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || slices.Contains(result, tag) {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return nil, apperror.Validation("tag.too_long")
+		}
+		result = append(result, tag)
+	}
+	if len(result) > maxTags {
+		return nil, apperror.Validation("tag.too_many")
+	}
+	return result, nil
+}
+
+// ruleSnapshot возвращает поля операции, которые изменяют правила.
+func ruleSnapshot(t transaction.Transaction) rule.Snapshot {
+	return rule.Snapshot{Payee: t.Payee, CategoryID: t.CategoryID, Tags: slices.Clone(t.Tags)}
+}
+
+// snapshotsEqual сравнивает значения полей операции до и после применения правил.
+func snapshotsEqual(a, b rule.Snapshot) bool {
+	sameCategory := (a.CategoryID == nil) == (b.CategoryID == nil) && (a.CategoryID == nil || *a.CategoryID == *b.CategoryID)
+	return a.Payee == b.Payee && sameCategory && slices.Equal(a.Tags, b.Tags)
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/dedup"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/category"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transaction"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+const (
+	defaultTransactionPageSize = 50    // Размер страницы списка операций по умолчанию
+	maxTransactionPageSize     = 100   // Максимальный размер страницы списка операций
+	defaultDuplicatePeriodDays = 90    // Период поиска дубликатов по умолчанию
+	maxDuplicatePeriodDays     = 366   // Максимальный период поиска дубликатов
+	maxDuplicateScan           = 20000 // Максимальное количество операций, среди которых ищутся дубликаты
+)
+
+// ErrTransactionNotFound возвращается, если операция не найдена среди операций пользователя.
+var ErrTransactionNotFound = apperror.NotFound("transaction_not_found")
+
+// TransactionService реализует бизнес-логику операций по банковским аккаунтам.
+// Баланс аккаунта изменяется атомарно вместе с каждой операцией.
+type TransactionService struct {
+	repo         *repository.TransactionRepository // Репозиторий операций
+	accountRepo  *repository.BankAccountRepository // Репозиторий банковских аккаунтов
+	categoryRepo *repository.CategoryRepository    // Репозиторий категорий
+	ruleRepo     *repository.RuleRepository        // Репозиторий правил категоризации
+}
+
+// NewTransactionService создает новый экземпляр TransactionService.
+func NewTransactionService(repo *repository.TransactionRepository, accountRepo *repository.BankAccountRepository, categoryRepo *repository.CategoryRepository, ruleRepo *repository.RuleRepository) *TransactionService {
+	return &TransactionService{repo: repo, accountRepo: accountRepo, categoryRepo: categoryRepo, ruleRepo: ruleRepo}
+}
+
+// Create создает операцию пользователя. Сумма передаётся положительной, знак определяется типом операции.
+func (s *TransactionService) Create(ctx context.Context, userID int, t transaction.Transaction) (*transaction.Transaction, error) {
+	t.UserID = userID
+	// Правила дополняют операцию до проверки: категория, выбранная пользователем, не заменяется.
+	engine, err := loadRuleEngine(ctx, s.ruleRepo, s.categoryRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	engine.Apply(&t, false)
+	if err := s.prepare(ctx, &t); err != nil {
+		return nil, err
+	}
+	created, err := s.repo.Create(ctx, &t)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrAccountNotFound
+	}
+	return created, err
+}
+
+// Get возвращает операцию пользователя по id.
+func (s *TransactionService) Get(ctx context.Context, id, userID int) (*transaction.Transaction, error) {
+	t, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTransactionNotFound
+	}
+	return t, err
+}
+
+// List возвращает страницу операций пользователя и курсор следующей страницы.
+func (s *TransactionService) List(ctx context.Context, userID int, filter transaction.ListFilter) ([]transaction.Transaction, string, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransactionPageSize
+	}
+	if filter.Limit > maxTransactionPageSize {
+		filter.Limit = maxTransactionPageSize
+	}
+	transactions, nextCursor, err := s.repo.List(ctx, userID, filter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, "", ErrInvalidCursor
+	}
+	return transactions, nextCursor, err
+}
+
+// Update изменяет операцию пользователя. Корректировки баланса изменять нельзя.
+func (s *TransactionService) Update(ctx context.Context, id, userID int, t transaction.Transaction) (*transaction.Transaction, error) {
+	existing, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isEditableType(existing.Type) {
+		return nil, apperror.Validation("transaction.not_editable")
+	}
+	t.ID = id
+	t.UserID = userID
+	if err := s.prepare(ctx, &t); err != nil {
+		return nil, err
+	}
+	updated, err := s.repo.Update(ctx, &t)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTransactionNotFound
+	}
+	return updated, err
+}
+
+// Delete удаляет операцию пользователя и возвращает её сумму с баланса аккаунта.
+// Операции, входящие в перевод, удаляются только вместе с переводом.
+func (s *TransactionService) Delete(ctx context.Context, id, userID int) error {
+	existing, err := s.Get(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+	if existing.TransferID != nil {
+		return apperror.Conflict("transaction_in_transfer")
+	}
+	err = s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTransactionNotFound
+	}
+	return err
+}
+
+// FindDuplicates ищет среди доходов и расходов пользователя за период пары вероятных дубликатов.
+// Нулевые from и to означают последние defaultDuplicatePeriodDays дней; accountID 0 — все аккаунты.
+func (s *TransactionService) FindDuplicates(ctx context.Context, userID, accountID int, from, to time.Time) ([]transaction.Duplicate, error) {
+	if to.IsZero() {
+		to = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, 0, -defaultDuplicatePeriodDays)
+	}
+	if from.After(to) {
+		return nil, apperror.Validation("period.start_after_end")
+	}
+	if to.Sub(from) > maxDuplicatePeriodDays*24*time.Hour {
+		return nil, apperror.Validation("transaction.duplicates_period_too_long")
+	}
+	transactions, err := s.repo.ListInRange(ctx, userID, accountID, from, to, maxDuplicateScan)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int]transaction.Transaction, len(transactions))
+	entries := make([]dedup.Entry, 0, len(transactions))
+	for _, t := range transactions {
+		byID[t.ID] = t
+		entries = append(entries, dedupEntry(t))
+	}
+	duplicates := []transaction.Duplicate{}
+	for _, m := range dedup.Find(entries, entries, true) {
+		duplicates = append(duplicates, transaction.Duplicate{Transaction: byID[m.Item], Duplicate: byID[m.Candidate], Score: m.Score})
+	}
+	return duplicates, nil
+}
+
+// Merge объединяет операцию sourceID с её дубликатом targetID: sourceID удаляется, её данные дополняют targetID,
+// а копия сохраняется в журнале объединений. Объединять можно только доходы и расходы одного аккаунта с равной суммой.
+func (s *TransactionService) Merge(ctx context.Context, userID, sourceID, targetID int) (*transaction.Transaction, error) {
+	if sourceID == targetID {
+		return nil, apperror.Validation("transaction.merge_with_itself")
+	}
+	merged, err := s.repo.Merge(ctx, userID, sourceID, targetID)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, ErrTransactionNotFound
+	case errors.Is(err, repository.ErrMergeNotAllowed):
+		return nil, apperror.Validation("transaction.merge_not_allowed")
+	case errors.Is(err, repository.ErrMergeMismatch):
+		return nil, apperror.Validation("transaction.merge_mismatch")
+	}
+	return merged, err
+}
+
+// ListMerges возвращает журнал объединений дубликатов пользователя.
+func (s *TransactionService) ListMerges(ctx context.Context, userID int) ([]transaction.Merge, error) {
+	return s.repo.ListMerges(ctx, userID)
+}
+
+// prepare проверяет операцию и приводит сумму к знаковому виду: расход хранится отрицательным.
+func (s *TransactionService) prepare(ctx context.Context, t *transaction.Transaction) error {
+	if !isEditableType(t.Type) {
+		return apperror.Validation("transaction.invalid_type")
+	}
+	if !t.Amount.IsPositive() {
+		return apperror.Validation("transaction.amount_not_positive")
+	}
+	acc, err := s.accountRepo.GetByID(ctx, t.AccountID, t.UserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAccountNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !money.FitsCurrency(t.Amount, acc.Currency) {
+		return apperror.Validation("money.too_many_decimals")
+	}
+	if t.Tags, err = normalizeTags(t.Tags); err != nil {
+		return err
+	}
+	if t.CategoryID != nil {
+		c, err := s.categoryRepo.GetByID(ctx, *t.CategoryID, t.UserID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCategoryNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if c.Kind != categoryKindFor(t.Type) {
+			return apperror.Validation("transaction.category_kind_mismatch")
+		}
+	}
+	if t.Type == transaction.TypeExpense {
+		t.Amount = t.Amount.Neg()
+	}
+	return nil
+}
+
+// categoryKindFor возвращает вид категории, подходящий для типа операции.
+func categoryKindFor(transactionType string) string {
+	if transactionType == transaction.TypeIncome {
+		return category.KindIncome
+	}
+	return category.KindExpense
+}
+
+// isEditableType сообщает, можно ли создавать и изменять операции этого типа вручную.
+func isEditableType(t string) bool {
+	return t == transaction.TypeIncome || t == transaction.TypeExpense
+}
+
+// dedupEntry переводит операцию в элемент для поиска дубликатов.
+func dedupEntry(t transaction.Transaction) dedup.Entry {
+	e := dedup.Entry{ID: t.ID, AccountID: t.AccountID, Date: t.Date, Amount: t.Amount, Payee: t.Payee}
+	if t.ExternalID != nil {
+		e.ExternalID = *t.ExternalID
+	}
+	return e
+}
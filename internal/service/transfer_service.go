@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transfer"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/repository"
+)
+
+const (
+	rateScale               = 10  // Количество знаков после запятой в курсе перевода
+	defaultTransferPageSize = 50  // Размер страницы списка переводов по умолчанию
+	maxTransferPageSize     = 100 // Максимальный размер страницы списка переводов
+)
+
+var (
+	// ErrTransferNotFound возвращается, если перевод не найден среди переводов пользователя.
+	ErrTransferNotFound = apperror.NotFound("transfer_not_found")
+	// ErrForeignAccountTransfer возвращается при попытке перевода с участием чужого аккаунта.
+	ErrForeignAccountTransfer = apperror.Forbidden("foreign_account")
+)
+
+// TransferService реализует бизнес-логику переводов между аккаунтами пользователя.
+type TransferService struct {
+	repo *repository.TransferRepository // Репозиторий переводов
+}
+
+// NewTransferService создает новый экземпляр TransferService.
+func NewTransferService(repo *repository.TransferRepository) *TransferService {
+	return &TransferService{repo: repo}
+}
+
+// Create выполняет перевод между аккаунтами пользователя.
+// Для разных валют нужно указать сумму зачисления (ToAmount) или курс (Rate); недостающее значение вычисляется.
+func (s *TransferService) Create(ctx context.Context, userID int, t transfer.Transfer) (*transfer.Transfer, error) {
+	t.UserID = userID
+	if t.FromAccountID == t.ToAccountID {
+		return nil, apperror.Validation("transfer.same_account")
+	}
+	if !t.FromAmount.IsPositive() {
+		return nil, apperror.Validation("transfer.amount_not_positive")
+	}
+	created, err := s.repo.Create(ctx, &t, resolveTransferAmounts)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return nil, ErrAccountNotFound
+	case errors.Is(err, repository.ErrForeignAccount):
+		return nil, ErrForeignAccountTransfer
+	}
+	return created, err
+}
+
+// Get возвращает перевод пользователя по id.
+func (s *TransferService) Get(ctx context.Context, id, userID int) (*transfer.Transfer, error) {
+	t, err := s.repo.GetByID(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTransferNotFound
+	}
+	return t, err
+}
+
+// List возвращает страницу переводов пользователя и курсор следующей страницы.
+func (s *TransferService) List(ctx context.Context, userID int, filter transfer.ListFilter) ([]transfer.Transfer, string, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultTransferPageSize
+	}
+	if filter.Limit > maxTransferPageSize {
+		filter.Limit = maxTransferPageSize
+	}
+	transfers, nextCursor, err := s.repo.List(ctx, userID, filter)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return nil, "", ErrInvalidCursor
+	}
+	return transfers, nextCursor, err
+}
+
+// Delete отменяет перевод: удаляет обе операции и возвращает суммы на балансы.
+func (s *TransferService) Delete(ctx context.Context, id, userID int) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTransferNotFound
+	}
+	return err
+}
+
+// resolveTransferAmounts проверяет суммы и вычисляет сумму зачисления и курс перевода по валютам аккаунтов.
+// В одной валюте курс равен 1, а сумма зачисления — сумме списания; другие значения отклоняются.
+// Если для разных валют указаны и сумма зачисления, и курс, они должны согласовываться.
+// Вычисленные значения проверяются на размер колонок NUMERIC(22,4) и NUMERIC(30,12).
+func resolveTransferAmounts(t *transfer.Transfer) error {
+	if !money.FitsCurrency(t.FromAmount, t.FromCurrency) {
+		return apperror.Validation("money.too_many_decimals")
+	}
+	if t.FromCurrency == t.ToCurrency {
+		if t.ToAmount.IsSet() && !t.ToAmount.Equal(t.FromAmount) {
+			return apperror.Validation("transfer.amounts_differ")
+		}
+		if t.Rate.IsSet() && !t.Rate.Equal(money.NewFromInt(1)) {
+			return apperror.Validation("transfer.rate_same_currency")
+		}
+		t.ToAmount = t.FromAmount
+		t.Rate = money.NewFromInt(1)
+		return nil
+	}
+	if t.Rate.IsSet() && !t.Rate.IsPositive() {
+		return apperror.Validation("transfer.rate_not_positive")
+	}
+	switch {
+	case t.ToAmount.IsSet():
+		if !t.ToAmount.IsPositive() {
+			return apperror.Validation("transfer.to_amount_not_positive")
+		}
+		if !money.FitsCurrency(t.ToAmount, t.ToCurrency) {
+			return apperror.Validation("money.too_many_decimals")
+		}
+		if t.Rate.IsSet() {
+			if !t.FromAmount.Mul(t.Rate).Round(money.MinorUnits(t.ToCurrency)).Equal(t.ToAmount) {
+				return apperror.Validation("transfer.rate_mismatch")
+			}
+			break
+		}
+		rate, err := t.ToAmount.Quo(t.FromAmount, rateScale)
+		if err != nil {
+			return err
+		}
+		if !rate.IsPositive() {
+			return apperror.Validation("transfer.rate_not_positive")
+		}
+		t.Rate = rate
+	case t.Rate.IsSet():
+		t.ToAmount = t.FromAmount.Mul(t.Rate).Round(money.MinorUnits(t.ToCurrency))
+		if !t.ToAmount.IsPositive() {
+			return apperror.Validation("transfer.to_amount_not_positive")
+		}
+	default:
+		return apperror.Validation("transfer.rate_required")
+	}
+	if t.ToAmount.IntegerDigits() > money.MaxIntegerDigits || t.Rate.IntegerDigits() > money.MaxIntegerDigits {
+		return apperror.Validation("money.too_many_digits", money.MaxIntegerDigits)
+	}
+	return nil
+}
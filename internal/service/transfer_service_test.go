@@ -0,0 +1,59 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/apperror"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/money"
+	"github.com/stepanpotapov/moneyflow-go-backend/internal/models/transfer"
+)
+
+func TestResolveTransferAmounts(t *testing.T) {
+	tests := []struct {
+		name                   string
+		from, to, rate         string
+		fromCurrency, currency string
+		wantTo, wantRate       string
+		wantErr                string
+	}{
+		{name: "same currency", from: "100", fromCurrency: "RUB", currency: "RUB", wantTo: "100", wantRate: "1"},
+		{name: "same currency with rate 1", from: "100", rate: "1.000", fromCurrency: "RUB", currency: "RUB", wantTo: "100", wantRate: "1"},
+		{name: "same currency with other rate", from: "100", rate: "2", fromCurrency: "RUB", currency: "RUB", wantErr: "transfer.rate_same_currency"},
+		{name: "same currency with other amount", from: "100", to: "200", fromCurrency: "RUB", currency: "RUB", wantErr: "transfer.amounts_differ"},
+		{name: "to amount", from: "100", to: "9150", fromCurrency: "USD", currency: "RUB", wantTo: "9150", wantRate: "91.5000000000"},
+		{name: "rate", from: "100", rate: "91.5", fromCurrency: "USD", currency: "RUB", wantTo: "9150.00", wantRate: "91.5"},
+		{name: "consistent amount and rate", from: "100", to: "9150", rate: "91.5", fromCurrency: "USD", currency: "RUB", wantTo: "9150", wantRate: "91.5"},
+		{name: "inconsistent amount and rate", from: "100", to: "9150", rate: "90", fromCurrency: "USD", currency: "RUB", wantErr: "transfer.rate_mismatch"},
+		{name: "no amount and rate", from: "100", fromCurrency: "USD", currency: "RUB", wantErr: "transfer.rate_required"},
+		{name: "negative rate", from: "100", rate: "-1", fromCurrency: "USD", currency: "RUB", wantErr: "transfer.rate_not_positive"},
+		{name: "rate rounds to zero", from: "100000000000000000", to: "0.01", fromCurrency: "USD", currency: "RUB", wantErr: "transfer.rate_not_positive"},
+		{name: "rate too large", from: "0.01", to: "100000000000000000", fromCurrency: "USD", currency: "RUB", wantErr: "money.too_many_digits"},
+		{name: "to amount too large", from: "100000000000000000", rate: "1000", fromCurrency: "USD", currency: "RUB", wantErr: "money.too_many_digits"},
+		{name: "too many decimals", from: "1.001", rate: "2", fromCurrency: "USD", currency: "RUB", wantErr: "money.too_many_decimals"},
+	}
+	for _, tt := range tests {
+		tr := transfer.Transfer{FromAmount: money.MustParse(tt.from), FromCurrency: tt.fromCurrency, ToCurrency: tt.currency}
+		if tt.to != "" {
+			tr.ToAmount = money.MustParse(tt.to)
+		}
+		if tt.rate != "" {
+			tr.Rate = money.MustParse(tt.rate)
+		}
+		err := resolveTransferAmounts(&tr)
+		if tt.wantErr != "" {
+			var appErr *apperror.Error
+			if !errors.As(err, &appErr) || appErr.Key != tt.wantErr {
+				t.Errorf("%s: error = %v, want %s", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error: %v", tt.name, err)
+			continue
+		}
+		if tr.ToAmount.String() != tt.wantTo || tr.Rate.String() != tt.wantRate {
+			t.Errorf("%s: to = %s, rate = %s; want %s, %s", tt.name, tr.ToAmount, tr.Rate, tt.wantTo, tt.wantRate)
+		}
+	}
+}
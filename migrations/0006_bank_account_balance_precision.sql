@@ -0,0 +1,6 @@
+-- +goose Up
+-- Четыре знака после запятой покрывают все валюты ISO 4217 (BHD — 3, CLF — 4) без округления на стороне БД
+ALTER TABLE bank_accounts ALTER COLUMN balance TYPE NUMERIC(22, 4);
+
+-- +goose Down
+ALTER TABLE bank_accounts ALTER COLUMN balance TYPE NUMERIC(20, 2);
@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS transactions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL,
+    amount NUMERIC(22, 4) NOT NULL,
+    date DATE NOT NULL,
+    payee VARCHAR(255) NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    category VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_transactions_account_date ON transactions (account_id, date);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_date ON transactions (user_id, date);
+
+-- Текущие балансы существующих аккаунтов переносятся в историю корректировками
+INSERT INTO transactions (user_id, account_id, type, amount, date, note)
+SELECT user_id, id, 'adjustment', balance, created_at::date, 'Начальный баланс'
+FROM bank_accounts
+WHERE balance <> 0;
+
+-- +goose Down
+DROP TABLE IF EXISTS transactions;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS transfers (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    from_account_id INTEGER NOT NULL REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    to_account_id INTEGER NOT NULL REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    from_amount NUMERIC(22, 4) NOT NULL,
+    to_amount NUMERIC(22, 4) NOT NULL,
+    rate NUMERIC(30, 12) NOT NULL,
+    date DATE NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (from_account_id <> to_account_id),
+    CHECK (from_amount > 0 AND to_amount > 0 AND rate > 0)
+);
+CREATE INDEX IF NOT EXISTS idx_transfers_user_date ON transfers (user_id, date);
+
+ALTER TABLE transactions ADD COLUMN transfer_id INTEGER REFERENCES transfers(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer_id ON transactions (transfer_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_transactions_transfer_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS transfer_id;
+DROP TABLE IF EXISTS transfers;
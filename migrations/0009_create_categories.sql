@@ -0,0 +1,36 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('income', 'expense')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (parent_id IS NULL OR parent_id <> id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_user_parent_name ON categories (user_id, COALESCE(parent_id, 0), LOWER(name));
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+ALTER TABLE transactions ADD COLUMN category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_transactions_category_id ON transactions (category_id);
+
+-- Текстовые категории существующих операций переносятся в справочник категорий пользователя
+INSERT INTO categories (user_id, name, kind)
+SELECT DISTINCT ON (user_id, LOWER(category)) user_id, category, CASE WHEN type = 'income' THEN 'income' ELSE 'expense' END
+FROM transactions
+WHERE category <> ''
+ORDER BY user_id, LOWER(category), type;
+
+UPDATE transactions t SET category_id = c.id
+FROM categories c
+WHERE c.user_id = t.user_id AND c.parent_id IS NULL AND LOWER(c.name) = LOWER(t.category) AND t.category <> '';
+
+ALTER TABLE transactions DROP COLUMN category;
+
+-- +goose Down
+ALTER TABLE transactions ADD COLUMN category VARCHAR(255) NOT NULL DEFAULT '';
+UPDATE transactions t SET category = c.name FROM categories c WHERE c.id = t.category_id;
+DROP INDEX IF EXISTS idx_transactions_category_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS category_id;
+DROP TABLE IF EXISTS categories;
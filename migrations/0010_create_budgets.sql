@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS budgets (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    period VARCHAR(10) NOT NULL CHECK (period IN ('week', 'month', 'quarter', 'year')),
+    currency VARCHAR(10) NOT NULL,
+    start_date DATE NOT NULL,
+    rollover BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_budgets_user_id ON budgets (user_id);
+
+CREATE TABLE IF NOT EXISTS budget_limits (
+    budget_id INTEGER NOT NULL REFERENCES budgets(id) ON DELETE CASCADE,
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    amount NUMERIC(22,4) NOT NULL CHECK (amount > 0),
+    PRIMARY KEY (budget_id, category_id)
+);
+CREATE INDEX IF NOT EXISTS idx_budget_limits_category_id ON budget_limits (category_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS budget_limits;
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    delimiter VARCHAR(1) NOT NULL DEFAULT ',',
+    encoding VARCHAR(20) NOT NULL DEFAULT 'utf-8',
+    skip_rows INTEGER NOT NULL DEFAULT 0 CHECK (skip_rows >= 0),
+    date_format VARCHAR(20) NOT NULL,
+    decimal_separator VARCHAR(1) NOT NULL DEFAULT '.',
+    sign_convention VARCHAR(20) NOT NULL CHECK (sign_convention IN ('signed', 'inverted', 'debit_credit')),
+    columns JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_import_profiles_user_name ON import_profiles (user_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS import_batches (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    format VARCHAR(20) NOT NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL CHECK (status IN ('preview', 'committed')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    committed_at TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_import_batches_user_id ON import_batches (user_id);
+
+CREATE TABLE IF NOT EXISTS import_rows (
+    id SERIAL PRIMARY KEY,
+    batch_id INTEGER NOT NULL REFERENCES import_batches(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    date DATE,
+    amount NUMERIC(22,4),
+    payee VARCHAR(255) NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_import_rows_batch_id ON import_rows (batch_id, line);
+
+-- +goose Down
+DROP TABLE IF EXISTS import_rows;
+DROP TABLE IF EXISTS import_batches;
+DROP TABLE IF EXISTS import_profiles;
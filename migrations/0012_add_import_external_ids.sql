@@ -0,0 +1,17 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN external_id VARCHAR(255);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_account_external_id ON transactions (account_id, external_id) WHERE external_id IS NOT NULL;
+
+ALTER TABLE import_rows ADD COLUMN external_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE import_rows ADD COLUMN already_imported BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE import_batches ADD COLUMN closing_balance NUMERIC(22,4);
+ALTER TABLE import_batches ADD COLUMN closing_balance_date DATE;
+
+-- +goose Down
+ALTER TABLE import_batches DROP COLUMN IF EXISTS closing_balance_date;
+ALTER TABLE import_batches DROP COLUMN IF EXISTS closing_balance;
+ALTER TABLE import_rows DROP COLUMN IF EXISTS already_imported;
+ALTER TABLE import_rows DROP COLUMN IF EXISTS external_id;
+DROP INDEX IF EXISTS idx_transactions_account_external_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE bank_accounts ADD COLUMN iban VARCHAR(34);
+ALTER TABLE bank_accounts ADD COLUMN bic VARCHAR(11);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_accounts_user_iban ON bank_accounts (user_id, iban) WHERE iban IS NOT NULL;
+
+ALTER TABLE import_batches ADD COLUMN opening_balance NUMERIC(22,4);
+ALTER TABLE import_batches ADD COLUMN opening_balance_date DATE;
+
+-- +goose Down
+ALTER TABLE import_batches DROP COLUMN IF EXISTS opening_balance_date;
+ALTER TABLE import_batches DROP COLUMN IF EXISTS opening_balance;
+DROP INDEX IF EXISTS idx_bank_accounts_user_iban;
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS bic;
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS iban;
@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE import_rows ADD COLUMN duplicate_of INTEGER REFERENCES transactions(id) ON DELETE SET NULL;
+ALTER TABLE import_rows ADD COLUMN duplicate_score DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS transaction_merges (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    merged JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_transaction_merges_user_id ON transaction_merges (user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_transaction_merges_transaction_id ON transaction_merges (transaction_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS transaction_merges;
+ALTER TABLE import_rows DROP COLUMN IF EXISTS duplicate_score;
+ALTER TABLE import_rows DROP COLUMN IF EXISTS duplicate_of;
@@ -0,0 +1,28 @@
+-- +goose Up
+ALTER TABLE transactions ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS idx_transactions_tags ON transactions USING GIN (tags);
+
+CREATE TABLE IF NOT EXISTS rules (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    payee_pattern TEXT NOT NULL DEFAULT '',
+    note_pattern TEXT NOT NULL DEFAULT '',
+    min_amount NUMERIC(22,4),
+    max_amount NUMERIC(22,4),
+    account_id INTEGER REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    type VARCHAR(10) NOT NULL DEFAULT '' CHECK (type IN ('', 'income', 'expense')),
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    rename_payee VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_rules_user_position ON rules (user_id, position);
+
+-- +goose Down
+DROP TABLE IF EXISTS rules;
+DROP INDEX IF EXISTS idx_transactions_tags;
+ALTER TABLE transactions DROP COLUMN IF EXISTS tags;
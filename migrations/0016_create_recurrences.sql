@@ -0,0 +1,44 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS recurrences (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    type VARCHAR(10) NOT NULL CHECK (type IN ('income', 'expense')),
+    amount NUMERIC(22,4) NOT NULL CHECK (amount > 0),
+    payee VARCHAR(255) NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    category_id INTEGER REFERENCES categories(id) ON DELETE SET NULL,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    frequency VARCHAR(10) NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    repeat_interval INTEGER NOT NULL DEFAULT 1 CHECK (repeat_interval > 0),
+    month_day INTEGER NOT NULL DEFAULT 0 CHECK (month_day BETWEEN -1 AND 31),
+    business_day VARCHAR(10) NOT NULL DEFAULT 'none' CHECK (business_day IN ('none', 'previous', 'next')),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    mode VARCHAR(10) NOT NULL CHECK (mode IN ('auto', 'suggest')),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    next_date DATE,
+    next_due DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_recurrences_user_id ON recurrences (user_id);
+CREATE INDEX IF NOT EXISTS idx_recurrences_next_due ON recurrences (next_due) WHERE active AND next_due IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS recurrence_occurrences (
+    id SERIAL PRIMARY KEY,
+    recurrence_id INTEGER NOT NULL REFERENCES recurrences(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    due_date DATE NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('pending', 'posted', 'skipped')),
+    transaction_id INTEGER REFERENCES transactions(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (recurrence_id, date)
+);
+CREATE INDEX IF NOT EXISTS idx_recurrence_occurrences_user_status ON recurrence_occurrences (user_id, status, due_date);
+
+-- +goose Down
+DROP TABLE IF EXISTS recurrence_occurrences;
+DROP TABLE IF EXISTS recurrences;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    base VARCHAR(10) NOT NULL,
+    quote VARCHAR(10) NOT NULL,
+    date DATE NOT NULL,
+    rate NUMERIC(30,12) NOT NULL CHECK (rate > 0),
+    source VARCHAR(50) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (base, quote, date),
+    CHECK (base <> quote)
+);
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_source_date ON exchange_rates (source, date);
+
+-- +goose Down
+DROP TABLE IF EXISTS exchange_rates;
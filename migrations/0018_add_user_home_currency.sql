@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE users ADD COLUMN IF NOT EXISTS home_currency VARCHAR(10) NOT NULL DEFAULT 'RUB';
+
+-- +goose Down
+ALTER TABLE users DROP COLUMN IF EXISTS home_currency;
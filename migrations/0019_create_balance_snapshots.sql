@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS balance_snapshots (
+    account_id INTEGER NOT NULL REFERENCES bank_accounts(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    balance NUMERIC(22,4) NOT NULL,
+    currency VARCHAR(10) NOT NULL,
+    PRIMARY KEY (account_id, date)
+);
+CREATE INDEX IF NOT EXISTS idx_balance_snapshots_user_date ON balance_snapshots (user_id, date);
+
+-- История до появления снимков восстанавливается из операций: баланс на дату равен сумме операций по эту дату
+INSERT INTO balance_snapshots (account_id, user_id, date, balance, currency)
+SELECT d.account_id, d.user_id, d.date, SUM(d.amount) OVER (PARTITION BY d.account_id ORDER BY d.date), a.currency
+FROM (
+    SELECT account_id, user_id, date, SUM(amount) AS amount
+    FROM transactions
+    WHERE date <= CURRENT_DATE
+    GROUP BY account_id, user_id, date
+) d
+JOIN bank_accounts a ON a.id = d.account_id
+ON CONFLICT (account_id, date) DO NOTHING;
+
+-- +goose Down
+DROP TABLE IF EXISTS balance_snapshots;
@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE bank_accounts
+    ADD COLUMN IF NOT EXISTS type VARCHAR(20) NOT NULL DEFAULT 'checking',
+    ADD COLUMN IF NOT EXISTS credit_limit NUMERIC(22,4) CHECK (credit_limit >= 0),
+    ADD COLUMN IF NOT EXISTS statement_day SMALLINT CHECK (statement_day BETWEEN 1 AND 31),
+    ADD COLUMN IF NOT EXISTS interest_rate NUMERIC(7,4) CHECK (interest_rate >= 0);
+
+-- +goose Down
+ALTER TABLE bank_accounts
+    DROP COLUMN IF EXISTS interest_rate,
+    DROP COLUMN IF EXISTS statement_day,
+    DROP COLUMN IF EXISTS credit_limit,
+    DROP COLUMN IF EXISTS type;
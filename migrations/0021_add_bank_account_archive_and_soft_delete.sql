@@ -0,0 +1,18 @@
+-- +goose Up
+ALTER TABLE bank_accounts
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_bank_accounts_deleted_at ON bank_accounts (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Удалённый аккаунт не занимает IBAN, пока его можно восстановить
+DROP INDEX IF EXISTS idx_bank_accounts_user_iban;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_accounts_user_iban ON bank_accounts (user_id, iban) WHERE iban IS NOT NULL AND deleted_at IS NULL;
+
+-- +goose Down
+DELETE FROM bank_accounts WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_bank_accounts_user_iban;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bank_accounts_user_iban ON bank_accounts (user_id, iban) WHERE iban IS NOT NULL;
+DROP INDEX IF EXISTS idx_bank_accounts_deleted_at;
+ALTER TABLE bank_accounts
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS archived_at;
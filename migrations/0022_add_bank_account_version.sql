@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE bank_accounts ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE bank_accounts DROP COLUMN IF EXISTS version;
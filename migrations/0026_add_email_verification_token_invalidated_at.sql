@@ -0,0 +1,7 @@
+-- +goose Up
+-- Токены, заменённые более новым письмом, не удаляются, а помечаются: по ним считается суточный лимит писем
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS invalidated_at TIMESTAMP;
+
+-- +goose Down
+DELETE FROM email_verification_tokens WHERE invalidated_at IS NOT NULL;
+ALTER TABLE email_verification_tokens DROP COLUMN IF EXISTS invalidated_at;
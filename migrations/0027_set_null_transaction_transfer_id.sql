@@ -0,0 +1,11 @@
+-- +goose Up
+-- Удаление перевода вместе с одним из аккаунтов не должно удалять операцию на другом аккаунте:
+-- иначе баланс другого аккаунта перестаёт совпадать с суммой его операций
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transfer_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transfer_id_fkey
+    FOREIGN KEY (transfer_id) REFERENCES transfers(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE transactions DROP CONSTRAINT IF EXISTS transactions_transfer_id_fkey;
+ALTER TABLE transactions ADD CONSTRAINT transactions_transfer_id_fkey
+    FOREIGN KEY (transfer_id) REFERENCES transfers(id) ON DELETE CASCADE;